@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_checklist_items
+(
+    id         BIGINT PRIMARY KEY,
+    task_id    BIGINT       NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    title      VARCHAR(255) NOT NULL,
+    done       BOOLEAN      NOT NULL DEFAULT FALSE,
+    position   BIGINT       NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE task_checklist_items_sequence start 1;
+CREATE INDEX IF NOT EXISTS task_checklist_items_task_id_idx ON task_checklist_items USING btree (task_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_checklist_items_task_id_idx;
+DROP SEQUENCE task_checklist_items_sequence;
+DROP TABLE task_checklist_items;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE SEQUENCE task_history_sequence start 1;
+CREATE table IF NOT EXISTS task_history
+(
+    id         BIGINT PRIMARY KEY DEFAULT nextval('task_history_sequence'),
+    task_id    BIGINT       NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id    BIGINT       NOT NULL REFERENCES users (id),
+    action     VARCHAR(255) NOT NULL,
+    old_value  TEXT         NOT NULL DEFAULT '',
+    new_value  TEXT         NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS task_history_task_id_idx ON task_history USING btree (task_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_history_task_id_idx;
+DROP TABLE task_history;
+DROP SEQUENCE task_history_sequence;
+-- +goose StatementEnd
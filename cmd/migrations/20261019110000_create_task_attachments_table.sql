@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_attachments
+(
+    id           BIGINT PRIMARY KEY,
+    task_id      BIGINT       NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id      BIGINT       NOT NULL REFERENCES users (id),
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size         BIGINT       NOT NULL,
+    storage_key  VARCHAR(512) NOT NULL,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE task_attachments_sequence start 1;
+CREATE INDEX IF NOT EXISTS task_attachments_task_id_idx ON task_attachments USING btree (task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_attachments_task_id_idx;
+DROP SEQUENCE task_attachments_sequence;
+DROP TABLE task_attachments;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_mentions
+(
+    task_id    BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users (id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+CREATE table IF NOT EXISTS task_references
+(
+    source_task_id BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    target_task_id BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (source_task_id, target_task_id)
+);
+CREATE INDEX IF NOT EXISTS task_references_target_task_id_idx ON task_references USING btree (target_task_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_references_target_task_id_idx;
+DROP TABLE task_references;
+DROP TABLE task_mentions;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS notifications
+(
+    id         BIGINT PRIMARY KEY,
+    user_id    BIGINT       NOT NULL REFERENCES users (id),
+    task_id    BIGINT       NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    event      VARCHAR(50)  NOT NULL,
+    message    VARCHAR(500) NOT NULL,
+    is_read    BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE notifications_sequence start 1;
+CREATE INDEX IF NOT EXISTS notifications_user_id_is_read_idx ON notifications USING btree (user_id, is_read);
+CREATE table IF NOT EXISTS notification_preferences
+(
+    user_id BIGINT      NOT NULL REFERENCES users (id),
+    event   VARCHAR(50) NOT NULL,
+    enabled BOOLEAN     NOT NULL,
+    PRIMARY KEY (user_id, event)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_preferences;
+DROP INDEX notifications_user_id_is_read_idx;
+DROP SEQUENCE notifications_sequence;
+DROP TABLE notifications;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS webhooks
+(
+    id         BIGINT PRIMARY KEY,
+    url        VARCHAR(2048) NOT NULL,
+    events     TEXT[]        NOT NULL,
+    secret     VARCHAR(255)  NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE webhooks_sequence start 1;
+CREATE table IF NOT EXISTS webhook_deliveries
+(
+    id              BIGINT PRIMARY KEY,
+    webhook_id      BIGINT       NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event           VARCHAR(50)  NOT NULL,
+    payload         TEXT         NOT NULL,
+    status          VARCHAR(20)  NOT NULL,
+    attempts        INT          NOT NULL DEFAULT 0,
+    response_status INT          NOT NULL DEFAULT 0,
+    last_error      VARCHAR(500) NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP    NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE webhook_deliveries_sequence start 1;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries USING btree (webhook_id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_status_next_attempt_at_idx
+    ON webhook_deliveries USING btree (status, next_attempt_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX webhook_deliveries_status_next_attempt_at_idx;
+DROP INDEX webhook_deliveries_webhook_id_idx;
+DROP SEQUENCE webhook_deliveries_sequence;
+DROP TABLE webhook_deliveries;
+DROP SEQUENCE webhooks_sequence;
+DROP TABLE webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS outbox
+(
+    id           BIGINT PRIMARY KEY,
+    aggregate_id BIGINT       NOT NULL,
+    event        VARCHAR(50)  NOT NULL,
+    payload      TEXT         NOT NULL,
+    status       VARCHAR(20)  NOT NULL,
+    attempts     INT          NOT NULL DEFAULT 0,
+    last_error   VARCHAR(500) NOT NULL DEFAULT '',
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE outbox_sequence start 1;
+CREATE INDEX IF NOT EXISTS outbox_status_id_idx ON outbox USING btree (status, id);
+CREATE table IF NOT EXISTS outbox_processed
+(
+    handler  VARCHAR(100) NOT NULL,
+    event_id BIGINT       NOT NULL REFERENCES outbox (id) ON DELETE CASCADE,
+    PRIMARY KEY (handler, event_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_processed;
+DROP INDEX outbox_status_id_idx;
+DROP SEQUENCE outbox_sequence;
+DROP TABLE outbox;
+-- +goose StatementEnd
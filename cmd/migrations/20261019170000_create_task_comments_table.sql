@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_comments
+(
+    id         BIGINT PRIMARY KEY,
+    task_id    BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id    BIGINT NOT NULL REFERENCES users (id),
+    body       TEXT   NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE task_comments_sequence start 1;
+CREATE INDEX IF NOT EXISTS task_comments_task_id_idx ON task_comments USING btree (task_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_comments_task_id_idx;
+DROP SEQUENCE task_comments_sequence;
+DROP TABLE task_comments;
+-- +goose StatementEnd
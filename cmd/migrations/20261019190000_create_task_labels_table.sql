@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_labels
+(
+    task_id BIGINT      NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label   VARCHAR(64) NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_labels;
+-- +goose StatementEnd
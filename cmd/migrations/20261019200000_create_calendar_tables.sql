@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_at TIMESTAMP NULL;
+CREATE table IF NOT EXISTS task_watchers
+(
+    task_id BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, user_id)
+);
+CREATE table IF NOT EXISTS calendar_feeds
+(
+    user_id    BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token      VARCHAR(64) NOT NULL UNIQUE,
+    all_tasks  BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE calendar_feeds;
+DROP TABLE task_watchers;
+ALTER TABLE tasks DROP COLUMN due_at;
+-- +goose StatementEnd
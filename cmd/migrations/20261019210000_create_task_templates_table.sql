@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_templates
+(
+    id          BIGINT PRIMARY KEY,
+    name        VARCHAR(128) NOT NULL UNIQUE,
+    title       VARCHAR(255) NOT NULL,
+    description TEXT         NOT NULL,
+    priority    INT          NOT NULL,
+    labels      TEXT[]       NOT NULL DEFAULT '{}',
+    checklist   TEXT[]       NOT NULL DEFAULT '{}',
+    visibility  VARCHAR(16)  NOT NULL DEFAULT 'ALL',
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE SEQUENCE task_templates_sequence start 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP SEQUENCE task_templates_sequence;
+DROP TABLE task_templates;
+-- +goose StatementEnd
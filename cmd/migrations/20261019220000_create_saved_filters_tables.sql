@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS saved_filters
+(
+    id         BIGINT PRIMARY KEY,
+    user_id    BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       VARCHAR(128) NOT NULL,
+    filter     JSONB        NOT NULL,
+    visibility VARCHAR(16)  NOT NULL DEFAULT 'PRIVATE',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+CREATE SEQUENCE saved_filters_sequence start 1;
+CREATE table IF NOT EXISTS saved_filter_settings
+(
+    filter_id BIGINT  NOT NULL REFERENCES saved_filters (id) ON DELETE CASCADE,
+    user_id   BIGINT  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    pinned    BOOLEAN NOT NULL DEFAULT FALSE,
+    notify    BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (filter_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS saved_filter_settings_notify_idx ON saved_filter_settings USING btree (filter_id)
+    WHERE notify;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX saved_filter_settings_notify_idx;
+DROP TABLE saved_filter_settings;
+DROP SEQUENCE saved_filters_sequence;
+DROP TABLE saved_filters;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_status_history
+(
+    event_id   BIGINT PRIMARY KEY,
+    task_id    BIGINT       NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    old_status VARCHAR(255) NOT NULL DEFAULT '',
+    new_status VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS task_status_history_task_id_idx ON task_status_history USING btree (task_id, changed_at);
+CREATE INDEX IF NOT EXISTS task_status_history_new_status_idx
+    ON task_status_history USING btree (new_status, changed_at);
+INSERT INTO task_status_history (event_id, task_id, old_status, new_status, changed_at)
+SELECT outbox.id,
+       outbox.aggregate_id,
+       COALESCE(outbox.payload::jsonb ->> 'previousStatus', ''),
+       outbox.payload::jsonb -> 'task' ->> 'status',
+       outbox.created_at
+FROM outbox
+         JOIN tasks ON tasks.id = outbox.aggregate_id
+WHERE outbox.event = 'task.created'
+   OR outbox.event = 'task.updated'
+    AND outbox.payload::jsonb ->> 'previousStatus' <> outbox.payload::jsonb -> 'task' ->> 'status';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_status_history_new_status_idx;
+DROP INDEX task_status_history_task_id_idx;
+DROP TABLE task_status_history;
+-- +goose StatementEnd
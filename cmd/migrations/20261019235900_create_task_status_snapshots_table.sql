@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_status_snapshots
+(
+    day     DATE         NOT NULL,
+    user_id BIGINT       NOT NULL,
+    status  VARCHAR(255) NOT NULL,
+    tasks   INT          NOT NULL,
+    PRIMARY KEY (day, user_id, status)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_status_snapshots;
+-- +goose StatementEnd
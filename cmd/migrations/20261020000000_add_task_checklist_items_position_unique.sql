@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE task_checklist_items AS items
+SET position = numbered.position
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY task_id ORDER BY position, id) AS position
+      FROM task_checklist_items) AS numbered
+WHERE items.id = numbered.id
+  AND items.position <> numbered.position;
+DROP INDEX task_checklist_items_task_id_idx;
+ALTER TABLE task_checklist_items
+    ADD CONSTRAINT task_checklist_items_task_id_position_key UNIQUE (task_id, position) DEFERRABLE INITIALLY IMMEDIATE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE task_checklist_items DROP CONSTRAINT task_checklist_items_task_id_position_key;
+CREATE INDEX IF NOT EXISTS task_checklist_items_task_id_idx ON task_checklist_items USING btree (task_id, position);
+-- +goose StatementEnd
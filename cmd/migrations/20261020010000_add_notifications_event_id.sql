@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS event_id BIGINT NOT NULL DEFAULT 0;
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_event_id_user_id_event_idx ON notifications USING btree (event_id, user_id, event)
+    WHERE event_id <> 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX notifications_event_id_user_id_event_idx;
+ALTER TABLE notifications DROP COLUMN event_id;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id BIGINT NOT NULL DEFAULT 0;
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_event_id_webhook_id_idx
+    ON webhook_deliveries USING btree (event_id, webhook_id) WHERE event_id <> 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX webhook_deliveries_event_id_webhook_id_idx;
+ALTER TABLE webhook_deliveries DROP COLUMN event_id;
+-- +goose StatementEnd
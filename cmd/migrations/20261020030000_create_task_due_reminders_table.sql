@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE table IF NOT EXISTS task_due_reminders
+(
+    task_id    BIGINT    NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    due_at     TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, due_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE task_due_reminders;
+-- +goose StatementEnd
@@ -3,18 +3,37 @@ package main
 import (
 	"context"
 	"embed"
+	"errors"
+	"flag"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/romakorinenko/task-manager/configs"
 	_ "github.com/romakorinenko/task-manager/docs"
+	"github.com/romakorinenko/task-manager/internal/backup"
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/config"
 	"github.com/romakorinenko/task-manager/internal/controller"
 	"github.com/romakorinenko/task-manager/internal/dbpool"
+	"github.com/romakorinenko/task-manager/internal/email"
+	"github.com/romakorinenko/task-manager/internal/live"
+	"github.com/romakorinenko/task-manager/internal/logging"
+	"github.com/romakorinenko/task-manager/internal/metrics"
+	"github.com/romakorinenko/task-manager/internal/outbox"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	"github.com/romakorinenko/task-manager/internal/server"
 	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/internal/storage"
+	"github.com/romakorinenko/task-manager/internal/tracing"
+	"github.com/romakorinenko/task-manager/internal/webhook"
 )
 
 // @title Task Manager API
@@ -26,22 +45,294 @@ import (
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
+// restoreCommand - подкоманда восстановления данных из резервной копии: task_manager restore -file <архив>.
+const restoreCommand = "restore"
+
 func main() {
 	cfg := configs.MustLoadConfig()
 
+	logger, err := logging.NewLogger(os.Stdout, cfg.Logging)
+	if err != nil {
+		log.Fatalln("cannot create logger", err)
+	}
+	slog.SetDefault(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	var workers sync.WaitGroup
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalln("cannot init tracing", err)
+	}
 	dbPool, err := dbpool.NewDBPool(context.Background(), cfg.DB)
 	if err != nil {
 		log.Fatalln("cannot create dbPool", err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == restoreCommand {
+		if restoreErr := RestoreData(cfg, dbPool, os.Args[2:]); restoreErr != nil {
+			log.Fatalln("cannot restore data", restoreErr)
+		}
+		log.Println("data restored")
+		return
+	}
+
 	MigrateData(dbPool)
+	prometheus.MustRegister(
+		metrics.NewTaskCollector(repository.NewReportRepo(dbPool)),
+		metrics.NewDBPoolCollector(dbPool),
+	)
+
+	attachmentStorage, err := storage.NewStorage(context.Background(), cfg.Attachments)
+	if err != nil {
+		log.Fatalln("cannot create attachments storage", err)
+	}
 
-	userService := service.NewUserService(repository.NewUserRepo(dbPool))
-	taskService := service.NewTaskService(repository.NewTaskRepo(dbPool), repository.NewUserRepo(dbPool))
+	webhookService := service.NewWebhookService(
+		repository.NewWebhookRepo(dbPool),
+		webhook.NewHTTPSender(cfg.Webhooks.Timeout),
+		cfg.Webhooks,
+	)
+	runWorker(ctx, &workers, webhookService.RunDeliveries)
+	userService := service.NewUserService(repository.NewUserRepo(dbPool), webhookService)
+	emailQueue := email.NewQueue(
+		email.NewSMTPSender(cfg.Email),
+		cfg.Email.QueueSize,
+		cfg.Email.MaxAttempts,
+		cfg.Email.RetryBackoff,
+	)
+	emailQueue.Start()
+	emailService := service.NewEmailService(
+		repository.NewUserRepo(dbPool),
+		repository.NewNotificationRepo(dbPool),
+		emailQueue,
+		cfg.Email,
+	)
+	runWorker(ctx, &workers, emailService.RunDigests)
+	notificationService := service.NewNotificationService(repository.NewNotificationRepo(dbPool), emailService)
+	dueReminderService := service.NewDueReminderService(
+		repository.NewTaskRepo(dbPool),
+		repository.NewTaskReminderRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		notificationService,
+		cfg.Reminders,
+	)
+	runWorker(ctx, &workers, dueReminderService.RunReminders)
+	savedFilterService := service.NewSavedFilterService(repository.NewSavedFilterRepo(dbPool))
+	attachmentService := service.NewAttachmentService(
+		repository.NewAttachmentRepo(dbPool),
+		repository.NewTaskRepo(dbPool),
+		attachmentStorage,
+		cfg.Attachments,
+	)
+	taskService := service.NewTaskService(
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		repository.NewOutboxRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		attachmentService,
+	)
+	taskStreamBroker := live.NewBroker(cfg.Stream.BufferSize)
+	taskStreamService := service.NewTaskStreamService(
+		taskStreamBroker,
+		repository.NewOutboxRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		cfg.Stream,
+	)
+	collabHub := collab.NewHub(cfg.Stream.BufferSize)
+	collabService := service.NewCollabService(
+		collabHub,
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+	)
+	outboxDispatcher := outbox.NewDispatcher(
+		repository.NewOutboxRepo(dbPool),
+		cfg.Outbox,
+		taskStreamService,
+		collabService,
+		service.NewTaskNotificationHandler(notificationService, repository.NewWatcherRepo(dbPool)),
+		service.NewTaskWebhookHandler(webhookService),
+		service.NewTaskStatusHistoryHandler(repository.NewTaskStatusHistoryRepo(dbPool)),
+		service.NewTaskMetricsHandler(),
+		service.NewSavedFilterNotificationHandler(
+			repository.NewSavedFilterRepo(dbPool),
+			repository.NewTaskRepo(dbPool),
+			repository.NewUserRepo(dbPool),
+			notificationService,
+		),
+	)
+	runWorker(ctx, &workers, outboxDispatcher.Run)
+	checklistService := service.NewChecklistService(
+		repository.NewChecklistRepo(dbPool),
+		repository.NewTaskRepo(dbPool),
+		repository.NewTaskHistoryRepo(dbPool),
+	)
+	mentionService := service.NewMentionService(
+		repository.NewMentionRepo(dbPool),
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		notificationService,
+	)
+	commentService := service.NewCommentService(
+		repository.NewCommentRepo(dbPool),
+		repository.NewTaskRepo(dbPool),
+		collabHub,
+		notificationService,
+		mentionService,
+	)
+	taskBulkService := service.NewTaskBulkService(
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		repository.NewLabelRepo(dbPool),
+		repository.NewOutboxRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		attachmentService,
+	)
+	taskImportService := service.NewTaskImportService(
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		repository.NewOutboxRepo(dbPool),
+		repository.NewTransactor(dbPool),
+	)
+	taskExportService := service.NewTaskExportService(repository.NewTaskRepo(dbPool))
+	backupService := service.NewBackupService(
+		repository.NewBackupRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		attachmentStorage,
+	)
+	calendarService := service.NewCalendarService(
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		repository.NewWatcherRepo(dbPool),
+		repository.NewCalendarRepo(dbPool),
+		cfg.Calendar,
+	)
+	taskTemplateService := service.NewTaskTemplateService(
+		repository.NewTaskTemplateRepo(dbPool),
+		repository.NewTaskRepo(dbPool),
+		repository.NewUserRepo(dbPool),
+		repository.NewLabelRepo(dbPool),
+		repository.NewChecklistRepo(dbPool),
+		repository.NewOutboxRepo(dbPool),
+		repository.NewTransactor(dbPool),
+	)
 	userController := controller.NewUserController(userService)
-	taskController := controller.NewTaskController(taskService, userService)
+	taskController := controller.NewTaskController(
+		taskService,
+		userService,
+		checklistService,
+		attachmentService,
+		mentionService,
+		commentService,
+		savedFilterService,
+	)
+	checklistController := controller.NewChecklistController(checklistService)
+	attachmentController := controller.NewAttachmentController(attachmentService)
+	notificationController := controller.NewNotificationController(notificationService, emailService)
+	webhookController := controller.NewWebhookController(webhookService)
+	taskStreamController := controller.NewTaskStreamController(taskStreamService, cfg.Stream.HeartbeatInterval)
+	commentController := controller.NewCommentController(commentService)
+	collabController := controller.NewCollabController(collabService)
+	taskBulkController := controller.NewTaskBulkController(taskBulkService, savedFilterService)
+	taskImportController := controller.NewTaskImportController(taskImportService)
+	taskExportController := controller.NewTaskExportController(taskExportService, savedFilterService)
+	calendarController := controller.NewCalendarController(calendarService)
+	backupController := controller.NewBackupController(backupService)
+	taskTemplateController := controller.NewTaskTemplateController(taskTemplateService, userService, mentionService)
+	savedFilterController := controller.NewSavedFilterController(savedFilterService)
+	reportController := controller.NewReportController(service.NewReportService(repository.NewReportRepo(dbPool)))
+	chartService := service.NewChartService(
+		repository.NewTaskSnapshotRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		cfg.Charts,
+	)
+	runWorker(ctx, &workers, chartService.RunSnapshots)
+	chartController := controller.NewChartController(chartService)
+	schemaVersion, err := LatestSchemaVersion()
+	if err != nil {
+		log.Fatalln("cannot read migrations", err)
+	}
+	healthController := controller.NewHealthController(
+		service.NewHealthService(repository.NewHealthRepo(dbPool), schemaVersion, cfg.Health),
+	)
+
+	server.RegisterServerAndHandlers(
+		userController,
+		taskController,
+		checklistController,
+		attachmentController,
+		notificationController,
+		webhookController,
+		taskStreamController,
+		commentController,
+		collabController,
+		taskBulkController,
+		taskImportController,
+		taskExportController,
+		calendarController,
+		backupController,
+		taskTemplateController,
+		savedFilterController,
+		reportController,
+		chartController,
+		healthController,
+	)
 
-	server.RegisterServerAndHandlers(userController, taskController, cfg.Server.Port)
+	// потоки событий и websocket сами не завершаются, поэтому при остановке сервера подписчики отключаются.
+	if err = server.Serve(ctx, cfg.Server, taskStreamBroker.Close, collabHub.Close); err != nil {
+		slog.Error("server closed", slog.Any("error", err))
+	}
+	stop()
+	Shutdown(cfg.Server, &workers, emailQueue, shutdownTracing, dbPool)
+}
+
+// runWorker запускает фоновую задачу run до отмены ctx и учитывает её в workers, чтобы при остановке
+// дождаться её завершения.
+func runWorker(ctx context.Context, workers *sync.WaitGroup, run func(ctx context.Context)) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		run(ctx)
+	}()
+}
+
+// Shutdown освобождает ресурсы после остановки HTTP-сервера: ждёт завершения фоновых задач и отправки писем
+// из очереди, отправляет накопленные трейсы и закрывает пул соединений с базой. Всё это ждётся не дольше
+// cfg.ShutdownTimeout; если фоновые задачи не успели завершиться, пул не закрывается, потому что закрытие
+// ждёт возврата всех соединений.
+func Shutdown(
+	cfg *config.Server,
+	workers *sync.WaitGroup,
+	emailQueue *email.Queue,
+	shutdownTracing func(ctx context.Context) error,
+	dbPool *pgxpool.Pool,
+) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		workers.Wait()
+		// сводки ставят письма в очередь, поэтому она закрывается после фоновых задач.
+		emailQueue.Close()
+	}()
+
+	workersStopped := true
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		workersStopped = false
+		slog.Warn("background workers did not stop before shutdown timeout")
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("cannot flush traces", slog.Any("error", err))
+	}
+	if workersStopped {
+		dbPool.Close()
+	}
+	slog.Info("server stopped")
 }
 
 func MigrateData(dbPool *pgxpool.Pool) {
@@ -60,3 +351,69 @@ func MigrateData(dbPool *pgxpool.Pool) {
 		log.Fatalln("cannot migrate data", migrationsErr)
 	}
 }
+
+// RestoreData восстанавливает данные из резервной копии в пустую базу: проверяет манифест архива, применяет
+// миграции и только потом загружает данные.
+func RestoreData(cfg *config.Config, dbPool *pgxpool.Pool, args []string) error {
+	flags := flag.NewFlagSet(restoreCommand, flag.ExitOnError)
+	fileName := flags.String("file", "", "путь к архиву резервной копии")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *fileName == "" {
+		return errors.New("backup file is required: restore -file <archive>")
+	}
+
+	archiveFile, err := os.Open(*fileName)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	archiveInfo, err := archiveFile.Stat()
+	if err != nil {
+		return err
+	}
+	archive, err := backup.NewReader(archiveFile, archiveInfo.Size())
+	if err != nil {
+		return err
+	}
+
+	attachmentStorage, err := storage.NewStorage(context.Background(), cfg.Attachments)
+	if err != nil {
+		return err
+	}
+	backupService := service.NewBackupService(
+		repository.NewBackupRepo(dbPool),
+		repository.NewTransactor(dbPool),
+		attachmentStorage,
+	)
+
+	schemaVersion, err := LatestSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if err = backupService.Validate(archive.Manifest(), schemaVersion); err != nil {
+		return err
+	}
+
+	MigrateData(dbPool)
+
+	return backupService.Restore(context.Background(), archive)
+}
+
+// LatestSchemaVersion возвращает версию последней миграции, встроенной в приложение.
+func LatestSchemaVersion() (int64, error) {
+	goose.SetBaseFS(embedMigrations)
+	migrations, err := goose.CollectMigrations("migrations", 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	lastMigration, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+
+	return lastMigration.Version, nil
+}
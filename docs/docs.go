@@ -41,40 +41,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "аутентификация пользователя и создание сессии",
-                "consumes": [
-                    "application/x-www-form-urlencoded"
-                ],
+        "/backup": {
+            "get": {
+                "description": "выгружает zip-архив со всеми данными приложения: manifest.json с версиями формата и схемы базы,\nпо файлу JSON Lines на каждую таблицу и файлы вложений. Восстанавливается командой\ntask_manager restore -file \u003cархив\u003e в пустую базу. Только для администраторов",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "users"
-                ],
-                "summary": "User Login",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Username",
-                        "name": "username",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Password",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "backup"
                 ],
+                "summary": "Download backup",
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ResponseMap"
+                            "type": "file"
                         }
                     },
                     "401": {
@@ -83,6 +64,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -92,21 +79,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/logout": {
+        "/calendar": {
             "get": {
-                "description": "завершает сессию пользователя и открывает страницу для логина",
+                "description": "возвращает адрес календаря со сроками задач текущего пользователя для подписки в календарном приложении",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "calendar"
                 ],
-                "summary": "User Logout",
+                "summary": "Get calendar feed",
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/service.CalendarFeedInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
                     "500": {
@@ -118,24 +117,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
-            "get": {
-                "description": "возвращает список задач в зависимости от роли:\nдля администраторов - все задачи, для пользователей - задачи пользователя",
+        "/calendar/token": {
+            "post": {
+                "description": "выпускает новый секретный адрес календаря текущего пользователя, прежний адрес перестаёт работать.\nAllTasks=true - календарь со сроками задач всех пользователей, только для администраторов.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pages"
+                    "calendar"
+                ],
+                "summary": "Rotate calendar token",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Календарь со всеми задачами",
+                        "name": "AllTasks",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Get All Tasks",
                 "responses": {
                     "200": {
-                        "description": "List of tasks",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/repository.Task"
-                            }
+                            "$ref": "#/definitions/service.CalendarFeedInfo"
                         }
                     },
                     "400": {
@@ -150,6 +157,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -157,54 +170,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создаёт новую задачу с указанными параметрами: заголовком, описанием, приоритетом и пользователем.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/calendar/{token}": {
+            "get": {
+                "description": "отдаёт календарь iCalendar с задачами, у которых задан срок. Вход в систему не нужен, доступ даёт\nтокен в адресе. Календарь собирается при каждом запросе, поэтому отражает текущее состояние задач.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "tasks"
+                    "calendar"
                 ],
-                "summary": "Create task",
+                "summary": "Calendar feed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Заголовок задачи",
-                        "name": "Title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Описание задачи",
-                        "name": "Description",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Приоритет задачи (число)",
-                        "name": "Priority",
-                        "in": "formData",
+                        "description": "Токен календаря, можно с расширением .ics",
+                        "name": "token",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Логин пользователя, которому назначена задача",
-                        "name": "UserLogin",
-                        "in": "formData",
-                        "required": true
+                        "description": "event (по умолчанию) - события VEVENT, todo - задачи VTODO",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ResponseMap"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -213,6 +210,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -222,40 +225,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/by-priority/{priority}": {
+        "/filters": {
             "get": {
-                "description": "Возвращает список задач с указанным приоритетом. Только для администраторов",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "возвращает свои фильтры пользователя и фильтры с видимостью SHARED и GLOBAL\nс настройками пользователя: pinned - закреплён на странице задач, notify - подписка на уведомления",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks-admins"
-                ],
-                "summary": "Get Tasks by priority",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Приоритет задачи",
-                        "name": "priority",
-                        "in": "path",
-                        "required": true
-                    }
+                    "filters"
                 ],
+                "summary": "Get saved filters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/repository.Task"
+                                "$ref": "#/definitions/repository.SavedFilter"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
@@ -267,11 +258,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/by-status/{status}": {
-            "get": {
-                "description": "Возвращает список задач с указанным статусом. Статус должен быть одним из: OPEN, IN_PROGRESS или DONE.\nТолько для администраторов",
+            },
+            "post": {
+                "description": "сохраняет именованный фильтр задач: статус, приоритет, исполнитель, метки, текст и сортировка.\nВидимость: PRIVATE - только владельцу, SHARED - всем пользователям,\nGLOBAL - всем пользователям и закреплён у всех, только для администраторов.",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,26 +268,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tasks-admins"
+                    "filters"
                 ],
-                "summary": "Get Tasks by status",
+                "summary": "Create saved filter",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Статус задачи",
-                        "name": "status",
-                        "in": "path",
-                        "required": true
+                        "description": "Saved filter",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SavedFilterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/repository.Task"
-                            }
+                            "$ref": "#/definitions/repository.SavedFilter"
                         }
                     },
                     "400": {
@@ -307,36 +295,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
-                    }
-                }
-            }
-        },
-        "/tasks/create": {
-            "get": {
-                "description": "Отображает страницу создания шаблона задачи для пользователя в зависимости от его роли.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "text/html"
-                ],
-                "tags": [
-                    "pages"
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/dto.UsersTemplateData"
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
@@ -350,33 +322,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/user/{login}": {
-            "get": {
-                "description": "возвращает задачи пользователя по его логину",
+        "/filters/{id}": {
+            "put": {
+                "description": "заменяет имя, условия и видимость фильтра. Менять фильтр может владелец или администратор.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "filters"
                 ],
-                "summary": "Get Tasks by User Login",
+                "summary": "Update saved filter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User Login",
-                        "name": "login",
+                        "description": "Filter ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Saved filter",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SavedFilterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of tasks",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/repository.Task"
-                            }
+                            "$ref": "#/definitions/repository.SavedFilter"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
                     "500": {
@@ -386,22 +397,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/{id}": {
-            "get": {
-                "description": "возвращает задачу по идентификатору",
+            },
+            "delete": {
+                "description": "удаляет фильтр вместе с закреплениями и подписками. Удалять фильтр может владелец или администратор.",
                 "produces": [
-                    "text/html"
+                    "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "filters"
                 ],
-                "summary": "Get Task by ID",
+                "summary": "Delete saved filter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
+                        "description": "Filter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -411,7 +420,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/repository.TaskWithLogin"
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
                     "400": {
@@ -419,63 +428,111 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
-                    }
-                }
-            },
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/{id}/pin": {
             "post": {
-                "description": "обновляет задачу по идентификатору",
-                "consumes": [
-                    "application/x-www-form-urlencoded"
-                ],
+                "description": "закрепляет доступный пользователю фильтр на его странице задач",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "filters"
                 ],
-                "summary": "Update Task by ID",
+                "summary": "Pin saved filter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
+                        "description": "Filter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Task Title",
-                        "name": "Title",
-                        "in": "formData",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Task Description",
-                        "name": "Description",
-                        "in": "formData",
-                        "required": true
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Task Priority",
-                        "name": "Priority",
-                        "in": "formData",
-                        "required": true
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/{id}/subscribe": {
+            "post": {
+                "description": "подписывает пользователя на уведомления о созданных задачах и о задачах, у которых сменился статус\nили исполнитель, если задача подходит под фильтр и видна пользователю",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "filters"
+                ],
+                "summary": "Subscribe to saved filter",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Task Status",
-                        "name": "Status",
-                        "in": "formData",
+                        "description": "Filter ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
                     "400": {
@@ -484,6 +541,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -493,31 +562,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/delete": {
+        "/filters/{id}/unpin": {
             "post": {
-                "description": "Удаляет задачу по указанному идентификатору",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "открепляет фильтр от страницы задач пользователя. Фильтры GLOBAL остаются закреплёнными.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tasks"
+                    "filters"
                 ],
-                "summary": "Delete Task by ID",
+                "summary": "Unpin saved filter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
+                        "description": "Filter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
@@ -528,6 +594,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -537,19 +615,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}/edit": {
-            "get": {
-                "description": "отображает форму редактирования задачи по идентификатору",
+        "/filters/{id}/unsubscribe": {
+            "post": {
+                "description": "отписывает пользователя от уведомлений по фильтру",
                 "produces": [
-                    "text/html"
+                    "application/json"
                 ],
                 "tags": [
-                    "pages"
+                    "filters"
                 ],
+                "summary": "Unsubscribe from saved filter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
+                        "description": "Filter ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -559,7 +638,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/repository.TaskWithLogin"
+                            "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
                     "400": {
@@ -567,70 +646,73 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
                     }
                 }
             }
         },
-        "/users": {
+        "/health": {
             "get": {
-                "description": "возвращает список всех пользователей, только для администраторов",
+                "description": "возвращает проверки готовности с текстами ошибок, время работы, состояние пула соединений\nи число событий outbox в каждом статусе. Только для администраторов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "health"
                 ],
-                "summary": "Get All Users",
+                "summary": "Health report",
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/repository.User"
-                            }
+                            "$ref": "#/definitions/service.HealthReport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "создает нового пользователя, только для администраторов",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "всегда отвечает 200, пока процесс обрабатывает запросы; зависимости не проверяются.\nАутентификация не нужна",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-admins"
-                ],
-                "summary": "Create User",
-                "parameters": [
-                    {
-                        "description": "New User Data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/repository.User"
-                        }
-                    }
+                    "health"
                 ],
+                "summary": "Liveness probe",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ResponseMap"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
@@ -638,53 +720,3753 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/block": {
-            "put": {
-                "description": "блокирует пользователя по идентификатору, только для администраторов",
+        "/login": {
+            "post": {
+                "description": "аутентификация пользователя и создание сессии",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users-admins"
+                    "users"
                 ],
-                "summary": "Block User",
+                "summary": "User Login",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Username",
+                        "name": "username",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password",
+                        "name": "password",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "302": {
+                        "description": "Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ResponseMap"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ResponseMap": {
-            "type": "object",
-            "additionalProperties": {
-                "type": "string"
-            }
         },
-        "dto.UsersTemplateData": {
-            "type": "object",
-            "properties": {
-                "users": {
+        "/logout": {
+            "get": {
+                "description": "завершает сессию пользователя и открывает страницу для логина",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "User Logout",
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "отображает последние уведомления пользователя и настройки, о каких событиях уведомлять",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "summary": "Notifications inbox",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationsTemplateData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/email": {
+            "post": {
+                "description": "сохраняет адрес для писем с уведомлениями (пустой - не отправлять письма)\nи режим: сразу по каждому событию или ежедневной сводкой",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update email notification settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "E-mail",
+                        "name": "Email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Ежедневная сводка вместо отдельных писем",
+                        "name": "Digest",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "post": {
+                "description": "включает уведомления о переданных событиях и отключает остальные.\nТипы событий: TASK_ASSIGNED, TASK_STATUS_CHANGED, USER_MENTIONED, FILTER_MATCHED, COMMENT_ADDED,\nTASK_DUE_SOON",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Включённые типы событий",
+                        "name": "Events",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "description": "отмечает все уведомления текущего пользователя прочитанными",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "description": "возвращает количество непрочитанных уведомлений текущего пользователя",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Unread notifications count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnreadCountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{notificationId}/read": {
+            "post": {
+                "description": "отмечает уведомление текущего пользователя прочитанным",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "notificationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "проверяет, что база отвечает через пул соединений и миграции применены до версии, встроенной\nв приложение. Тексты ошибок не возвращаются, они пишутся в лог. Аутентификация не нужна",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.Readiness"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/service.Readiness"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "get": {
+                "description": "возвращает страницу со всеми отчётами по задачам за период.\nADMIN видит отчёты по всем задачам, остальные - по своим.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "summary": "Reports page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/aging": {
+            "get": {
+                "description": "возвращает число невыполненных задач, созданных 0-7, 8-30, 31-90 и больше 90 дней назад.\nADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Open task aging",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.AgingBucket"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/breakdown": {
+            "get": {
+                "description": "возвращает число задач в каждом статусе по исполнителям и по приоритетам.\nADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Task breakdown",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.TaskBreakdown"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/burndown": {
+            "get": {
+                "description": "возвращает по дням периода, сколько задач в статусах OPEN и IN_PROGRESS, и идеальную линию\nих выполнения к концу периода. Дни без снимка пропускаются.\nADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Burndown",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода, YYYY-MM-DD; по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.BurndownPoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/charts": {
+            "get": {
+                "description": "возвращает страницу с графиками сгорания задач и накопительным графиком потока, нарисованными в SVG.\nADMIN видит графики по всем задачам, остальные - по своим.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "summary": "Charts page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода, YYYY-MM-DD; по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/cumulative-flow": {
+            "get": {
+                "description": "возвращает по дням периода число задач в статусах OPEN, IN_PROGRESS и DONE.\nДни без снимка пропускаются. ADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Cumulative flow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода, YYYY-MM-DD; по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.CumulativeFlowPoint"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/cycle-time": {
+            "get": {
+                "description": "возвращает среднее время от открытия до выполнения задач, выполненных за период,\nпо истории статусов. ADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Cycle time",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.CycleTime"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/throughput": {
+            "get": {
+                "description": "возвращает по неделям за период, сколько задач создано и сколько переведено в DONE.\nНедели без задач возвращаются с нулями. ADMIN видит все задачи, остальные - свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Weekly throughput",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12",
+                        "name": "weeks",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.WeeklyThroughput"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "description": "возвращает список задач в зависимости от роли:\nдля администраторов - все задачи, для пользователей - задачи пользователя.\nЗадачи можно отобрать сохранённым фильтром filterId и условиями из параметров запроса,\nкоторые дополняют или заменяют условия сохранённого фильтра.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "summary": "Get All Tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Сохранённый фильтр",
+                        "name": "filterId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус задачи",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Приоритет задачи",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Логин исполнителя",
+                        "name": "userLogin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Метки, которые должны быть у задачи все одновременно",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст в названии или описании",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id, title, priority, status, createdAt, updatedAt или dueAt, с минусом - по убыванию",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tasks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт новую задачу с указанными параметрами: заголовком, описанием, приоритетом и пользователем.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Create task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Заголовок задачи",
+                        "name": "Title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Описание задачи",
+                        "name": "Description",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Приоритет задачи (число)",
+                        "name": "Priority",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Логин пользователя, которому назначена задача",
+                        "name": "UserLogin",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/bulk": {
+            "post": {
+                "description": "меняет статус, приоритет, исполнителя, добавляет метку или удаляет задачи из списка taskIds\nили, если список пуст, задачи по фильтру filter или сохранённому фильтру filterId.\nВсе изменения выполняются в одной транзакции.\nДля каждой задачи возвращается результат: OK, FORBIDDEN, NOT_FOUND или CONFLICT.\nПользователь, не являющийся ADMIN, может менять только свои задачи и не может назначать их на других.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Bulk task operation",
+                "parameters": [
+                    {
+                        "description": "Bulk operation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskBulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.TaskBulkResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/by-priority/{priority}": {
+            "get": {
+                "description": "Возвращает список задач с указанным приоритетом. Только для администраторов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-admins"
+                ],
+                "summary": "Get Tasks by priority",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Приоритет задачи",
+                        "name": "priority",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "html - дополнительно вернуть описание, отрендеренное из markdown",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/by-status/{status}": {
+            "get": {
+                "description": "Возвращает список задач с указанным статусом. Статус должен быть одним из: OPEN, IN_PROGRESS или DONE.\nТолько для администраторов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-admins"
+                ],
+                "summary": "Get Tasks by status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус задачи",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "html - дополнительно вернуть описание, отрендеренное из markdown",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Task"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/create": {
+            "get": {
+                "description": "Отображает страницу создания шаблона задачи для пользователя в зависимости от его роли.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UsersTemplateData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/events": {
+            "get": {
+                "description": "передаёт события task.created, task.updated и task.deleted по задачам, которые видит пользователь:\nADMIN - по всем, остальные - по своим. Идентификатор события передаётся в поле id; после\nпереподключения пропущенные события отдаются по заголовку Last-Event-ID или параметру lastEventId.\nЕсли пропущено слишком много событий, приходит событие reset и страницу нужно загрузить заново.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Task changes stream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID последнего полученного события, если заголовок нельзя передать",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/live.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/export": {
+            "get": {
+                "description": "выгружает задачи в CSV, JSON Lines или XLSX. Задачи читаются и отправляются по одной.\nВидимость как в списке задач: ADMIN выгружает все задачи, остальные - только свои.\nСтолбцы: id, title, description, priority, status, createdAt, updatedAt, dueAt, userLogin, labels,\nversion;\nпо умолчанию - все, кроме version.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Export tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (по умолчанию), json или xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Столбцы через запятую",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сохранённый фильтр",
+                        "name": "filterId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус задачи",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Приоритет задачи",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Логин исполнителя",
+                        "name": "userLogin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Метки, которые должны быть у задачи все одновременно",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст в названии или описании",
+                        "name": "text",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Столбец сортировки, с минусом - по убыванию",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/import": {
+            "post": {
+                "description": "импортирует задачи из CSV (первая строка - заголовки) или JSON (массив объектов), только для\nадминистраторов. Каждая запись проверяется по правилам создания задачи, исполнитель ищется по логину.\nЗадачи создаются одной транзакцией и только если ошибок нет; с DryRun=true файл только проверяется.\nОтвет содержит столбцы файла, по которым составляется Mapping, и ошибки по записям.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks-admins"
+                ],
+                "summary": "Import tasks",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл с задачами",
+                        "name": "File",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv или json, по умолчанию - по расширению файла",
+                        "name": "Format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сопоставление полей столбцам, например {\\",
+                        "name": "Mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл",
+                        "name": "DryRun",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл проверен",
+                        "schema": {
+                            "$ref": "#/definitions/service.TaskImportReport"
+                        }
+                    },
+                    "201": {
+                        "description": "Задачи созданы",
+                        "schema": {
+                            "$ref": "#/definitions/service.TaskImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "422": {
+                        "description": "В файле есть ошибки, задачи не созданы",
+                        "schema": {
+                            "$ref": "#/definitions/service.TaskImportReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/preview": {
+            "post": {
+                "description": "рендерит markdown описания задачи в безопасный HTML для превью в форме редактирования.\nУпоминания @login и #123 существующих пользователей и задач становятся ссылками.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Preview task description",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Описание задачи в markdown",
+                        "name": "Description",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/user/{login}": {
+            "get": {
+                "description": "возвращает задачи пользователя по его логину",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get Tasks by User Login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User Login",
+                        "name": "login",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "html - дополнительно вернуть описание, отрендеренное из markdown",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tasks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Task"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "description": "возвращает страницу задачи, а клиенту, который принимает только JSON (Accept: application/json), -\nзадачу в JSON. ETag ответа передаётся в If-Match при обновлении задачи.\nС ?render=html в JSON добавляется отрендеренное описание.",
+                "produces": [
+                    "text/html",
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Get Task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "html - добавить в JSON отрендеренное описание",
+                        "name": "render",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Task"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия задачи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "обновляет задачу по идентификатору. Ожидаемая версия задачи обязательна и передаётся заголовком\nIf-Match (значение ETag) или полем Version; без неё возвращается 428.\nЕсли задачу успели изменить, возвращается 409 с её текущим состоянием.\nETag ответа - версия задачи после обновления.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Update Task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag задачи",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task Title",
+                        "name": "Title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task Description",
+                        "name": "Description",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task Priority",
+                        "name": "Priority",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task Status",
+                        "name": "Status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task Version",
+                        "name": "Version",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия задачи после обновления"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskConflictResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments": {
+            "get": {
+                "description": "возвращает метаданные вложений задачи. Доступно владельцу задачи и администраторам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get task attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "загружает файл и прикрепляет его к задаче. Доступно владельцу задачи и администраторам.\nРазмер и допустимые типы файлов задаются в конфигурации.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "File",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments/{attachmentId}": {
+            "get": {
+                "description": "отдаёт содержимое вложения. Доступно владельцу задачи и администраторам.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/attachments/{attachmentId}/delete": {
+            "post": {
+                "description": "удаляет вложение задачи вместе с содержимым в хранилище",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist": {
+            "get": {
+                "description": "возвращает пункты чеклиста задачи в порядке их следования. Пользователю доступны только его задачи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Get task checklist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.ChecklistItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "добавляет пункт в конец чеклиста задачи",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Create checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст пункта",
+                        "name": "Title",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{itemId}/check": {
+            "post": {
+                "description": "отмечает пункт чеклиста выполненным и записывает это в историю задачи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Check checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{itemId}/delete": {
+            "post": {
+                "description": "удаляет пункт чеклиста",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Delete checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{itemId}/move": {
+            "post": {
+                "description": "перемещает пункт чеклиста на указанную позицию, нумерация позиций начинается с 1",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Move checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Новая позиция",
+                        "name": "Position",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/checklist/{itemId}/uncheck": {
+            "post": {
+                "description": "снимает отметку о выполнении с пункта чеклиста и записывает это в историю задачи",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Uncheck checklist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Checklist item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/comments": {
+            "get": {
+                "description": "возвращает комментарии задачи в порядке добавления",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get task comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Comment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "добавляет комментарий к задаче и рассылает его пользователям, открывшим задачу",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Create comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Текст комментария",
+                        "name": "Body",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/delete": {
+            "post": {
+                "description": "Удаляет задачу по указанному идентификатору вместе с файлами её вложений",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Delete Task by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/due": {
+            "post": {
+                "description": "задаёт срок выполнения задачи: в RFC 3339 или в формате поля datetime-local (2006-01-02T15:04)\nпо времени сервера. Пустое значение снимает срок. Задачи со сроком попадают в календарь.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Set task due date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-20T18:00",
+                        "description": "Срок выполнения",
+                        "name": "DueAt",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/edit": {
+            "get": {
+                "description": "отображает форму редактирования задачи по идентификатору",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.TaskWithLogin"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "версия задачи"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/unwatch": {
+            "post": {
+                "description": "убирает задачу из календаря текущего пользователя, если она назначена не ему",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Unwatch task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/watch": {
+            "post": {
+                "description": "добавляет задачу в календарь текущего пользователя, даже если она назначена не ему.\nПользователь может следить только за своими задачами, ADMIN - за любыми",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Watch task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/ws": {
+            "get": {
+                "description": "открывает WebSocket-канал задачи. Сервер присылает JSON-сообщения presence (кто открыл задачу),\nfield (кто какое поле меняет), saved и deleted (задача сохранена или удалена) и comment (новый\nкомментарий). Клиент отправляет {\"type\":\"field\",\"field\":\"Title\",\"value\":\"...\"}, пока меняет поле формы.\nПодключение разрешено только со страниц приложения (заголовок Origin).",
+                "tags": [
+                    "tasks"
+                ],
+                "summary": "Task collaboration channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/collab.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "description": "возвращает шаблоны задач: ADMIN видит все шаблоны, остальные - с видимостью ALL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get task templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.TaskTemplate"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "создаёт шаблон задачи, только для администраторов. Название, описание и пункты чеклиста могут\nсодержать подстановки {{name}}. Видимость: ALL - всем пользователям, ADMIN - только администраторам.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates-admins"
+                ],
+                "summary": "Create task template",
+                "parameters": [
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/choose": {
+            "get": {
+                "description": "отображает список доступных пользователю шаблонов задач",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskTemplatesTemplateData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "put": {
+                "description": "заменяет все поля шаблона задачи, только для администраторов. Созданные по шаблону задачи не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates-admins"
+                ],
+                "summary": "Update task template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.TaskTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "удаляет шаблон задачи, только для администраторов. Созданные по шаблону задачи остаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates-admins"
+                ],
+                "summary": "Delete task template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/tasks": {
+            "post": {
+                "description": "создаёт задачу по шаблону: подстановки {{name}} в названии, описании и пунктах чеклиста заменяются\nзначениями полей Values[name], все подстановки обязательны. К задаче добавляются метки и чеклист\nшаблона. USER создаёт задачи только на себя.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create task from template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Логин пользователя, которому назначена задача",
+                        "name": "UserLogin",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Приоритет задачи, по умолчанию - из шаблона",
+                        "name": "Priority",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Значение подстановки {{version}}",
+                        "name": "Values[version]",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}/use": {
+            "get": {
+                "description": "отображает форму создания задачи по шаблону: поля для подстановок, приоритет и исполнителя",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pages"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TaskFromTemplateTemplateData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "возвращает список всех пользователей, только для администраторов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get All Users",
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "создает нового пользователя, только для администраторов",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-admins"
+                ],
+                "summary": "Create User",
+                "parameters": [
+                    {
+                        "description": "New User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/repository.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/block": {
+            "put": {
+                "description": "блокирует пользователя по идентификатору, только для администраторов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users-admins"
+                ],
+                "summary": "Block User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "возвращает список подписок без секретов, только для администраторов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Get all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "регистрирует подписку на события задач и пользователей, только для администраторов.\nСобытия: task.created, task.updated, task.deleted, user.created, user.blocked.\nТело запроса подписчику подписано HMAC-SHA256 с секретом подписки в заголовке X-Webhook-Signature.\nСекрет возвращается только в ответе на этот запрос.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "удаляет подписку вместе с журналом доставок, только для администраторов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "возвращает последние доставки подписки: статус, число попыток, ответ подписчика и последнюю ошибку.\nДоставка в статусе DEAD исчерпала все попытки и больше не повторяется.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{deliveryId}/redeliver": {
+            "post": {
+                "description": "возвращает доставку из статуса DEAD в очередь с полным числом попыток",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Redeliver dead webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "description": "сразу отправляет подписчику событие webhook.test и возвращает результат доставки",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks-admins"
+                ],
+                "summary": "Send test webhook event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResponseMap"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "collab.Message": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "$ref": "#/definitions/repository.Comment"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/repository.Task"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NotificationsTemplateData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "emailDigest": {
+                    "type": "boolean"
+                },
+                "eventTitles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Notification"
+                    }
+                },
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.NotificationPreference"
+                    }
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ResponseMap": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "dto.SavedFilterRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/repository.TaskFilter"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Мои срочные"
+                },
+                "visibility": {
+                    "description": "Visibility - PRIVATE (по умолчанию) - только владельцу, SHARED - всем пользователям,\nGLOBAL - всем пользователям и закреплён у всех, задаёт только ADMIN.",
+                    "type": "string",
+                    "example": "PRIVATE"
+                }
+            }
+        },
+        "dto.TaskBulkRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action - status, priority, assign, label или delete.",
+                    "type": "string",
+                    "example": "status"
+                },
+                "filter": {
+                    "$ref": "#/definitions/repository.TaskFilter"
+                },
+                "filterId": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "taskIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "value": {
+                    "description": "Value - новый статус, приоритет, логин исполнителя или метка; для delete не нужен.",
+                    "type": "string",
+                    "example": "DONE"
+                }
+            }
+        },
+        "dto.TaskConflictResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/repository.Task"
+                }
+            }
+        },
+        "dto.TaskFromTemplateTemplateData": {
+            "type": "object",
+            "properties": {
+                "placeholders": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "template": {
+                    "$ref": "#/definitions/repository.TaskTemplate"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.User"
+                    }
+                }
+            }
+        },
+        "dto.TaskTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Собрать changelog {{version}}",
+                        "Поставить тег"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Выпустить версию {{version}}"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "release"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Релиз"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Релиз {{version}}"
+                },
+                "visibility": {
+                    "description": "Visibility - ALL (по умолчанию) или ADMIN.",
+                    "type": "string",
+                    "example": "ALL"
+                }
+            }
+        },
+        "dto.TaskTemplatesTemplateData": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.TaskTemplate"
+                    }
+                }
+            }
+        },
+        "dto.UnreadCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UsersTemplateData": {
+            "type": "object",
+            "properties": {
+                "users": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/repository.User"
@@ -692,6 +4474,277 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "task.created",
+                        "task.updated"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret - ключ подписи запросов; если не указан, будет сгенерирован.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://ci.example.com/hooks/tasks"
+                }
+            }
+        },
+        "live.Event": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "previousUserId": {
+                    "type": "integer"
+                },
+                "task": {
+                    "$ref": "#/definitions/repository.Task"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "userLogin": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.AssigneeStatusCounts": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "inProgress": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "userLogin": {
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "repository.Attachment": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.ChecklistItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "done": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.Comment": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "bodyHtml": {
+                    "description": "BodyHTML - текст комментария, отрендеренный из Markdown со ссылками на упомянутых пользователей и задачи.",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userLogin": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.CycleTime": {
+            "type": "object",
+            "properties": {
+                "averageHours": {
+                    "type": "number",
+                    "example": 30.5
+                },
+                "tasks": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "repository.Notification": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.PoolStat": {
+            "type": "object",
+            "properties": {
+                "acquiredConns": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "idleConns": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "maxConns": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "totalConns": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "repository.PriorityStatusCounts": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "inProgress": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "repository.SavedFilter": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/repository.TaskFilter"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notify": {
+                    "type": "boolean"
+                },
+                "pinned": {
+                    "description": "Pinned и Notify - настройки фильтра у текущего пользователя, хранятся в SavedFilterSettings.",
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
         "repository.Task": {
             "type": "object",
             "properties": {
@@ -701,6 +4754,16 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "descriptionHtml": {
+                    "type": "string"
+                },
+                "descriptionText": {
+                    "type": "string"
+                },
+                "dueAt": {
+                    "description": "DueAt - срок выполнения в UTC, nil - срок не задан.",
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -718,6 +4781,88 @@ const docTemplate = `{
                 },
                 "userId": {
                     "type": "integer"
+                },
+                "version": {
+                    "description": "Version увеличивается при каждом изменении задачи и используется для оптимистичной блокировки.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.TaskFilter": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "description": "Labels - метки, которые должны быть у задачи все одновременно.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sort": {
+                    "description": "Sort - столбец из TaskSortColumns, с префиксом \"-\" - по убыванию. По умолчанию задачи упорядочены по id.",
+                    "type": "string",
+                    "example": "-updatedAt"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "text": {
+                    "description": "Text ищется без учёта регистра в названии и описании задачи.",
+                    "type": "string",
+                    "example": "релиз"
+                },
+                "userLogin": {
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "repository.TaskTemplate": {
+            "type": "object",
+            "properties": {
+                "checklist": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "visibility": {
+                    "type": "string"
                 }
             }
         },
@@ -730,9 +4875,18 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "dueAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "priority": {
                     "type": "integer"
                 },
@@ -747,6 +4901,9 @@ const docTemplate = `{
                 },
                 "userLogin": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -759,6 +4916,14 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "email": {
+                    "description": "Email - адрес для уведомлений, пустой - письма не отправляются.",
+                    "type": "string"
+                },
+                "emailDigest": {
+                    "description": "EmailDigest - отправлять уведомления раз в день одной сводкой вместо отдельных писем.",
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -772,6 +4937,306 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "repository.Webhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret - ключ подписи тел запросов, отдаётся только при создании подписки.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.AgingBucket": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "8-30"
+                },
+                "tasks": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "service.BurndownPoint": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "example": "2026-10-12T00:00:00Z"
+                },
+                "ideal": {
+                    "type": "number",
+                    "example": 6.5
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "service.CalendarFeedInfo": {
+            "type": "object",
+            "properties": {
+                "allTasks": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/calendar/3f2a.ics"
+                }
+            }
+        },
+        "service.CumulativeFlowPoint": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "example": "2026-10-12T00:00:00Z"
+                },
+                "done": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "inProgress": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "open": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "service.DatabaseCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "service.HealthReport": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "$ref": "#/definitions/service.DatabaseCheck"
+                },
+                "migrations": {
+                    "$ref": "#/definitions/service.MigrationsCheck"
+                },
+                "outbox": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "outboxError": {
+                    "type": "string"
+                },
+                "pool": {
+                    "$ref": "#/definitions/repository.PoolStat"
+                },
+                "startedAt": {
+                    "type": "string",
+                    "example": "2026-10-19T08:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "uptimeSeconds": {
+                    "type": "integer",
+                    "example": 3600
+                }
+            }
+        },
+        "service.MigrationsCheck": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "service.Readiness": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "$ref": "#/definitions/service.DatabaseCheck"
+                },
+                "migrations": {
+                    "$ref": "#/definitions/service.MigrationsCheck"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "service.TaskBreakdown": {
+            "type": "object",
+            "properties": {
+                "byAssignee": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.AssigneeStatusCounts"
+                    }
+                },
+                "byPriority": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.PriorityStatusCounts"
+                    }
+                }
+            }
+        },
+        "service.TaskBulkResult": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "taskId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.TaskImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.TaskImportReport": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TaskImportError"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "service.WeeklyThroughput": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "weekStart": {
+                    "type": "string",
+                    "example": "2026-10-12T00:00:00Z"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,131 @@
+// Package backup описывает формат архива резервной копии: manifest.json, по файлу JSON Lines на каждую сущность
+// и содержимое вложений.
+package backup
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// FormatVersion - версия формата архива. Увеличивается при несовместимых изменениях формата.
+const FormatVersion = 1
+
+const (
+	manifestName  = "manifest.json"
+	entitiesDir   = "entities/"
+	entityFileExt = ".jsonl"
+	filesDir      = "files/"
+)
+
+var ErrManifestNotFound = errors.New("backup manifest not found")
+
+// Manifest описывает содержимое архива. SchemaVersion - версия последней миграции базы, из которой сделана копия.
+type Manifest struct {
+	FormatVersion int        `json:"formatVersion"`
+	SchemaVersion int64      `json:"schemaVersion"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	Entities      []Entity   `json:"entities"`
+	Sequences     []Sequence `json:"sequences"`
+	Files         []File     `json:"files"`
+}
+
+// Entity - таблица в архиве. Каждая строка файла сущности - объект JSON со столбцами Columns.
+type Entity struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    int      `json:"rows"`
+}
+
+// Sequence - состояние последовательности, из которой выдаются идентификаторы.
+type Sequence struct {
+	Name      string `json:"name"`
+	LastValue int64  `json:"lastValue"`
+	IsCalled  bool   `json:"isCalled"`
+}
+
+// File - содержимое вложения по ключу хранилища.
+type File struct {
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// Writer пишет архив потоково: сущности и файлы по мере поступления, манифест - последним, в Close.
+type Writer struct {
+	archive *zip.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{archive: zip.NewWriter(w)}
+}
+
+// CreateEntity начинает файл сущности. Предыдущий файл должен быть полностью записан.
+func (w *Writer) CreateEntity(name string) (io.Writer, error) {
+	return w.archive.Create(entitiesDir + name + entityFileExt)
+}
+
+// CreateFile начинает файл вложения с ключом хранилища key.
+func (w *Writer) CreateFile(key string) (io.Writer, error) {
+	return w.archive.Create(filesDir + key)
+}
+
+// Close записывает манифест и завершает архив.
+func (w *Writer) Close(manifest *Manifest) error {
+	manifestWriter, err := w.archive.Create(manifestName)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(manifest); err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
+
+// Reader читает архив, записанный Writer.
+type Reader struct {
+	archive  *zip.Reader
+	manifest *Manifest
+}
+
+// NewReader открывает архив и читает его манифест.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open backup archive: %w", err)
+	}
+
+	manifestFile, err := archive.Open(manifestName)
+	if err != nil {
+		return nil, ErrManifestNotFound
+	}
+	defer manifestFile.Close()
+
+	var manifest Manifest
+	if err = json.NewDecoder(manifestFile).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("cannot read backup manifest: %w", err)
+	}
+
+	return &Reader{archive: archive, manifest: &manifest}, nil
+}
+
+func (r *Reader) Manifest() *Manifest {
+	return r.manifest
+}
+
+// OpenEntity открывает файл сущности.
+func (r *Reader) OpenEntity(name string) (io.ReadCloser, error) {
+	return r.archive.Open(entitiesDir + name + entityFileExt)
+}
+
+// OpenFile открывает файл вложения с ключом хранилища key.
+func (r *Reader) OpenFile(key string) (io.ReadCloser, error) {
+	return r.archive.Open(filesDir + key)
+}
@@ -0,0 +1,64 @@
+//go:build unit && !integration
+
+package backup
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	var archive bytes.Buffer
+	writer := NewWriter(&archive)
+
+	entityWriter, err := writer.CreateEntity("users")
+	require.NoError(t, err)
+	_, err = io.WriteString(entityWriter, `{"id":1,"login":"admin"}`+"\n")
+	require.NoError(t, err)
+
+	fileWriter, err := writer.CreateFile("tasks/1/2")
+	require.NoError(t, err)
+	_, err = io.WriteString(fileWriter, "log line")
+	require.NoError(t, err)
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		SchemaVersion: 20261019200000,
+		CreatedAt:     time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		Entities:      []Entity{{Name: "users", Columns: []string{"id", "login"}, Rows: 1}},
+		Sequences:     []Sequence{{Name: "users_sequence", LastValue: 100, IsCalled: true}},
+		Files:         []File{{Key: "tasks/1/2", ContentType: "text/plain", Size: 8}},
+	}
+	require.NoError(t, writer.Close(manifest))
+
+	reader, err := NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+	require.Equal(t, manifest, reader.Manifest())
+
+	entityReader, err := reader.OpenEntity("users")
+	require.NoError(t, err)
+	entity, err := io.ReadAll(entityReader)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1,"login":"admin"}`+"\n", string(entity))
+
+	fileReader, err := reader.OpenFile("tasks/1/2")
+	require.NoError(t, err)
+	file, err := io.ReadAll(fileReader)
+	require.NoError(t, err)
+	require.Equal(t, "log line", string(file))
+}
+
+func TestNewReader_ManifestNotFound(t *testing.T) {
+	var archive bytes.Buffer
+	writer := NewWriter(&archive)
+	_, err := writer.CreateEntity("users")
+	require.NoError(t, err)
+	require.NoError(t, writer.archive.Close())
+
+	_, err = NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.ErrorIs(t, err, ErrManifestNotFound)
+}
@@ -0,0 +1,191 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+)
+
+const (
+	width        = 800
+	height       = 340
+	marginLeft   = 50
+	marginRight  = 20
+	marginTop    = 20
+	marginBottom = 70
+	plotWidth    = width - marginLeft - marginRight
+	plotHeight   = height - marginTop - marginBottom
+
+	yTicks      = 5
+	maxXLabels  = 10
+	legendY     = height - 15
+	legendWidth = 150
+)
+
+// Series - ряд значений графика, по одному на каждую подпись оси X.
+type Series struct {
+	Name   string
+	Color  string
+	Values []float64
+	Dashed bool
+}
+
+// Lines рисует линейный график: по линии на каждый ряд. labels - подписи точек оси X.
+func Lines(labels []string, series []Series) template.HTML {
+	maxValue := 0.0
+	for _, s := range series {
+		for _, value := range s.Values {
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+
+	var b strings.Builder
+	top := niceCeil(maxValue)
+	if !begin(&b, labels, top) {
+		return template.HTML(b.String()) //nolint:gosec // все подписи экранированы
+	}
+
+	for _, s := range series {
+		points := make([]string, 0, len(labels))
+		for i := range labels {
+			points = append(points, point(i, len(labels), value(s.Values, i), top))
+		}
+		dash := ""
+		if s.Dashed {
+			dash = ` stroke-dasharray="6 4"`
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2"%s points="%s"/>`,
+			html.EscapeString(s.Color), dash, strings.Join(points, " "))
+	}
+
+	end(&b, series)
+
+	return template.HTML(b.String()) //nolint:gosec // все подписи экранированы
+}
+
+// StackedArea рисует диаграмму с накоплением: ряды складываются снизу вверх в порядке series.
+func StackedArea(labels []string, series []Series) template.HTML {
+	stacked := make([][]float64, len(series))
+	sums := make([]float64, len(labels))
+	maxValue := 0.0
+	for k, s := range series {
+		stacked[k] = make([]float64, len(labels))
+		for i := range labels {
+			sums[i] += value(s.Values, i)
+			stacked[k][i] = sums[i]
+			maxValue = math.Max(maxValue, sums[i])
+		}
+	}
+
+	var b strings.Builder
+	top := niceCeil(maxValue)
+	if !begin(&b, labels, top) {
+		return template.HTML(b.String()) //nolint:gosec // все подписи экранированы
+	}
+
+	for k, s := range series {
+		points := make([]string, 0, 2*len(labels))
+		for i := range labels {
+			points = append(points, point(i, len(labels), stacked[k][i], top))
+		}
+		for i := len(labels) - 1; i >= 0; i-- {
+			lower := 0.0
+			if k > 0 {
+				lower = stacked[k-1][i]
+			}
+			points = append(points, point(i, len(labels), lower, top))
+		}
+		fmt.Fprintf(&b, `<polygon fill="%s" fill-opacity="0.8" stroke="%s" points="%s"/>`,
+			html.EscapeString(s.Color), html.EscapeString(s.Color), strings.Join(points, " "))
+	}
+
+	end(&b, series)
+
+	return template.HTML(b.String()) //nolint:gosec // все подписи экранированы
+}
+
+// begin открывает svg и рисует оси, сетку и подписи. Если точек нет, закрывает svg с надписью
+// «Нет данных» и возвращает false.
+func begin(b *strings.Builder, labels []string, top float64) bool {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`font-family="sans-serif" font-size="11">`, width, height, width, height)
+	if len(labels) == 0 {
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="middle">Нет данных</text></svg>`, width/2, height/2)
+		return false
+	}
+
+	for tick := 0; tick <= yTicks; tick++ {
+		tickValue := top * float64(tick) / yTicks
+		y := yCoord(tickValue, top)
+		fmt.Fprintf(b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#ddd"/>`,
+			marginLeft, y, width-marginRight, y)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" text-anchor="end">%g</text>`, marginLeft-5, y+4, tickValue)
+	}
+
+	step := (len(labels) + maxXLabels - 1) / maxXLabels
+	for i := 0; i < len(labels); i += step {
+		fmt.Fprintf(b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`,
+			xCoord(i, len(labels)), height-marginBottom+15, html.EscapeString(labels[i]))
+	}
+
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`,
+		marginLeft, marginTop, marginLeft, height-marginBottom)
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`,
+		marginLeft, height-marginBottom, width-marginRight, height-marginBottom)
+
+	return true
+}
+
+// end рисует легенду и закрывает svg.
+func end(b *strings.Builder, series []Series) {
+	for k, s := range series {
+		x := marginLeft + k*legendWidth
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`,
+			x, legendY-10, html.EscapeString(s.Color))
+		fmt.Fprintf(b, `<text x="%d" y="%d">%s</text>`, x+18, legendY, html.EscapeString(s.Name))
+	}
+	b.WriteString(`</svg>`)
+}
+
+func point(i, n int, v, top float64) string {
+	return fmt.Sprintf("%.1f,%.1f", xCoord(i, n), yCoord(v, top))
+}
+
+// xCoord равномерно распределяет точки по ширине графика; единственная точка стоит посередине.
+func xCoord(i, n int) float64 {
+	if n == 1 {
+		return marginLeft + plotWidth/2
+	}
+
+	return marginLeft + float64(plotWidth)*float64(i)/float64(n-1)
+}
+
+func yCoord(v, top float64) float64 {
+	return marginTop + float64(plotHeight)*(1-v/top)
+}
+
+// niceCeil округляет верх оси Y вверх до 1, 2 или 5, умноженных на степень десяти, чтобы деления были круглыми.
+func niceCeil(v float64) float64 {
+	if v <= yTicks {
+		return yTicks
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*magnitude >= v {
+			return m * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
+
+func value(values []float64, i int) float64 {
+	if i < len(values) {
+		return values[i]
+	}
+
+	return 0
+}
@@ -0,0 +1,58 @@
+//go:build unit && !integration
+
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	rendered := string(Lines([]string{"01.10", "02.10", "03.10"}, []Series{
+		{Name: "Осталось", Color: "#2196F3", Values: []float64{8, 5, 2}},
+		{Name: "Идеально", Color: "#999", Values: []float64{8, 4, 0}, Dashed: true},
+	}))
+
+	require.True(t, strings.HasPrefix(rendered, "<svg"))
+	require.True(t, strings.HasSuffix(rendered, "</svg>"))
+	require.Equal(t, 2, strings.Count(rendered, "<polyline"))
+	require.Contains(t, rendered, `stroke-dasharray="6 4"`)
+	require.Contains(t, rendered, `points="50.0,70.0 415.0,145.0 780.0,220.0"`)
+	require.Contains(t, rendered, ">Осталось</text>")
+}
+
+func TestStackedArea(t *testing.T) {
+	rendered := string(StackedArea([]string{"01.10", "02.10"}, []Series{
+		{Name: "DONE", Color: "#4CAF50", Values: []float64{1, 2}},
+		{Name: "OPEN", Color: "#FF9800", Values: []float64{4, 3}},
+	}))
+
+	require.Equal(t, 2, strings.Count(rendered, "<polygon"))
+	require.Contains(t, rendered, `points="50.0,220.0 780.0,170.0 780.0,270.0 50.0,270.0"`)
+	require.Contains(t, rendered, `points="50.0,20.0 780.0,20.0 780.0,170.0 50.0,220.0"`)
+}
+
+func TestLines_Empty(t *testing.T) {
+	rendered := string(Lines(nil, []Series{{Name: "Осталось", Color: "#2196F3"}}))
+
+	require.Contains(t, rendered, "Нет данных")
+	require.NotContains(t, rendered, "<polyline")
+}
+
+func TestLines_LabelsEscaped(t *testing.T) {
+	rendered := string(Lines([]string{"<b>"}, []Series{{Name: "<script>", Color: `"red`, Values: []float64{1}}}))
+
+	require.NotContains(t, rendered, "<script>")
+	require.NotContains(t, rendered, "<b>")
+	require.Contains(t, rendered, "&lt;script&gt;")
+}
+
+func TestNiceCeil(t *testing.T) {
+	require.InDelta(t, 5.0, niceCeil(0), 0)
+	require.InDelta(t, 10.0, niceCeil(7), 0)
+	require.InDelta(t, 20.0, niceCeil(13), 0)
+	require.InDelta(t, 50.0, niceCeil(50), 0)
+	require.InDelta(t, 1000.0, niceCeil(501), 0)
+}
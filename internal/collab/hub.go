@@ -0,0 +1,158 @@
+package collab
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// Типы сообщений канала задачи.
+const (
+	// PresenceMessage - список пользователей, открывших задачу. Отправляется при каждом входе и выходе.
+	PresenceMessage = "presence"
+	// FieldMessage - пользователь меняет поле в форме редактирования; приходит от клиента и рассылается остальным.
+	FieldMessage = "field"
+	// SavedMessage - изменения задачи сохранены.
+	SavedMessage = "saved"
+	// DeletedMessage - задача удалена.
+	DeletedMessage = "deleted"
+	// CommentMessage - к задаче добавлен комментарий.
+	CommentMessage = "comment"
+)
+
+type Message struct {
+	Type    string              `json:"type"`
+	User    string              `json:"user,omitempty"`
+	Users   []string            `json:"users,omitempty"`
+	Field   string              `json:"field,omitempty"`
+	Value   string              `json:"value,omitempty"`
+	Task    *repository.Task    `json:"task,omitempty"`
+	Comment *repository.Comment `json:"comment,omitempty"`
+}
+
+// Client - одно открытое соединение с каналом задачи.
+type Client struct {
+	Login    string
+	messages chan Message
+}
+
+// Messages возвращает сообщения для клиента. Канал закрывается, когда клиент покинул канал задачи
+// или отключён из-за того, что не успевает читать сообщения.
+func (c *Client) Messages() <-chan Message {
+	return c.messages
+}
+
+// Hub хранит каналы задач: у каждой задачи свой набор клиентов.
+type Hub struct {
+	mu         sync.Mutex
+	rooms      map[int]map[*Client]struct{}
+	bufferSize int
+	closed     bool
+}
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		rooms:      make(map[int]map[*Client]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+// Join подключает клиента к каналу задачи и рассылает всем участникам новый список присутствующих.
+func (h *Hub) Join(taskID int, login string) *Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	client := &Client{Login: login, messages: make(chan Message, h.bufferSize)}
+	if h.closed {
+		close(client.messages)
+		return client
+	}
+
+	room, ok := h.rooms[taskID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		h.rooms[taskID] = room
+	}
+	room[client] = struct{}{}
+	h.broadcastLocked(taskID, h.presenceLocked(taskID), nil)
+
+	return client
+}
+
+// Leave отключает клиента от канала задачи. Повторный вызов ничего не делает.
+func (h *Hub) Leave(taskID int, client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.rooms[taskID][client]; !ok {
+		return
+	}
+	h.removeLocked(taskID, client)
+	h.broadcastLocked(taskID, h.presenceLocked(taskID), nil)
+}
+
+// Broadcast отправляет сообщение всем участникам канала задачи, кроме from. from может быть nil.
+func (h *Hub) Broadcast(taskID int, message Message, from *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.broadcastLocked(taskID, message, from)
+}
+
+// Close отключает всех клиентов; новые клиенты сразу отключены.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for taskID, room := range h.rooms {
+		for client := range room {
+			h.removeLocked(taskID, client)
+		}
+	}
+}
+
+// broadcastLocked не блокируется: клиент с заполненным буфером отключается, а оставшимся
+// рассылается обновлённый список присутствующих.
+func (h *Hub) broadcastLocked(taskID int, message Message, from *Client) {
+	for {
+		dropped := false
+		for client := range h.rooms[taskID] {
+			if client == from {
+				continue
+			}
+
+			select {
+			case client.messages <- message:
+			default:
+				h.removeLocked(taskID, client)
+				dropped = true
+			}
+		}
+		if !dropped {
+			return
+		}
+
+		message = h.presenceLocked(taskID)
+		from = nil
+	}
+}
+
+func (h *Hub) presenceLocked(taskID int) Message {
+	users := make([]string, 0, len(h.rooms[taskID]))
+	for client := range h.rooms[taskID] {
+		users = append(users, client.Login)
+	}
+	slices.Sort(users)
+
+	return Message{Type: PresenceMessage, Users: slices.Compact(users)}
+}
+
+func (h *Hub) removeLocked(taskID int, client *Client) {
+	delete(h.rooms[taskID], client)
+	if len(h.rooms[taskID]) == 0 {
+		delete(h.rooms, taskID)
+	}
+	close(client.messages)
+}
@@ -0,0 +1,71 @@
+//go:build unit && !integration
+
+package collab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_Join_PresenceBroadcast(t *testing.T) {
+	hub := NewHub(10)
+	first := hub.Join(1, "user")
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"user"}}, <-first.Messages())
+
+	second := hub.Join(1, "admin")
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"admin", "user"}}, <-first.Messages())
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"admin", "user"}}, <-second.Messages())
+
+	hub.Leave(1, second)
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"user"}}, <-first.Messages())
+	_, ok := <-second.Messages()
+	require.False(t, ok)
+}
+
+func TestHub_Broadcast_SenderAndOtherTasksSkipped(t *testing.T) {
+	hub := NewHub(10)
+	sender := hub.Join(1, "user")
+	<-sender.Messages()
+	receiver := hub.Join(1, "admin")
+	<-sender.Messages()
+	<-receiver.Messages()
+	otherTask := hub.Join(2, "other")
+	<-otherTask.Messages()
+
+	hub.Broadcast(1, Message{Type: FieldMessage, User: "user", Field: "Title", Value: "Release"}, sender)
+
+	require.Equal(t, Message{Type: FieldMessage, User: "user", Field: "Title", Value: "Release"}, <-receiver.Messages())
+	require.Empty(t, sender.Messages())
+	require.Empty(t, otherTask.Messages())
+}
+
+func TestHub_Broadcast_SlowClientDropped(t *testing.T) {
+	hub := NewHub(2)
+	slow := hub.Join(1, "slow")
+	hub.Broadcast(1, Message{Type: SavedMessage}, nil)
+
+	// буфер медленного клиента заполнен, поэтому при следующей рассылке он отключается.
+	fast := hub.Join(1, "fast")
+
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"fast", "slow"}}, <-fast.Messages())
+	require.Equal(t, Message{Type: PresenceMessage, Users: []string{"fast"}}, <-fast.Messages())
+	require.Equal(t, PresenceMessage, (<-slow.Messages()).Type)
+	require.Equal(t, SavedMessage, (<-slow.Messages()).Type)
+	_, ok := <-slow.Messages()
+	require.False(t, ok)
+	hub.Leave(1, slow)
+}
+
+func TestHub_Close_ClientsDisconnected(t *testing.T) {
+	hub := NewHub(10)
+	client := hub.Join(1, "user")
+	<-client.Messages()
+
+	hub.Close()
+	_, ok := <-client.Messages()
+	require.False(t, ok)
+
+	_, ok = <-hub.Join(1, "user").Messages()
+	require.False(t, ok)
+}
@@ -3,15 +3,113 @@ package config
 import "time"
 
 type Config struct {
-	Server *Server `yaml:"server"`
-	DB     *DB     `yaml:"db"`
+	Server      *Server      `yaml:"server"`
+	DB          *DB          `yaml:"db"`
+	Attachments *Attachments `yaml:"attachments"`
+	Email       *Email       `yaml:"email"`
+	Webhooks    *Webhooks    `yaml:"webhooks"`
+	Outbox      *Outbox      `yaml:"outbox"`
+	Stream      *Stream      `yaml:"stream"`
+	Calendar    *Calendar    `yaml:"calendar"`
+	Charts      *Charts      `yaml:"charts"`
+	Reminders   *Reminders   `yaml:"reminders"`
+	Tracing     *Tracing     `yaml:"tracing"`
+	Logging     *Logging     `yaml:"logging"`
+	Health      *Health      `yaml:"health"`
 }
 
 type Server struct {
 	Port              int           `yaml:"port"`
 	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	ReadTimeout       time.Duration `yaml:"readTimeout"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout"`
 }
 
 type DB struct {
 	ConnectionString string `yaml:"connectionString"`
 }
+
+type Attachments struct {
+	MaxSize          int64    `yaml:"maxSize"`
+	AllowedMimeTypes []string `yaml:"allowedMimeTypes"`
+	Storage          string   `yaml:"storage"`
+	LocalDir         string   `yaml:"localDir"`
+	S3               *S3      `yaml:"s3"`
+}
+
+type S3 struct {
+	Endpoint  string `yaml:"endpoint"`
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	Bucket    string `yaml:"bucket"`
+	UseSSL    bool   `yaml:"useSSL"`
+}
+
+type Email struct {
+	Enabled      bool          `yaml:"enabled"`
+	Host         string        `yaml:"host"`
+	Port         int           `yaml:"port"`
+	Username     string        `yaml:"username"`
+	Password     string        `yaml:"password"`
+	From         string        `yaml:"from"`
+	BaseURL      string        `yaml:"baseUrl"`
+	Timeout      time.Duration `yaml:"timeout"`
+	QueueSize    int           `yaml:"queueSize"`
+	MaxAttempts  int           `yaml:"maxAttempts"`
+	RetryBackoff time.Duration `yaml:"retryBackoff"`
+	DigestHour   int           `yaml:"digestHour"`
+}
+
+type Webhooks struct {
+	Timeout      time.Duration `yaml:"timeout"`
+	MaxAttempts  int           `yaml:"maxAttempts"`
+	RetryBackoff time.Duration `yaml:"retryBackoff"`
+	PollInterval time.Duration `yaml:"pollInterval"`
+	BatchSize    int           `yaml:"batchSize"`
+}
+
+type Outbox struct {
+	PollInterval time.Duration `yaml:"pollInterval"`
+	BatchSize    int           `yaml:"batchSize"`
+	MaxAttempts  int           `yaml:"maxAttempts"`
+}
+
+type Stream struct {
+	BufferSize        int           `yaml:"bufferSize"`
+	ReplayLimit       int           `yaml:"replayLimit"`
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+}
+
+type Calendar struct {
+	BaseURL         string        `yaml:"baseUrl"`
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+type Charts struct {
+	SnapshotInterval time.Duration `yaml:"snapshotInterval"`
+	MaxDays          int           `yaml:"maxDays"`
+}
+
+type Reminders struct {
+	DueWithin     time.Duration `yaml:"dueWithin"`
+	CheckInterval time.Duration `yaml:"checkInterval"`
+}
+
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+type Logging struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type Health struct {
+	Timeout time.Duration `yaml:"timeout"`
+}
@@ -26,3 +26,98 @@ const (
 	ActiveUser  = true
 	BlockedUser = false
 )
+
+const (
+	ChecklistItemCheckedAction   = "CHECKLIST_ITEM_CHECKED"
+	ChecklistItemUncheckedAction = "CHECKLIST_ITEM_UNCHECKED"
+)
+
+const (
+	TaskAssignedEvent      = "TASK_ASSIGNED"
+	TaskStatusChangedEvent = "TASK_STATUS_CHANGED"
+	UserMentionedEvent     = "USER_MENTIONED"
+	FilterMatchedEvent     = "FILTER_MATCHED"
+	CommentAddedEvent      = "COMMENT_ADDED"
+	TaskDueSoonEvent       = "TASK_DUE_SOON"
+)
+
+var NotificationEvents = []string{
+	TaskAssignedEvent,
+	TaskStatusChangedEvent,
+	UserMentionedEvent,
+	FilterMatchedEvent,
+	CommentAddedEvent,
+	TaskDueSoonEvent,
+}
+
+var NotificationEventTitles = map[string]string{
+	TaskAssignedEvent:      "Мне назначена задача",
+	TaskStatusChangedEvent: "Изменён статус моей задачи",
+	UserMentionedEvent:     "Меня упомянули",
+	FilterMatchedEvent:     "Задача подошла под фильтр, на который я подписан",
+	CommentAddedEvent:      "Новый комментарий к моей задаче",
+	TaskDueSoonEvent:       "Скоро срок моей задачи",
+}
+
+const (
+	TaskCreatedEvent = "task.created"
+	TaskUpdatedEvent = "task.updated"
+	TaskDeletedEvent = "task.deleted"
+	UserCreatedEvent = "user.created"
+	UserBlockedEvent = "user.blocked"
+	// TestWebhookEvent отправляется только по запросу администратора, подписаться на него нельзя.
+	TestWebhookEvent = "webhook.test"
+)
+
+var WebhookEvents = []string{
+	TaskCreatedEvent,
+	TaskUpdatedEvent,
+	TaskDeletedEvent,
+	UserCreatedEvent,
+	UserBlockedEvent,
+}
+
+const (
+	PendingDeliveryStatus   = "PENDING"
+	DeliveredDeliveryStatus = "DELIVERED"
+	DeadDeliveryStatus      = "DEAD"
+)
+
+const (
+	PendingOutboxStatus   = "PENDING"
+	PublishedOutboxStatus = "PUBLISHED"
+	FailedOutboxStatus    = "FAILED"
+)
+
+const (
+	BulkStatusAction   = "status"
+	BulkPriorityAction = "priority"
+	BulkAssignAction   = "assign"
+	BulkLabelAction    = "label"
+	BulkDeleteAction   = "delete"
+)
+
+const (
+	BulkItemOK        = "OK"
+	BulkItemForbidden = "FORBIDDEN"
+	BulkItemNotFound  = "NOT_FOUND"
+	BulkItemConflict  = "CONFLICT"
+)
+
+// Видимость шаблонов задач: AllTemplateVisibility - для всех пользователей, AdminTemplateVisibility - только для ADMIN.
+const (
+	AllTemplateVisibility   = "ALL"
+	AdminTemplateVisibility = "ADMIN"
+)
+
+var TemplateVisibilities = []string{AllTemplateVisibility, AdminTemplateVisibility}
+
+// Видимость сохранённых фильтров: PRIVATE - только владельцу, SHARED - всем пользователям,
+// GLOBAL - всем пользователям и закреплён у всех на странице задач (задаёт только ADMIN).
+const (
+	PrivateFilterVisibility = "PRIVATE"
+	SharedFilterVisibility  = "SHARED"
+	GlobalFilterVisibility  = "GLOBAL"
+)
+
+var FilterVisibilities = []string{PrivateFilterVisibility, SharedFilterVisibility, GlobalFilterVisibility}
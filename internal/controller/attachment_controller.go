@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+// multipartOverhead - запас на заголовки multipart поверх максимального размера файла.
+const multipartOverhead = 1 << 20
+
+type IAttachmentController interface {
+	GetByTaskID(c *gin.Context)
+	Upload(c *gin.Context)
+	Download(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type AttachmentController struct {
+	AttachmentService service.IAttachmentService
+}
+
+func NewAttachmentController(attachmentService service.IAttachmentService) *AttachmentController {
+	return &AttachmentController{AttachmentService: attachmentService}
+}
+
+// GetByTaskID возвращает список вложений задачи.
+// @Summary Get task attachments
+// @Description возвращает метаданные вложений задачи. Доступно владельцу задачи и администраторам.
+// @Tags attachments
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} repository.Attachment
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/attachments [get]
+// .
+func (a *AttachmentController) GetByTaskID(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	attachments, err := a.AttachmentService.GetByTaskID(c.Request.Context(), sessionUser, taskID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// Upload загружает файл и прикрепляет его к задаче.
+// @Summary Upload attachment
+// @Description загружает файл и прикрепляет его к задаче. Доступно владельцу задачи и администраторам.
+// @Description Размер и допустимые типы файлов задаются в конфигурации.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param File formData file true "Файл"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 413 {object} dto.ResponseMap
+// @Failure 415 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/attachments [post]
+// .
+func (a *AttachmentController) Upload(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.AttachmentService.MaxSize()+multipartOverhead)
+	fileHeader, err := c.FormFile("File")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "file is not attached or too large"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+	defer file.Close()
+
+	_, err = a.AttachmentService.Upload(c.Request.Context(),
+		sessionUser,
+		taskID,
+		fileHeader.Filename,
+		fileHeader.Size,
+		file,
+	)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+// Download отдаёт содержимое вложения.
+// @Summary Download attachment
+// @Description отдаёт содержимое вложения. Доступно владельцу задачи и администраторам.
+// @Tags attachments
+// @Produce octet-stream
+// @Param id path string true "Task ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/attachments/{attachmentId} [get]
+// .
+func (a *AttachmentController) Download(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := a.AttachmentService.Download(c.Request.Context(), sessionUser, taskID, attachmentID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+	defer content.Close()
+
+	clearWriteDeadline(c)
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// Delete удаляет вложение задачи.
+// @Summary Delete attachment
+// @Description удаляет вложение задачи вместе с содержимым в хранилище
+// @Tags attachments
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param attachmentId path string true "Attachment ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/attachments/{attachmentId}/delete [post]
+// .
+func (a *AttachmentController) Delete(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	if err := a.AttachmentService.Delete(c.Request.Context(), sessionUser, taskID, attachmentID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+func parseAttachmentParams(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.Atoi(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "attachment ID is not number"})
+		return 0, 0, false
+	}
+
+	return taskID, attachmentID, true
+}
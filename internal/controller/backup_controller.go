@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type IBackupController interface {
+	Download(c *gin.Context)
+}
+
+type BackupController struct {
+	BackupService service.IBackupService
+}
+
+func NewBackupController(backupService service.IBackupService) *BackupController {
+	return &BackupController{BackupService: backupService}
+}
+
+// Download выгружает резервную копию всех данных приложения.
+// @Summary Download backup
+// @Description выгружает zip-архив со всеми данными приложения: manifest.json с версиями формата и схемы базы,
+// @Description по файлу JSON Lines на каждую таблицу и файлы вложений. Восстанавливается командой
+// @Description task_manager restore -file <архив> в пустую базу. Только для администраторов
+// @Tags backup
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /backup [get]
+// .
+func (b *BackupController) Download(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	// в копии есть пароли и токены всех пользователей, поэтому роль проверяется и здесь.
+	if sessionUser.Role != constant.AdminRole {
+		c.JSON(http.StatusForbidden, dto.ResponseMap{"error": "you should be admin for the action"})
+		return
+	}
+
+	clearWriteDeadline(c)
+	w := &fileResponseWriter{
+		c:           c,
+		contentType: "application/zip",
+		fileName:    "task-manager-backup-" + time.Now().UTC().Format("20060102T150405Z") + ".zip",
+	}
+	err := b.BackupService.Backup(c.Request.Context(), w)
+	if err != nil && !w.started {
+		writeServiceError(c, err)
+		return
+	} else if err != nil {
+		// часть архива уже отправлена, сообщить об ошибке клиенту можно только обрывом ответа.
+		slog.ErrorContext(c.Request.Context(), "cannot back up data", slog.Any("error", err))
+		c.Abort()
+	}
+}
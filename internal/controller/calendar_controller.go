@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type ICalendarController interface {
+	GetFeed(c *gin.Context)
+	RotateToken(c *gin.Context)
+	Feed(c *gin.Context)
+	Watch(c *gin.Context)
+	Unwatch(c *gin.Context)
+}
+
+type CalendarController struct {
+	CalendarService service.ICalendarService
+}
+
+func NewCalendarController(calendarService service.ICalendarService) *CalendarController {
+	return &CalendarController{CalendarService: calendarService}
+}
+
+// GetFeed возвращает адрес календаря текущего пользователя.
+// @Summary Get calendar feed
+// @Description возвращает адрес календаря со сроками задач текущего пользователя для подписки в календарном приложении
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} service.CalendarFeedInfo
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /calendar [get]
+// .
+func (cl *CalendarController) GetFeed(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	feed, err := cl.CalendarService.GetFeed(c.Request.Context(), sessionUser)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// RotateToken выпускает новый адрес календаря.
+// @Summary Rotate calendar token
+// @Description выпускает новый секретный адрес календаря текущего пользователя, прежний адрес перестаёт работать.
+// @Description AllTasks=true - календарь со сроками задач всех пользователей, только для администраторов.
+// @Tags calendar
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param AllTasks formData boolean false "Календарь со всеми задачами"
+// @Success 200 {object} service.CalendarFeedInfo
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /calendar/token [post]
+// .
+func (cl *CalendarController) RotateToken(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	allTasks := false
+	if allTasksForm := c.PostForm("AllTasks"); allTasksForm != "" {
+		var err error
+		if allTasks, err = strconv.ParseBool(allTasksForm); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "all tasks flag is not a boolean"})
+			return
+		}
+	}
+
+	feed, err := cl.CalendarService.RotateToken(c.Request.Context(), sessionUser, allTasks)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// Feed отдаёт календарь по секретному токену.
+// @Summary Calendar feed
+// @Description отдаёт календарь iCalendar с задачами, у которых задан срок. Вход в систему не нужен, доступ даёт
+// @Description токен в адресе. Календарь собирается при каждом запросе, поэтому отражает текущее состояние задач.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Токен календаря, можно с расширением .ics"
+// @Param type query string false "event (по умолчанию) - события VEVENT, todo - задачи VTODO"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /calendar/{token} [get]
+// .
+func (cl *CalendarController) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	kind := c.DefaultQuery("type", service.EventCalendarKind)
+
+	// календарь небольшой, он собирается целиком, чтобы ошибка не оборвала уже начатый ответ.
+	var calendar bytes.Buffer
+	if err := cl.CalendarService.WriteFeed(c.Request.Context(), token, kind, &calendar); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, calendarContentType, calendar.Bytes())
+}
+
+// Watch добавляет задачу в календарь текущего пользователя.
+// @Summary Watch task
+// @Description добавляет задачу в календарь текущего пользователя, даже если она назначена не ему.
+// @Description Пользователь может следить только за своими задачами, ADMIN - за любыми
+// @Tags calendar
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/watch [post]
+// .
+func (cl *CalendarController) Watch(c *gin.Context) {
+	cl.setWatching(c, true)
+}
+
+// Unwatch убирает задачу, назначенную не пользователю, из его календаря.
+// @Summary Unwatch task
+// @Description убирает задачу из календаря текущего пользователя, если она назначена не ему
+// @Tags calendar
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/unwatch [post]
+// .
+func (cl *CalendarController) Unwatch(c *gin.Context) {
+	cl.setWatching(c, false)
+}
+
+func (cl *CalendarController) setWatching(c *gin.Context, watching bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	if watching {
+		err = cl.CalendarService.Watch(c.Request.Context(), sessionUser, taskID)
+	} else {
+		err = cl.CalendarService.Unwatch(c.Request.Context(), sessionUser, taskID)
+	}
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
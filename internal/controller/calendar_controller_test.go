@@ -0,0 +1,94 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var calendarConfig = &config.Calendar{BaseURL: "http://tasks.example", RefreshInterval: 15 * time.Minute}
+
+func TestCalendarController_Feed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarController := NewCalendarController(
+		service.NewCalendarService(taskRepo, userRepo, nil, calendarRepo, calendarConfig))
+
+	router.GET("/calendar/:token", calendarController.Feed)
+
+	dueAt := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "secret").
+		Return(&repository.CalendarFeed{UserID: 2, Token: "secret"}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.User{ID: 2, Login: "user", Role: constant.UserRole, Active: true}, nil)
+	taskRepo.EXPECT().GetWithDueDate(gomock.Any(), 2).
+		Return([]repository.TaskWithLogin{{ID: 5, Title: "Release", Priority: 1, DueAt: &dueAt}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar/secret.ics?type=todo", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/calendar; charset=utf-8", response.Header.Get("Content-Type"))
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(respBodyBytes), "BEGIN:VCALENDAR\r\n"))
+	require.Contains(t, string(respBodyBytes), "BEGIN:VTODO\r\n")
+}
+
+func TestCalendarController_Feed_UnknownToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarController := NewCalendarController(
+		service.NewCalendarService(nil, nil, nil, calendarRepo, calendarConfig))
+
+	router.GET("/calendar/:token", calendarController.Feed)
+
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "revoked").Return(nil, pgx.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar/revoked.ics", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestCalendarController_RotateToken_AllTasksForbidden(t *testing.T) {
+	router := test.SetUpTestRouter()
+	calendarController := NewCalendarController(service.NewCalendarService(nil, nil, nil, nil, calendarConfig))
+
+	router.POST("/calendar/token", withSessionUser(&repository.User{ID: 2, Role: constant.UserRole}),
+		calendarController.RotateToken)
+
+	req := httptest.NewRequest(http.MethodPost, "/calendar/token", strings.NewReader("AllTasks=true"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/chart"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+const (
+	// chartDateLayout - формат параметров from и to.
+	chartDateLayout = "2006-01-02"
+	// chartLabelLayout - формат подписей дней на графиках.
+	chartLabelLayout = "02.01"
+)
+
+type IChartController interface {
+	Page(c *gin.Context)
+	GetBurndown(c *gin.Context)
+	GetCumulativeFlow(c *gin.Context)
+}
+
+type ChartController struct {
+	ChartService service.IChartService
+}
+
+func NewChartController(chartService service.IChartService) *ChartController {
+	return &ChartController{ChartService: chartService}
+}
+
+// Page возвращает страницу с графиками burndown и cumulative flow.
+// @Summary Charts page
+// @Description возвращает страницу с графиками сгорания задач и накопительным графиком потока, нарисованными в SVG.
+// @Description ADMIN видит графики по всем задачам, остальные - по своим.
+// @Tags pages
+// @Produce html
+// @Param from query string false "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to"
+// @Param to query string false "Последний день периода, YYYY-MM-DD; по умолчанию сегодня"
+// @Success 200 {string} string "HTML page"
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/charts [get]
+// .
+func (ch *ChartController) Page(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	from, to, ok := chartPeriod(c)
+	if !ok {
+		return
+	}
+
+	burndown, err := ch.ChartService.GetBurndown(c.Request.Context(), sessionUser, from, to)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+	flow, err := ch.ChartService.GetCumulativeFlow(c.Request.Context(), sessionUser, from, to)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.HTML(http.StatusOK, "charts.html", dto.ChartsTemplateData{
+		From:           c.Query("from"),
+		To:             c.Query("to"),
+		Burndown:       burndownChart(burndown),
+		CumulativeFlow: cumulativeFlowChart(flow),
+	})
+}
+
+// GetBurndown возвращает число невыполненных задач по дням.
+// @Summary Burndown
+// @Description возвращает по дням периода, сколько задач в статусах OPEN и IN_PROGRESS, и идеальную линию
+// @Description их выполнения к концу периода. Дни без снимка пропускаются.
+// @Description ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Param from query string false "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to"
+// @Param to query string false "Последний день периода, YYYY-MM-DD; по умолчанию сегодня"
+// @Success 200 {array} service.BurndownPoint
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/burndown [get]
+// .
+func (ch *ChartController) GetBurndown(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	from, to, ok := chartPeriod(c)
+	if !ok {
+		return
+	}
+
+	burndown, err := ch.ChartService.GetBurndown(c.Request.Context(), sessionUser, from, to)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, burndown)
+}
+
+// GetCumulativeFlow возвращает число задач в каждом статусе по дням.
+// @Summary Cumulative flow
+// @Description возвращает по дням периода число задач в статусах OPEN, IN_PROGRESS и DONE.
+// @Description Дни без снимка пропускаются. ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Param from query string false "Первый день периода, YYYY-MM-DD; по умолчанию 29 дней до to"
+// @Param to query string false "Последний день периода, YYYY-MM-DD; по умолчанию сегодня"
+// @Success 200 {array} service.CumulativeFlowPoint
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/cumulative-flow [get]
+// .
+func (ch *ChartController) GetCumulativeFlow(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	from, to, ok := chartPeriod(c)
+	if !ok {
+		return
+	}
+
+	flow, err := ch.ChartService.GetCumulativeFlow(c.Request.Context(), sessionUser, from, to)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flow)
+}
+
+// chartPeriod читает период графиков из параметров from и to; незаданный день остаётся нулевым.
+// При ошибке сам пишет ответ.
+func chartPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	days := make([]time.Time, 0, 2)
+	for _, param := range []string{"from", "to"} {
+		var day time.Time
+		if query := c.Query(param); query != "" {
+			var err error
+			if day, err = time.Parse(chartDateLayout, query); err != nil {
+				c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": param + " is not a date"})
+				return time.Time{}, time.Time{}, false
+			}
+		}
+		days = append(days, day)
+	}
+
+	return days[0], days[1], true
+}
+
+func burndownChart(burndown []service.BurndownPoint) template.HTML {
+	labels := make([]string, 0, len(burndown))
+	remaining := make([]float64, 0, len(burndown))
+	ideal := make([]float64, 0, len(burndown))
+	for _, point := range burndown {
+		labels = append(labels, point.Day.Format(chartLabelLayout))
+		remaining = append(remaining, float64(point.Remaining))
+		ideal = append(ideal, point.Ideal)
+	}
+
+	return chart.Lines(labels, []chart.Series{
+		{Name: "Осталось задач", Color: "#2196F3", Values: remaining},
+		{Name: "Идеально", Color: "#9E9E9E", Values: ideal, Dashed: true},
+	})
+}
+
+// cumulativeFlowChart складывает статусы снизу вверх от выполненных к открытым, как принято на таких графиках.
+func cumulativeFlowChart(flow []service.CumulativeFlowPoint) template.HTML {
+	labels := make([]string, 0, len(flow))
+	open := make([]float64, 0, len(flow))
+	inProgress := make([]float64, 0, len(flow))
+	done := make([]float64, 0, len(flow))
+	for _, point := range flow {
+		labels = append(labels, point.Day.Format(chartLabelLayout))
+		open = append(open, float64(point.Open))
+		inProgress = append(inProgress, float64(point.InProgress))
+		done = append(done, float64(point.Done))
+	}
+
+	return chart.StackedArea(labels, []chart.Series{
+		{Name: "DONE", Color: "#4CAF50", Values: done},
+		{Name: "IN_PROGRESS", Color: "#2196F3", Values: inProgress},
+		{Name: "OPEN", Color: "#FF9800", Values: open},
+	})
+}
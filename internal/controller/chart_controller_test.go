@@ -0,0 +1,94 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChartController_Page(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	snapshotRepo := mockRepository.NewMockITaskSnapshotRepo(ctrl)
+	chartController := NewChartController(service.NewChartService(snapshotRepo, nil, &config.Charts{MaxDays: 366}))
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/reports/charts", withSessionUser(user), chartController.Page)
+
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 3, 0, 0, 0, 0, time.UTC)
+	snapshotRepo.EXPECT().GetDaily(gomock.Any(), 2, from, to).Return([]repository.DailyStatusCount{
+		{Day: from, Status: constant.OpenTaskStatus, Tasks: 3},
+		{Day: to, Status: constant.DoneTaskStatus, Tasks: 3},
+	}, nil).Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/charts?from=2026-10-01&to=2026-10-03", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	require.Contains(t, body, `value="2026-10-01"`)
+	require.Contains(t, body, "<polyline")
+	require.Contains(t, body, "<polygon")
+	require.Contains(t, body, ">03.10</text>")
+}
+
+func TestChartController_GetCumulativeFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	snapshotRepo := mockRepository.NewMockITaskSnapshotRepo(ctrl)
+	chartController := NewChartController(service.NewChartService(snapshotRepo, nil, &config.Charts{MaxDays: 366}))
+
+	user := &repository.User{ID: 1, Login: "admin", Role: constant.AdminRole}
+	router.GET("/reports/cumulative-flow", withSessionUser(user), chartController.GetCumulativeFlow)
+
+	day := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	snapshotRepo.EXPECT().GetDaily(gomock.Any(), 0, day, day).Return([]repository.DailyStatusCount{
+		{Day: day, Status: constant.InProgressTaskStatus, Tasks: 2},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/cumulative-flow?from=2026-10-01&to=2026-10-01", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var flow []service.CumulativeFlowPoint
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &flow))
+	require.Len(t, flow, 1)
+	require.Equal(t, 2, flow[0].InProgress)
+}
+
+func TestChartController_GetBurndown_InvalidPeriod(t *testing.T) {
+	router := test.SetUpTestRouter()
+	chartController := NewChartController(service.NewChartService(nil, nil, &config.Charts{MaxDays: 366}))
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/reports/burndown", withSessionUser(user), chartController.GetBurndown)
+
+	for _, query := range []string{"from=yesterday", "to=2026-13-01", "from=2026-10-05&to=2026-10-01"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports/burndown?"+query, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode, query)
+	}
+}
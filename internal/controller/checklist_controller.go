@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type IChecklistController interface {
+	GetByTaskID(c *gin.Context)
+	Create(c *gin.Context)
+	Check(c *gin.Context)
+	Uncheck(c *gin.Context)
+	Move(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type ChecklistController struct {
+	ChecklistService service.IChecklistService
+}
+
+func NewChecklistController(checklistService service.IChecklistService) *ChecklistController {
+	return &ChecklistController{ChecklistService: checklistService}
+}
+
+// GetByTaskID возвращает пункты чеклиста задачи.
+// @Summary Get task checklist
+// @Description возвращает пункты чеклиста задачи в порядке их следования. Пользователю доступны только его задачи
+// @Tags checklists
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} repository.ChecklistItem
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist [get]
+// .
+func (ch *ChecklistController) GetByTaskID(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	items, err := ch.ChecklistService.GetByTaskID(c.Request.Context(), sessionUser, taskID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// Create добавляет пункт в конец чеклиста задачи.
+// @Summary Create checklist item
+// @Description добавляет пункт в конец чеклиста задачи
+// @Tags checklists
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param Title formData string true "Текст пункта"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist [post]
+// .
+func (ch *ChecklistController) Create(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	_, err = ch.ChecklistService.Create(c.Request.Context(), sessionUser, taskID, c.PostForm("Title"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+// Check отмечает пункт чеклиста выполненным.
+// @Summary Check checklist item
+// @Description отмечает пункт чеклиста выполненным и записывает это в историю задачи
+// @Tags checklists
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist/{itemId}/check [post]
+// .
+func (ch *ChecklistController) Check(c *gin.Context) {
+	ch.setDone(c, true)
+}
+
+// Uncheck снимает отметку о выполнении с пункта чеклиста.
+// @Summary Uncheck checklist item
+// @Description снимает отметку о выполнении с пункта чеклиста и записывает это в историю задачи
+// @Tags checklists
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist/{itemId}/uncheck [post]
+// .
+func (ch *ChecklistController) Uncheck(c *gin.Context) {
+	ch.setDone(c, false)
+}
+
+// Move перемещает пункт чеклиста на указанную позицию.
+// @Summary Move checklist item
+// @Description перемещает пункт чеклиста на указанную позицию, нумерация позиций начинается с 1
+// @Tags checklists
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Param Position formData integer true "Новая позиция"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist/{itemId}/move [post]
+// .
+func (ch *ChecklistController) Move(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+	position, err := strconv.Atoi(c.PostForm("Position"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "position is not a number"})
+		return
+	}
+
+	if err = ch.ChecklistService.Move(c.Request.Context(), sessionUser, taskID, itemID, position); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+// Delete удаляет пункт чеклиста.
+// @Summary Delete checklist item
+// @Description удаляет пункт чеклиста
+// @Tags checklists
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/checklist/{itemId}/delete [post]
+// .
+func (ch *ChecklistController) Delete(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	if err := ch.ChecklistService.Delete(c.Request.Context(), sessionUser, taskID, itemID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+func (ch *ChecklistController) setDone(c *gin.Context, done bool) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	taskID, itemID, ok := parseChecklistParams(c)
+	if !ok {
+		return
+	}
+
+	if err := ch.ChecklistService.SetDone(c.Request.Context(), sessionUser, taskID, itemID, done); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+func parseChecklistParams(c *gin.Context) (int, int, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return 0, 0, false
+	}
+	itemID, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "checklist item ID is not number"})
+		return 0, 0, false
+	}
+
+	return taskID, itemID, true
+}
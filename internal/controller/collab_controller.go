@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"golang.org/x/net/websocket"
+)
+
+// maxCollabMessageSize - максимальный размер сообщения от клиента: значение поля и служебные данные.
+const maxCollabMessageSize = 64 << 10
+
+type ICollabController interface {
+	Connect(c *gin.Context)
+}
+
+type CollabController struct {
+	CollabService service.ICollabService
+}
+
+func NewCollabController(collabService service.ICollabService) *CollabController {
+	return &CollabController{CollabService: collabService}
+}
+
+// Connect открывает WebSocket-канал совместной работы над задачей.
+// @Summary Task collaboration channel
+// @Description открывает WebSocket-канал задачи. Сервер присылает JSON-сообщения presence (кто открыл задачу),
+// @Description field (кто какое поле меняет), saved и deleted (задача сохранена или удалена) и comment (новый
+// @Description комментарий). Клиент отправляет {"type":"field","field":"Title","value":"..."}, пока меняет поле формы.
+// @Description Подключение разрешено только со страниц приложения (заголовок Origin).
+// @Tags tasks
+// @Param id path string true "Task ID"
+// @Success 101 {object} collab.Message
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/ws [get]
+// .
+func (co *CollabController) Connect(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	if err = co.CollabService.CheckAccess(c.Request.Context(), sessionUser, taskID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(conn *websocket.Conn) {
+			conn.MaxPayloadBytes = maxCollabMessageSize
+			// сроки чтения и записи сервера остаются на захваченном соединении и оборвали бы долгую сессию.
+			_ = conn.SetDeadline(time.Time{})
+			client := co.CollabService.Join(taskID, sessionUser)
+			defer co.CollabService.Leave(taskID, client)
+
+			go func() {
+				for message := range client.Messages() {
+					if sendErr := websocket.JSON.Send(conn, message); sendErr != nil {
+						break
+					}
+				}
+				// клиент покинул канал или отключён хабом - закрываем соединение, чтобы завершить чтение.
+				_ = conn.Close()
+			}()
+
+			for {
+				var message collab.Message
+				if receiveErr := websocket.JSON.Receive(conn, &message); receiveErr != nil {
+					return
+				}
+				if message.Type == collab.FieldMessage {
+					// некорректные сообщения клиента не рассылаются и не разрывают соединение.
+					_ = co.CollabService.ChangeField(taskID, client, message.Field, message.Value)
+				}
+			}
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// checkSameOrigin разрешает подключение только со страниц того же хоста: иначе чужой сайт мог бы открыть
+// канал с cookie сессии пользователя.
+func checkSameOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil || origin.Host != req.Host {
+		return errors.New("websocket origin does not match host")
+	}
+	config.Origin = origin
+
+	return nil
+}
@@ -0,0 +1,118 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/websocket"
+)
+
+func TestCollabController_Connect_FieldChangeBroadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	collabService := service.NewCollabService(collab.NewHub(10), taskRepo, nil)
+	collabController := NewCollabController(collabService)
+
+	router.GET("/user/tasks/:id/ws", withSessionUser(&repository.User{ID: 1, Login: "user", Role: constant.UserRole}),
+		collabController.Connect)
+	router.GET("/admin/tasks/:id/ws",
+		withSessionUser(&repository.User{ID: 2, Login: "admin", Role: constant.AdminRole}), collabController.Connect)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 1}, nil).Times(2)
+
+	editor := dialCollab(t, server, "/user/tasks/1/ws")
+	defer editor.Close()
+	receiveCollab(t, editor)
+	viewer := dialCollab(t, server, "/admin/tasks/1/ws")
+	defer viewer.Close()
+
+	require.Equal(t, collab.Message{Type: collab.PresenceMessage, Users: []string{"admin", "user"}},
+		receiveCollab(t, editor))
+	require.Equal(t, collab.Message{Type: collab.PresenceMessage, Users: []string{"admin", "user"}},
+		receiveCollab(t, viewer))
+
+	err := websocket.JSON.Send(editor, collab.Message{Type: collab.FieldMessage, Field: "Title", Value: "Release"})
+	require.NoError(t, err)
+	require.Equal(t, collab.Message{Type: collab.FieldMessage, User: "user", Field: "Title", Value: "Release"},
+		receiveCollab(t, viewer))
+
+	require.NoError(t, editor.Close())
+	require.Equal(t, collab.Message{Type: collab.PresenceMessage, Users: []string{"admin"}}, receiveCollab(t, viewer))
+}
+
+func TestCollabController_Connect_ForeignOrigin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	collabController := NewCollabController(service.NewCollabService(collab.NewHub(10), taskRepo, nil))
+
+	router.GET("/tasks/:id/ws", withSessionUser(&repository.User{ID: 1, Login: "user", Role: constant.UserRole}),
+		collabController.Connect)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 1}, nil)
+
+	_, err := websocket.Dial(wsURL(server, "/tasks/1/ws"), "", "http://evil.example.com")
+	require.Error(t, err)
+}
+
+func TestCollabController_Connect_OtherUsersTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	collabController := NewCollabController(service.NewCollabService(collab.NewHub(10), taskRepo, nil))
+
+	router.GET("/tasks/:id/ws", withSessionUser(&repository.User{ID: 1, Login: "user", Role: constant.UserRole}),
+		collabController.Connect)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/1/ws", nil)
+
+	w := httptest.NewRecorder()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
+
+func dialCollab(t *testing.T, server *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+
+	conn, err := websocket.Dial(wsURL(server, path), "", server.URL)
+	require.NoError(t, err)
+
+	return conn
+}
+
+func receiveCollab(t *testing.T, conn *websocket.Conn) collab.Message {
+	t.Helper()
+
+	var message collab.Message
+	require.NoError(t, websocket.JSON.Receive(conn, &message))
+
+	return message
+}
+
+func wsURL(server *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + path
+}
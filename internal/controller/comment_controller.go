@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ICommentController interface {
+	GetByTaskID(c *gin.Context)
+	Create(c *gin.Context)
+}
+
+type CommentController struct {
+	CommentService service.ICommentService
+}
+
+func NewCommentController(commentService service.ICommentService) *CommentController {
+	return &CommentController{CommentService: commentService}
+}
+
+// GetByTaskID возвращает комментарии задачи.
+// @Summary Get task comments
+// @Description возвращает комментарии задачи в порядке добавления
+// @Tags comments
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} repository.Comment
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/comments [get]
+// .
+func (co *CommentController) GetByTaskID(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	comments, err := co.CommentService.GetByTaskID(c.Request.Context(), taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// Create добавляет комментарий к задаче.
+// @Summary Create comment
+// @Description добавляет комментарий к задаче и рассылает его пользователям, открывшим задачу
+// @Tags comments
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param Body formData string true "Текст комментария"
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/comments [post]
+// .
+func (co *CommentController) Create(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	if _, err = co.CommentService.Create(c.Request.Context(), sessionUser, taskID, c.PostForm("Body")); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
@@ -0,0 +1,72 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommentController_Create_CommentCreated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	commentRepo := mockRepository.NewMockICommentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	commentService := service.NewCommentService(commentRepo, taskRepo, collab.NewHub(10),
+		service.NewNotificationService(nil, nil), service.NewMentionService(nil, nil, nil, nil))
+	commentController := NewCommentController(commentService)
+
+	router.POST("/tasks/:id/comments", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		commentController.Create)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/1/comments", nil)
+	values := url.Values{}
+	values.Set("Body", "Looks good")
+	req.PostForm = values
+
+	w := httptest.NewRecorder()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 1}, nil)
+	commentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusFound, response.StatusCode)
+	require.Equal(t, "/tasks/1", response.Header.Get("Location"))
+}
+
+func TestCommentController_Create_EmptyBody(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	commentController := NewCommentController(service.NewCommentService(nil, nil, collab.NewHub(10), nil, nil))
+
+	router.POST("/tasks/:id/comments", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		commentController.Create)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/1/comments", nil)
+	req.PostForm = url.Values{}
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "{\"error\":\"bad request\"}", string(respBodyBytes))
+}
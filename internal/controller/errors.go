@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/errs"
+)
+
+// writeServiceError переводит ошибку сервисного слоя в HTTP-ответ.
+func writeServiceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errs.BadReqErr{}):
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+	case errors.Is(err, errs.NotFoundErr{}):
+		c.JSON(http.StatusNotFound, dto.ResponseMap{"error": err.Error()})
+	case errors.Is(err, errs.ForbiddenErr{}):
+		c.JSON(http.StatusForbidden, dto.ResponseMap{"error": err.Error()})
+	case errors.Is(err, errs.ConflictErr{}), errors.Is(err, errs.TemplateExistsErr{}),
+		errors.Is(err, errs.FilterExistsErr{}):
+		c.JSON(http.StatusConflict, dto.ResponseMap{"error": err.Error()})
+	case errors.Is(err, errs.TooLargeErr{}):
+		c.JSON(http.StatusRequestEntityTooLarge, dto.ResponseMap{"error": err.Error()})
+	case errors.Is(err, errs.UnsupportedMediaTypeErr{}):
+		c.JSON(http.StatusUnsupportedMediaType, dto.ResponseMap{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+	}
+}
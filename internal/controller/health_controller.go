@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type IHealthController interface {
+	Healthz(c *gin.Context)
+	Readyz(c *gin.Context)
+	GetReport(c *gin.Context)
+}
+
+type HealthController struct {
+	HealthService service.IHealthService
+}
+
+func NewHealthController(healthService service.IHealthService) *HealthController {
+	return &HealthController{HealthService: healthService}
+}
+
+// Healthz сообщает, что процесс запущен и обрабатывает запросы.
+// @Summary Liveness probe
+// @Description всегда отвечает 200, пока процесс обрабатывает запросы; зависимости не проверяются.
+// @Description Аутентификация не нужна
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.ResponseMap
+// @Router /healthz [get]
+// .
+func (h *HealthController) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.ResponseMap{"status": service.HealthStatusOK})
+}
+
+// Readyz сообщает, готово ли приложение обслуживать запросы.
+// @Summary Readiness probe
+// @Description проверяет, что база отвечает через пул соединений и миграции применены до версии, встроенной
+// @Description в приложение. Тексты ошибок не возвращаются, они пишутся в лог. Аутентификация не нужна
+// @Tags health
+// @Produce json
+// @Success 200 {object} service.Readiness
+// @Failure 503 {object} service.Readiness
+// @Router /readyz [get]
+// .
+func (h *HealthController) Readyz(c *gin.Context) {
+	readiness := h.HealthService.GetReadiness(c.Request.Context())
+	if readiness.Status != service.HealthStatusOK {
+		c.JSON(http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
+
+// GetReport возвращает подробный отчёт о состоянии приложения.
+// @Summary Health report
+// @Description возвращает проверки готовности с текстами ошибок, время работы, состояние пула соединений
+// @Description и число событий outbox в каждом статусе. Только для администраторов
+// @Tags health
+// @Produce json
+// @Success 200 {object} service.HealthReport
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Router /health [get]
+// .
+func (h *HealthController) GetReport(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	// в отчёте тексты ошибок базы, поэтому роль проверяется и здесь.
+	if sessionUser.Role != constant.AdminRole {
+		c.JSON(http.StatusForbidden, dto.ResponseMap{"error": "you should be admin for the action"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.HealthService.GetReport(c.Request.Context()))
+}
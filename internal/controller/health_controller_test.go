@@ -0,0 +1,65 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthController_Readyz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	healthRepo := mockRepository.NewMockIHealthRepo(ctrl)
+	healthService := service.NewHealthService(healthRepo, 20, &config.Health{Timeout: time.Second})
+	healthController := NewHealthController(healthService)
+	router.GET("/readyz", healthController.Readyz)
+
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	healthRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(int64(20), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(errors.New("password authentication failed"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Result().StatusCode)
+	require.NotContains(t, w.Body.String(), "password")
+
+	var readiness service.Readiness
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &readiness))
+	require.Equal(t, service.HealthStatusFail, readiness.Status)
+	require.Equal(t, service.HealthStatusFail, readiness.Database.Status)
+}
+
+func TestHealthController_GetReport_ForbiddenForUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	healthRepo := mockRepository.NewMockIHealthRepo(ctrl)
+	healthService := service.NewHealthService(healthRepo, 20, &config.Health{Timeout: time.Second})
+	healthController := NewHealthController(healthService)
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/health", withSessionUser(user), healthController.GetReport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
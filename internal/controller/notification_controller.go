@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type INotificationController interface {
+	GetInbox(c *gin.Context)
+	GetUnreadCount(c *gin.Context)
+	MarkRead(c *gin.Context)
+	MarkAllRead(c *gin.Context)
+	UpdatePreferences(c *gin.Context)
+	UpdateEmailSettings(c *gin.Context)
+}
+
+type NotificationController struct {
+	NotificationService service.INotificationService
+	EmailService        service.IEmailService
+}
+
+func NewNotificationController(
+	notificationService service.INotificationService,
+	emailService service.IEmailService,
+) *NotificationController {
+	return &NotificationController{
+		NotificationService: notificationService,
+		EmailService:        emailService,
+	}
+}
+
+// GetInbox отображает входящие уведомления пользователя и его настройки уведомлений.
+// @Summary Notifications inbox
+// @Description отображает последние уведомления пользователя и настройки, о каких событиях уведомлять
+// @Tags pages
+// @Produce html
+// @Success 200 {object} dto.NotificationsTemplateData
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications [get]
+// .
+func (n *NotificationController) GetInbox(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	notifications, err := n.NotificationService.GetByUser(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	preferences, err := n.NotificationService.GetPreferences(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	unread, err := n.NotificationService.CountUnread(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	emailSettings, err := n.EmailService.GetSettings(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	templateData := dto.NotificationsTemplateData{
+		Notifications: notifications,
+		Unread:        unread,
+		Preferences:   preferences,
+		EventTitles:   constant.NotificationEventTitles,
+		Email:         emailSettings.Email,
+		EmailDigest:   emailSettings.EmailDigest,
+	}
+
+	c.HTML(http.StatusOK, "notifications.html", templateData)
+}
+
+// GetUnreadCount возвращает количество непрочитанных уведомлений.
+// @Summary Unread notifications count
+// @Description возвращает количество непрочитанных уведомлений текущего пользователя
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} dto.UnreadCountResponse
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications/unread-count [get]
+// .
+func (n *NotificationController) GetUnreadCount(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	count, err := n.NotificationService.CountUnread(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.UnreadCountResponse{Count: count})
+}
+
+// MarkRead отмечает уведомление прочитанным.
+// @Summary Mark notification as read
+// @Description отмечает уведомление текущего пользователя прочитанным
+// @Tags notifications
+// @Produce json
+// @Param notificationId path string true "Notification ID"
+// @Success 302 {string} Redirected to inbox
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications/{notificationId}/read [post]
+// .
+func (n *NotificationController) MarkRead(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+	notificationID, err := strconv.Atoi(c.Param("notificationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "notification ID is not number"})
+		return
+	}
+
+	if err = n.NotificationService.MarkRead(c.Request.Context(), sessionUser, notificationID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/notifications")
+}
+
+// MarkAllRead отмечает все уведомления прочитанными.
+// @Summary Mark all notifications as read
+// @Description отмечает все уведомления текущего пользователя прочитанными
+// @Tags notifications
+// @Produce json
+// @Success 302 {string} Redirected to inbox
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications/read-all [post]
+// .
+func (n *NotificationController) MarkAllRead(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	if err := n.NotificationService.MarkAllRead(c.Request.Context(), sessionUser); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/notifications")
+}
+
+// UpdatePreferences сохраняет, о каких событиях уведомлять пользователя.
+// @Summary Update notification preferences
+// @Description включает уведомления о переданных событиях и отключает остальные.
+// @Description Типы событий: TASK_ASSIGNED, TASK_STATUS_CHANGED, USER_MENTIONED, FILTER_MATCHED, COMMENT_ADDED,
+// @Description TASK_DUE_SOON
+// @Tags notifications
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param Events formData []string false "Включённые типы событий" collectionFormat(multi)
+// @Success 302 {string} Redirected to inbox
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications/preferences [post]
+// .
+func (n *NotificationController) UpdatePreferences(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	err := n.NotificationService.SetPreferences(c.Request.Context(), sessionUser, c.PostFormArray("Events"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/notifications")
+}
+
+// UpdateEmailSettings сохраняет адрес для писем с уведомлениями и режим отправки.
+// @Summary Update email notification settings
+// @Description сохраняет адрес для писем с уведомлениями (пустой - не отправлять письма)
+// @Description и режим: сразу по каждому событию или ежедневной сводкой
+// @Tags notifications
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param Email formData string false "E-mail"
+// @Param Digest formData bool false "Ежедневная сводка вместо отдельных писем"
+// @Success 302 {string} Redirected to inbox
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /notifications/email [post]
+// .
+func (n *NotificationController) UpdateEmailSettings(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	digest := c.PostForm("Digest") == "true"
+	err := n.EmailService.UpdateSettings(c.Request.Context(), sessionUser, c.PostForm("Email"), digest)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/notifications")
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type IReportController interface {
+	Page(c *gin.Context)
+	GetThroughput(c *gin.Context)
+	GetCycleTime(c *gin.Context)
+	GetAging(c *gin.Context)
+	GetBreakdown(c *gin.Context)
+}
+
+type ReportController struct {
+	ReportService service.IReportService
+}
+
+func NewReportController(reportService service.IReportService) *ReportController {
+	return &ReportController{ReportService: reportService}
+}
+
+// Page возвращает страницу отчётов по задачам.
+// @Summary Reports page
+// @Description возвращает страницу со всеми отчётами по задачам за период.
+// @Description ADMIN видит отчёты по всем задачам, остальные - по своим.
+// @Tags pages
+// @Produce html
+// @Param weeks query integer false "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12"
+// @Success 200 {string} string "HTML page"
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports [get]
+// .
+func (r *ReportController) Page(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	weeks, ok := reportWeeks(c)
+	if !ok {
+		return
+	}
+
+	report, err := r.ReportService.GetReport(c.Request.Context(), sessionUser, weeks)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.HTML(http.StatusOK, "reports.html", report)
+}
+
+// GetThroughput возвращает число созданных и выполненных задач по неделям.
+// @Summary Weekly throughput
+// @Description возвращает по неделям за период, сколько задач создано и сколько переведено в DONE.
+// @Description Недели без задач возвращаются с нулями. ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Param weeks query integer false "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12"
+// @Success 200 {array} service.WeeklyThroughput
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/throughput [get]
+// .
+func (r *ReportController) GetThroughput(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	weeks, ok := reportWeeks(c)
+	if !ok {
+		return
+	}
+
+	throughput, err := r.ReportService.GetThroughput(c.Request.Context(), sessionUser, weeks)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, throughput)
+}
+
+// GetCycleTime возвращает среднее время выполнения задач.
+// @Summary Cycle time
+// @Description возвращает среднее время от открытия до выполнения задач, выполненных за период,
+// @Description по истории статусов. ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Param weeks query integer false "Период в неделях, включая текущую: от 1 до 104, по умолчанию 12"
+// @Success 200 {object} repository.CycleTime
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/cycle-time [get]
+// .
+func (r *ReportController) GetCycleTime(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	weeks, ok := reportWeeks(c)
+	if !ok {
+		return
+	}
+
+	cycleTime, err := r.ReportService.GetCycleTime(c.Request.Context(), sessionUser, weeks)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cycleTime)
+}
+
+// GetAging возвращает число невыполненных задач по возрасту.
+// @Summary Open task aging
+// @Description возвращает число невыполненных задач, созданных 0-7, 8-30, 31-90 и больше 90 дней назад.
+// @Description ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Success 200 {array} service.AgingBucket
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/aging [get]
+// .
+func (r *ReportController) GetAging(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	aging, err := r.ReportService.GetAging(c.Request.Context(), sessionUser)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, aging)
+}
+
+// GetBreakdown возвращает число задач по исполнителям и приоритетам.
+// @Summary Task breakdown
+// @Description возвращает число задач в каждом статусе по исполнителям и по приоритетам.
+// @Description ADMIN видит все задачи, остальные - свои.
+// @Tags reports
+// @Produce json
+// @Success 200 {object} service.TaskBreakdown
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /reports/breakdown [get]
+// .
+func (r *ReportController) GetBreakdown(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	breakdown, err := r.ReportService.GetBreakdown(c.Request.Context(), sessionUser)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
+// reportWeeks читает период отчёта из параметра weeks; 0, если он не задан. При ошибке сам пишет ответ.
+func reportWeeks(c *gin.Context) (int, bool) {
+	weeksQuery := c.Query("weeks")
+	if weeksQuery == "" {
+		return 0, true
+	}
+
+	weeks, err := strconv.Atoi(weeksQuery)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "weeks is not a number"})
+		return 0, false
+	}
+
+	return weeks, true
+}
@@ -0,0 +1,65 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportController_Page(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	reportRepo := mockRepository.NewMockIReportRepo(ctrl)
+	reportController := NewReportController(service.NewReportService(reportRepo))
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/reports", withSessionUser(user), reportController.Page)
+
+	reportRepo.EXPECT().CountCreatedByWeek(gomock.Any(), 2, gomock.Any()).Return([]repository.WeekCount{}, nil)
+	reportRepo.EXPECT().CountCompletedByWeek(gomock.Any(), 2, gomock.Any()).Return([]repository.WeekCount{}, nil)
+	reportRepo.EXPECT().GetCycleTime(gomock.Any(), 2, gomock.Any()).
+		Return(&repository.CycleTime{Tasks: 2, AverageHours: 36.5}, nil)
+	reportRepo.EXPECT().CountOpenByAge(gomock.Any(), 2, gomock.Len(3)).Return([]int{1, 0, 0, 0}, nil)
+	reportRepo.EXPECT().CountByAssignee(gomock.Any(), 2).Return([]repository.AssigneeStatusCounts{
+		{UserLogin: "user", StatusCounts: repository.StatusCounts{Open: 1, Done: 2}},
+	}, nil)
+	reportRepo.EXPECT().CountByPriority(gomock.Any(), 2).Return([]repository.PriorityStatusCounts{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports?weeks=4", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	require.Contains(t, body, "36.5 ч.")
+	require.Contains(t, body, "<td>user</td>")
+}
+
+func TestReportController_GetThroughput_InvalidWeeks(t *testing.T) {
+	router := test.SetUpTestRouter()
+	reportController := NewReportController(service.NewReportService(nil))
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/reports/throughput", withSessionUser(user), reportController.GetThroughput)
+
+	for _, weeks := range []string{"many", "0x", "105"} {
+		req := httptest.NewRequest(http.MethodGet, "/reports/throughput?weeks="+weeks, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode, weeks)
+	}
+}
@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clearWriteDeadline снимает для ответа WriteTimeout сервера: поток событий и большие файлы отправляются дольше него.
+// Если ResponseWriter не поддерживает сроки, как в тестах, ничего не делает.
+func clearWriteDeadline(c *gin.Context) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+}
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ISavedFilterController interface {
+	GetAll(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Pin(c *gin.Context)
+	Unpin(c *gin.Context)
+	Subscribe(c *gin.Context)
+	Unsubscribe(c *gin.Context)
+}
+
+type SavedFilterController struct {
+	SavedFilterService service.ISavedFilterService
+}
+
+func NewSavedFilterController(savedFilterService service.ISavedFilterService) *SavedFilterController {
+	return &SavedFilterController{SavedFilterService: savedFilterService}
+}
+
+// GetAll возвращает сохранённые фильтры, доступные пользователю.
+// @Summary Get saved filters
+// @Description возвращает свои фильтры пользователя и фильтры с видимостью SHARED и GLOBAL
+// @Description с настройками пользователя: pinned - закреплён на странице задач, notify - подписка на уведомления
+// @Tags filters
+// @Produce json
+// @Success 200 {array} repository.SavedFilter
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters [get]
+// .
+func (f *SavedFilterController) GetAll(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	filters, err := f.SavedFilterService.GetVisible(c.Request.Context(), sessionUser)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
+
+// Create сохраняет фильтр задач.
+// @Summary Create saved filter
+// @Description сохраняет именованный фильтр задач: статус, приоритет, исполнитель, метки, текст и сортировка.
+// @Description Видимость: PRIVATE - только владельцу, SHARED - всем пользователям,
+// @Description GLOBAL - всем пользователям и закреплён у всех, только для администраторов.
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Param filter body dto.SavedFilterRequest true "Saved filter"
+// @Success 201 {object} repository.SavedFilter
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 409 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters [post]
+// .
+func (f *SavedFilterController) Create(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	var request dto.SavedFilterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	savedFilter := toSavedFilter(&request)
+	if err := f.SavedFilterService.Create(c.Request.Context(), sessionUser, savedFilter); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, savedFilter)
+}
+
+// Update изменяет сохранённый фильтр.
+// @Summary Update saved filter
+// @Description заменяет имя, условия и видимость фильтра. Менять фильтр может владелец или администратор.
+// @Tags filters
+// @Accept json
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Param filter body dto.SavedFilterRequest true "Saved filter"
+// @Success 200 {object} repository.SavedFilter
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 409 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id} [put]
+// .
+func (f *SavedFilterController) Update(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	filterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "filter ID is not number"})
+		return
+	}
+
+	var request dto.SavedFilterRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	savedFilter := toSavedFilter(&request)
+	savedFilter.ID = filterID
+	if err = f.SavedFilterService.Update(c.Request.Context(), sessionUser, savedFilter); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, savedFilter)
+}
+
+// Delete удаляет сохранённый фильтр.
+// @Summary Delete saved filter
+// @Description удаляет фильтр вместе с закреплениями и подписками. Удалять фильтр может владелец или администратор.
+// @Tags filters
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id} [delete]
+// .
+func (f *SavedFilterController) Delete(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	filterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "filter ID is not number"})
+		return
+	}
+
+	if err = f.SavedFilterService.Delete(c.Request.Context(), sessionUser, filterID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("filter '%d' deleted", filterID)})
+}
+
+// Pin закрепляет фильтр на странице задач.
+// @Summary Pin saved filter
+// @Description закрепляет доступный пользователю фильтр на его странице задач
+// @Tags filters
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id}/pin [post]
+// .
+func (f *SavedFilterController) Pin(c *gin.Context) {
+	f.setSettings(c, f.SavedFilterService.SetPinned, true, "pinned")
+}
+
+// Unpin открепляет фильтр от страницы задач.
+// @Summary Unpin saved filter
+// @Description открепляет фильтр от страницы задач пользователя. Фильтры GLOBAL остаются закреплёнными.
+// @Tags filters
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id}/unpin [post]
+// .
+func (f *SavedFilterController) Unpin(c *gin.Context) {
+	f.setSettings(c, f.SavedFilterService.SetPinned, false, "unpinned")
+}
+
+// Subscribe подписывает пользователя на уведомления по фильтру.
+// @Summary Subscribe to saved filter
+// @Description подписывает пользователя на уведомления о созданных задачах и о задачах, у которых сменился статус
+// @Description или исполнитель, если задача подходит под фильтр и видна пользователю
+// @Tags filters
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id}/subscribe [post]
+// .
+func (f *SavedFilterController) Subscribe(c *gin.Context) {
+	f.setSettings(c, f.SavedFilterService.SetNotify, true, "subscribed")
+}
+
+// Unsubscribe отписывает пользователя от уведомлений по фильтру.
+// @Summary Unsubscribe from saved filter
+// @Description отписывает пользователя от уведомлений по фильтру
+// @Tags filters
+// @Produce json
+// @Param id path string true "Filter ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /filters/{id}/unsubscribe [post]
+// .
+func (f *SavedFilterController) Unsubscribe(c *gin.Context) {
+	f.setSettings(c, f.SavedFilterService.SetNotify, false, "unsubscribed")
+}
+
+func (f *SavedFilterController) setSettings(c *gin.Context,
+	set func(ctx context.Context, user *repository.User, filterID int, value bool) error,
+	value bool,
+	action string,
+) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	filterID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "filter ID is not number"})
+		return
+	}
+
+	if err = set(c.Request.Context(), sessionUser, filterID, value); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("filter '%d' %s", filterID, action)})
+}
+
+// taskFilterFromQuery собирает фильтр задач из параметров запроса. filterId - сохранённый фильтр, доступный
+// пользователю; status, priority, userLogin, label (можно повторять), text и sort дополняют или заменяют
+// его условия. При ошибке сам пишет ответ и возвращает false.
+func taskFilterFromQuery(c *gin.Context,
+	savedFilterService service.ISavedFilterService,
+	user *repository.User,
+) (*repository.TaskFilter, bool) {
+	filter := &repository.TaskFilter{}
+	if filterIDQuery := c.Query("filterId"); filterIDQuery != "" {
+		filterID, err := strconv.Atoi(filterIDQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "filter ID is not number"})
+			return nil, false
+		}
+
+		savedFilter, err := savedFilterService.Resolve(c.Request.Context(), user, filterID)
+		if err != nil {
+			writeServiceError(c, err)
+			return nil, false
+		}
+		filter = savedFilter
+	}
+
+	if status := c.Query("status"); status != "" {
+		filter.Status = status
+	}
+	if priorityQuery := c.Query("priority"); priorityQuery != "" {
+		priority, err := strconv.Atoi(priorityQuery)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "priority is not a number"})
+			return nil, false
+		}
+		filter.Priority = priority
+	}
+	if userLogin := c.Query("userLogin"); userLogin != "" {
+		filter.UserLogin = userLogin
+	}
+	if labels := c.QueryArray("label"); len(labels) > 0 {
+		filter.Labels = append(filter.Labels, labels...)
+	}
+	if text := c.Query("text"); text != "" {
+		filter.Text = text
+	}
+	if sort := c.Query("sort"); sort != "" {
+		filter.Sort = sort
+	}
+
+	return filter, true
+}
+
+func toSavedFilter(request *dto.SavedFilterRequest) *repository.SavedFilter {
+	return &repository.SavedFilter{
+		Name:       request.Name,
+		Filter:     request.Filter,
+		Visibility: request.Visibility,
+	}
+}
@@ -0,0 +1,33 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedFilterController_Create_GlobalForbiddenForUser(t *testing.T) {
+	router := test.SetUpTestRouter()
+	savedFilterController := NewSavedFilterController(service.NewSavedFilterService(nil))
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.POST("/filters", withSessionUser(user), savedFilterController.Create)
+
+	req := httptest.NewRequest(http.MethodPost, "/filters",
+		strings.NewReader(`{"name":"Для всех","filter":{"status":"OPEN"},"visibility":"GLOBAL"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
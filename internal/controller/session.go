@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// getSessionUser возвращает пользователя текущей сессии или nil, если ответ с ошибкой уже отправлен.
+func getSessionUser(c *gin.Context) *repository.User {
+	session := sessions.Default(c)
+	user := session.Get(constant.UserSessionKey)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, dto.ResponseMap{"error": "unauthorized"})
+		return nil
+	}
+
+	sessionUser, ok := user.(*repository.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return nil
+	}
+
+	return sessionUser
+}
+
+// getSessionAdmin возвращает пользователя текущей сессии, если он ADMIN, или nil, если ответ с ошибкой уже отправлен.
+func getSessionAdmin(c *gin.Context) *repository.User {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return nil
+	}
+	if sessionUser.Role != constant.AdminRole {
+		c.JSON(http.StatusForbidden, dto.ResponseMap{"error": "you should be admin for the action"})
+		return nil
+	}
+
+	return sessionUser
+}
+
+// findSessionUser возвращает пользователя текущей сессии или nil, ничего не отвечая клиенту.
+func findSessionUser(c *gin.Context) *repository.User {
+	sessionUser, _ := sessions.Default(c).Get(constant.UserSessionKey).(*repository.User)
+	return sessionUser
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ITaskBulkController interface {
+	Apply(c *gin.Context)
+}
+
+type TaskBulkController struct {
+	TaskBulkService    service.ITaskBulkService
+	SavedFilterService service.ISavedFilterService
+}
+
+func NewTaskBulkController(
+	taskBulkService service.ITaskBulkService,
+	savedFilterService service.ISavedFilterService,
+) *TaskBulkController {
+	return &TaskBulkController{TaskBulkService: taskBulkService, SavedFilterService: savedFilterService}
+}
+
+// Apply выполняет массовую операцию над задачами.
+// @Summary Bulk task operation
+// @Description меняет статус, приоритет, исполнителя, добавляет метку или удаляет задачи из списка taskIds
+// @Description или, если список пуст, задачи по фильтру filter или сохранённому фильтру filterId.
+// @Description Все изменения выполняются в одной транзакции.
+// @Description Для каждой задачи возвращается результат: OK, FORBIDDEN, NOT_FOUND или CONFLICT.
+// @Description Пользователь, не являющийся ADMIN, может менять только свои задачи и не может назначать их на других.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body dto.TaskBulkRequest true "Bulk operation"
+// @Success 200 {array} service.TaskBulkResult
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/bulk [post]
+// .
+func (b *TaskBulkController) Apply(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	var request dto.TaskBulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	if len(request.TaskIDs) == 0 && request.FilterID != 0 {
+		filter, err := b.SavedFilterService.Resolve(c.Request.Context(), sessionUser, request.FilterID)
+		if err != nil {
+			writeServiceError(c, err)
+			return
+		}
+		request.Filter = filter
+	}
+
+	results, err := b.TaskBulkService.Apply(c.Request.Context(),
+		sessionUser,
+		request.Action,
+		request.Value,
+		request.TaskIDs,
+		request.Filter,
+	)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,73 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskBulkController_Apply_ResultsReturned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	bulkService := service.NewTaskBulkService(taskRepo, nil, nil, outboxRepo, newTransactorMock(ctrl), nil)
+	bulkController := NewTaskBulkController(bulkService, nil)
+
+	router.POST("/tasks/bulk", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		bulkController.Apply)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk",
+		strings.NewReader(`{"action":"priority","value":"2","taskIds":[1,2]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 1}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.Task{ID: 2, UserID: 3}, nil)
+	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, `[{"taskId":1,"status":"OK"},{"taskId":2,"status":"FORBIDDEN"}]`, string(respBodyBytes))
+}
+
+func TestTaskBulkController_Apply_BadRequest(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	bulkController := NewTaskBulkController(service.NewTaskBulkService(nil, nil, nil, nil, nil, nil), nil)
+
+	router.POST("/tasks/bulk", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		bulkController.Apply)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/bulk", strings.NewReader(`{"action":"status","value":"DONE"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"error":"bad request"}`, string(respBodyBytes))
+}
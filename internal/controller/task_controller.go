@@ -1,42 +1,71 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/dto"
 	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/markdown"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	"github.com/romakorinenko/task-manager/internal/service"
 )
 
+// dueDateLayout - формат значения поля datetime-local.
+const dueDateLayout = "2006-01-02T15:04"
+
 type ITaskController interface {
 	GetByUserLogin(c *gin.Context)
 	GetAll(c *gin.Context)
 	GetByID(c *gin.Context)
 	Edit(c *gin.Context)
 	Update(c *gin.Context)
+	SetDueDate(c *gin.Context)
 	Delete(c *gin.Context)
 	Create(c *gin.Context)
 	CreateTemplate(c *gin.Context)
+	Preview(c *gin.Context)
 	GetByStatus(c *gin.Context)
 	GetByPriority(c *gin.Context)
 }
 
 type TaskController struct {
-	TaskService service.ITaskService
-	UserService service.IUserService
+	TaskService        service.ITaskService
+	UserService        service.IUserService
+	ChecklistService   service.IChecklistService
+	AttachmentService  service.IAttachmentService
+	MentionService     service.IMentionService
+	CommentService     service.ICommentService
+	SavedFilterService service.ISavedFilterService
 }
 
-func NewTaskController(taskService service.ITaskService, userService service.IUserService) *TaskController {
+func NewTaskController(
+	taskService service.ITaskService,
+	userService service.IUserService,
+	checklistService service.IChecklistService,
+	attachmentService service.IAttachmentService,
+	mentionService service.IMentionService,
+	commentService service.ICommentService,
+	savedFilterService service.ISavedFilterService,
+) *TaskController {
 	return &TaskController{
-		TaskService: taskService,
-		UserService: userService,
+		TaskService:        taskService,
+		UserService:        userService,
+		ChecklistService:   checklistService,
+		AttachmentService:  attachmentService,
+		MentionService:     mentionService,
+		CommentService:     commentService,
+		SavedFilterService: savedFilterService,
 	}
 }
 
@@ -48,6 +77,7 @@ func NewTaskController(taskService service.ITaskService, userService service.IUs
 // @Param login path string true "User Login"
 // @Success 200 {array} repository.Task "List of tasks"
 // @Failure 500 {object} dto.ResponseMap
+// @Param render query string false "html - дополнительно вернуть описание, отрендеренное из markdown"
 // @Router /tasks/user/{login} [get]
 // .
 func (t *TaskController) GetByUserLogin(c *gin.Context) {
@@ -58,17 +88,28 @@ func (t *TaskController) GetByUserLogin(c *gin.Context) {
 		return
 	}
 
+	renderDescriptions(c, tasks)
 	c.JSON(http.StatusOK, tasks)
 }
 
 // GetAll возвращает список задач в зависимости от роли пользователя.
 // @Summary Get All Tasks
 // @Description возвращает список задач в зависимости от роли:
-// @Description для администраторов - все задачи, для пользователей - задачи пользователя
+// @Description для администраторов - все задачи, для пользователей - задачи пользователя.
+// @Description Задачи можно отобрать сохранённым фильтром filterId и условиями из параметров запроса,
+// @Description которые дополняют или заменяют условия сохранённого фильтра.
 // @Tags pages
 // @Produce json
+// @Param filterId query integer false "Сохранённый фильтр"
+// @Param status query string false "Статус задачи"
+// @Param priority query integer false "Приоритет задачи"
+// @Param userLogin query string false "Логин исполнителя"
+// @Param label query []string false "Метки, которые должны быть у задачи все одновременно" collectionFormat(multi)
+// @Param text query string false "Текст в названии или описании"
+// @Param sort query string false "id, title, priority, status, createdAt, updatedAt или dueAt, с минусом - по убыванию"
 // @Success 200 {array} repository.Task "List of tasks"
 // @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
 // @Failure 500 {object} dto.ResponseMap
 // @Failure 400 {object} dto.ResponseMap
 // @Router /tasks [get]
@@ -87,27 +128,72 @@ func (t *TaskController) GetAll(c *gin.Context) {
 		return
 	}
 
-	tasks, err := t.TaskService.GetAllByUser(c.Request.Context(), sessionUser)
+	filter, ok := taskFilterFromQuery(c, t.SavedFilterService, sessionUser)
+	if !ok {
+		return
+	}
+
+	var tasks []repository.TaskWithLogin
+	var err error
+	if filter.IsEmpty() && filter.Sort == "" {
+		tasks, err = t.TaskService.GetAllByUser(c.Request.Context(), sessionUser)
+	} else {
+		tasks, err = t.TaskService.GetAllByFilter(c.Request.Context(), sessionUser, filter)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	checklistProgress, err := t.ChecklistService.GetProgress(c.Request.Context(), taskIDs)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
 		return
 	}
 
-	templateData := dto.TasksWithLoginTemplateData{Tasks: tasks}
+	pinnedFilters, err := t.SavedFilterService.GetPinned(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+	filterID, _ := strconv.Atoi(c.Query("filterId"))
+
+	templateData := dto.TasksWithLoginTemplateData{
+		Tasks:             tasks,
+		ChecklistProgress: checklistProgress,
+		PinnedFilters:     pinnedFilters,
+		Filter:            filter,
+		FilterID:          filterID,
+	}
 	c.HTML(http.StatusOK, "tasks.html", templateData)
 }
 
 // GetByID возвращает задачу по идентификатору.
 // @Summary Get Task by ID
-// @Description возвращает задачу по идентификатору
+// @Description возвращает страницу задачи, а клиенту, который принимает только JSON (Accept: application/json), -
+// @Description задачу в JSON. ETag ответа передаётся в If-Match при обновлении задачи.
+// @Description С ?render=html в JSON добавляется отрендеренное описание.
 // @Tags tasks
-// @Produce html
+// @Produce html,json
 // @Param id path string true "Task ID"
-// @Success 200 {object} repository.TaskWithLogin
+// @Param render query string false "html - добавить в JSON отрендеренное описание"
+// @Success 200 {object} repository.Task
+// @Header 200 {string} ETag "версия задачи"
 // @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
 // @Router /tasks/{id} [get]
 // .
 func (t *TaskController) GetByID(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
 	IDParam := c.Param("id")
 	taskID, err := strconv.Atoi(IDParam)
 	if err != nil {
@@ -115,13 +201,64 @@ func (t *TaskController) GetByID(c *gin.Context) {
 		return
 	}
 
+	if c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		t.writeTaskJSON(c, sessionUser, taskID)
+		return
+	}
+
 	task, err := t.TaskService.GetTaskRepository().GetTaskWithLoginByID(c.Request.Context(), taskID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
 		return
 	}
 
-	c.HTML(http.StatusOK, "task.html", task)
+	checklist, err := t.ChecklistService.GetByTaskID(c.Request.Context(), sessionUser, taskID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	attachments, err := t.AttachmentService.GetByTaskID(c.Request.Context(), sessionUser, taskID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	descriptionHTML, err := t.MentionService.Render(c.Request.Context(), task.Description)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	mentionedIn, err := t.MentionService.GetBacklinks(c.Request.Context(), taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	comments, err := t.CommentService.GetByTaskID(c.Request.Context(), taskID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	templateData := dto.TaskTemplateData{
+		TaskWithLogin:     task,
+		DescriptionHTML:   descriptionHTML,
+		MentionedIn:       mentionedIn,
+		Checklist:         checklist,
+		Attachments:       attachments,
+		Comments:          comments,
+		ChecklistProgress: repository.ChecklistProgress{TaskID: taskID, Total: len(checklist)},
+	}
+	for _, item := range checklist {
+		if item.Done {
+			templateData.ChecklistProgress.Done++
+		}
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.HTML(http.StatusOK, "task.html", templateData)
 }
 
 // Edit отображает форму редактирования задачи по идентификатору.
@@ -130,6 +267,7 @@ func (t *TaskController) GetByID(c *gin.Context) {
 // @Produce html
 // @Param id path string true "Task ID"
 // @Success 200 {object} repository.TaskWithLogin
+// @Header 200 {string} ETag "версия задачи"
 // @Failure 400 {object} dto.ResponseMap
 // @Router /tasks/{id}/edit [get]
 // .
@@ -147,22 +285,32 @@ func (t *TaskController) Edit(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", taskETag(task.Version))
 	c.HTML(http.StatusOK, "task_edit.html", task)
 }
 
 // Update обновляет задачу по идентификатору.
 // @Summary Update Task by ID
-// @Description обновляет задачу по идентификатору
+// @Description обновляет задачу по идентификатору. Ожидаемая версия задачи обязательна и передаётся заголовком
+// @Description If-Match (значение ETag) или полем Version; без неё возвращается 428.
+// @Description Если задачу успели изменить, возвращается 409 с её текущим состоянием.
+// @Description ETag ответа - версия задачи после обновления.
 // @Tags tasks
 // @Accept x-www-form-urlencoded
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag задачи"
 // @Param Title formData string true "Task Title"
 // @Param Description formData string true "Task Description"
 // @Param Priority formData integer true "Task Priority"
 // @Param Status formData string true "Task Status"
+// @Param Version formData integer false "Task Version"
 // @Success 302 {string} Redirected to updated task
+// @Header 302 {string} ETag "версия задачи после обновления"
 // @Failure 400 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 409 {object} dto.TaskConflictResponse
+// @Failure 428 {object} dto.ResponseMap
 // @Failure 500 {object} dto.ResponseMap
 // @Router /tasks/{id} [post]
 // .
@@ -183,27 +331,88 @@ func (t *TaskController) Update(c *gin.Context) {
 		return
 	}
 
-	err = t.TaskService.Update(c.Request.Context(), title, description, status, priority, taskID)
-	if err != nil && errors.Is(err, errs.BadReqErr{}) {
+	version, found, err := requestedTaskVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "version is not a number"})
+		return
+	}
+	if !found {
+		c.JSON(http.StatusPreconditionRequired, dto.ResponseMap{"error": "task version is required in If-Match"})
+		return
+	}
+
+	sessionUser := findSessionUser(c)
+	err = t.TaskService.Update(c.Request.Context(), sessionUser, title, description, status, priority, taskID, version)
+	if err != nil && errors.Is(err, errs.ConflictErr{}) {
+		t.writeConflict(c, taskID)
+		return
+	} else if err != nil && errors.Is(err, errs.BadReqErr{}) {
 		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
 		return
 	} else if err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
 		return
 	}
+	t.syncMentions(c.Request.Context(), sessionUser, taskID, description)
+
+	c.Header("ETag", taskETag(version+1))
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
+}
+
+// SetDueDate задаёт или снимает срок выполнения задачи.
+// @Summary Set task due date
+// @Description задаёт срок выполнения задачи: в RFC 3339 или в формате поля datetime-local (2006-01-02T15:04)
+// @Description по времени сервера. Пустое значение снимает срок. Задачи со сроком попадают в календарь.
+// @Tags tasks
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param DueAt formData string false "Срок выполнения" example(2026-10-20T18:00)
+// @Success 302 {string} Redirected to task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 409 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/{id}/due [post]
+// .
+func (t *TaskController) SetDueDate(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
+		return
+	}
+
+	dueAt, err := parseDueDate(c.PostForm("DueAt"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "due date is not a date"})
+		return
+	}
+
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	if err = t.TaskService.SetDueDate(c.Request.Context(), sessionUser, taskID, dueAt); err != nil {
+		writeServiceError(c, err)
+		return
+	}
 
 	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", taskID))
 }
 
 // Delete удаляет задачу по указанному идентификатору.
 // @Summary Delete Task by ID
-// @Description Удаляет задачу по указанному идентификатору
+// @Description Удаляет задачу по указанному идентификатору вместе с файлами её вложений
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
 // @Success 302 {object} dto.ResponseMap
 // @Failure 400 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
 // @Failure 500 {object} dto.ResponseMap
 // @Router /tasks/{id}/delete [post]
 // .
@@ -214,9 +423,8 @@ func (t *TaskController) Delete(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "task ID is not number"})
 		return
 	}
-	err = t.TaskService.GetTaskRepository().DeleteByID(c.Request.Context(), taskID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+	if err = t.TaskService.Delete(c.Request.Context(), findSessionUser(c), taskID); err != nil {
+		writeServiceError(c, err)
 		return
 	}
 
@@ -249,7 +457,9 @@ func (t *TaskController) Create(c *gin.Context) {
 		return
 	}
 
-	createdTaskID, err := t.TaskService.Create(c.Request.Context(), priority, title, description, userLogin)
+	sessionUser := findSessionUser(c)
+	createdTaskID, err := t.TaskService.Create(c.Request.Context(),
+		sessionUser, priority, title, description, userLogin)
 	if err != nil && errors.Is(err, errs.BadReqErr{}) {
 		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
 		return
@@ -257,6 +467,7 @@ func (t *TaskController) Create(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
 		return
 	}
+	t.syncMentions(c.Request.Context(), sessionUser, createdTaskID, description)
 
 	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", createdTaskID))
 }
@@ -286,7 +497,11 @@ func (t *TaskController) CreateTemplate(c *gin.Context) {
 
 	var data dto.UsersTemplateData
 	if sessionUser.Role == constant.AdminRole {
-		users := t.UserService.GetAll(c.Request.Context())
+		users, err := t.UserService.GetAll(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+			return
+		}
 		data.Users = users
 	} else if sessionUser.Role == constant.UserRole {
 		users := []repository.User{*sessionUser}
@@ -307,6 +522,7 @@ func (t *TaskController) CreateTemplate(c *gin.Context) {
 // @Success 200 {array} repository.Task
 // @Failure 400 {object} dto.ResponseMap
 // @Failure 500 {object} dto.ResponseMap
+// @Param render query string false "html - дополнительно вернуть описание, отрендеренное из markdown"
 // @Router /tasks/by-status/{status} [get]
 // .
 func (t *TaskController) GetByStatus(c *gin.Context) {
@@ -321,6 +537,7 @@ func (t *TaskController) GetByStatus(c *gin.Context) {
 		return
 	}
 
+	renderDescriptions(c, tasks)
 	c.JSON(http.StatusOK, tasks)
 }
 
@@ -334,6 +551,7 @@ func (t *TaskController) GetByStatus(c *gin.Context) {
 // @Success 200 {array} repository.Task
 // @Failure 400 {object} dto.ResponseMap
 // @Failure 500 {object} dto.ResponseMap
+// @Param render query string false "html - дополнительно вернуть описание, отрендеренное из markdown"
 // @Router /tasks/by-priority/{priority} [get]
 // .
 func (t *TaskController) GetByPriority(c *gin.Context) {
@@ -357,5 +575,117 @@ func (t *TaskController) GetByPriority(c *gin.Context) {
 		return
 	}
 
+	renderDescriptions(c, tasks)
 	c.JSON(http.StatusOK, tasks)
 }
+
+// Preview возвращает HTML-превью описания задачи.
+// @Summary Preview task description
+// @Description рендерит markdown описания задачи в безопасный HTML для превью в форме редактирования.
+// @Description Упоминания @login и #123 существующих пользователей и задач становятся ссылками.
+// @Tags tasks
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param Description formData string true "Описание задачи в markdown"
+// @Success 200 {string} string "HTML"
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/preview [post]
+// .
+func (t *TaskController) Preview(c *gin.Context) {
+	description := c.PostForm("Description")
+	rendered, err := t.MentionService.Render(c.Request.Context(), description)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(rendered))
+}
+
+// writeTaskJSON отвечает задачей в JSON с её версией в ETag.
+func (t *TaskController) writeTaskJSON(c *gin.Context, user *repository.User, taskID int) {
+	task, err := t.TaskService.GetByID(c.Request.Context(), user, taskID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	tasks := []repository.Task{*task}
+	renderDescriptions(c, tasks)
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusOK, tasks[0])
+}
+
+// writeConflict отвечает 409 и возвращает текущее состояние задачи, чтобы клиент мог повторить изменение.
+func (t *TaskController) writeConflict(c *gin.Context, taskID int) {
+	task, err := t.TaskService.GetTaskRepository().GetByID(c.Request.Context(), taskID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		writeServiceError(c, errs.NotFoundErr{})
+		return
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.Header("ETag", taskETag(task.Version))
+	c.JSON(http.StatusConflict, dto.TaskConflictResponse{Error: errs.ConflictErr{}.Error(), Task: task})
+}
+
+// syncMentions сохраняет упоминания из описания задачи. Задача к этому моменту уже сохранена,
+// поэтому ошибка только логируется: упоминания обновятся при следующем редактировании.
+func (t *TaskController) syncMentions(ctx context.Context, actor *repository.User, taskID int, description string) {
+	if _, err := t.MentionService.Sync(ctx, actor, taskID, description); err != nil {
+		slog.ErrorContext(ctx, "cannot save task mentions", slog.Int("taskId", taskID), slog.Any("error", err))
+	}
+}
+
+// renderDescriptions дополняет JSON-ответ текстовой версией описания без разметки,
+// а по запросу клиента (?render=html) - и отрендеренным HTML.
+func renderDescriptions(c *gin.Context, tasks []repository.Task) {
+	renderHTML := c.Query("render") == "html"
+	for i := range tasks {
+		tasks[i].DescriptionText = markdown.ToText(tasks[i].Description)
+		if renderHTML {
+			tasks[i].DescriptionHTML = string(markdown.ToHTML(tasks[i].Description))
+		}
+	}
+}
+
+// parseDueDate разбирает срок в RFC 3339 или в формате поля datetime-local; пустая строка - срока нет.
+func parseDueDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	dueAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		dueAt, err = time.ParseInLocation(dueDateLayout, value, time.Local)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dueAt, nil
+}
+
+func taskETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// requestedTaskVersion возвращает версию задачи из заголовка If-Match или поля формы Version.
+// found равен false, если клиент не передал версию. If-Match: * версией не считается: перезаписать задачу,
+// не зная её версии, нельзя.
+func requestedTaskVersion(c *gin.Context) (version int, found bool, err error) {
+	if ifMatch := strings.TrimSpace(c.GetHeader("If-Match")); ifMatch != "" && ifMatch != "*" {
+		version, err = strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+		return version, true, err
+	}
+
+	if versionForm := c.PostForm("Version"); versionForm != "" {
+		version, err = strconv.Atoi(versionForm)
+		return version, true, err
+	}
+
+	return 0, false, nil
+}
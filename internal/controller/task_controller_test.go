@@ -3,6 +3,8 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
@@ -11,9 +13,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
 	"github.com/romakorinenko/task-manager/internal/service"
+	mockStorage "github.com/romakorinenko/task-manager/internal/storage/mocks"
 	"github.com/romakorinenko/task-manager/test"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -25,24 +31,35 @@ func TestTaskController_Create_UserCreated(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	mentionRepo := mockRepository.NewMockIMentionRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	emailService := service.NewEmailService(nil, nil, nil, &config.Email{})
+	notificationService := service.NewNotificationService(notificationRepo, emailService)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl), nil)
+	mentionService := service.NewMentionService(mentionRepo, taskRepo, userRepo, notificationService)
+	taskController := NewTaskController(taskService, userService, nil, nil, mentionService, nil, nil)
 
 	router.POST("/tasks", taskController.Create)
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks", nil)
 	values := url.Values{}
 	values.Set("Title", "Title")
-	values.Set("Description", "DescriptionDescription")
+	values.Set("Description", "Description for @user")
 	values.Set("UserLogin", "user")
 	values.Set("Priority", "1")
 	req.PostForm = values
 
 	w := httptest.NewRecorder()
 
-	userRepo.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(&repository.User{ID: 2, Login: "user"}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").
+		Return(&repository.User{ID: 2, Login: "user", Active: true}, nil).Times(2)
 	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	mentionRepo.EXPECT().ReplaceForTask(gomock.Any(), 1, []int{2}, []int{}).Return([]int{2}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 2).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 
 	router.ServeHTTP(w, req)
 
@@ -56,8 +73,8 @@ func TestTaskController_Create_UserCreated(t *testing.T) {
 func TestTaskController_Create_InvalidPriority(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.POST("/tasks", taskController.Create)
 
@@ -83,8 +100,8 @@ func TestTaskController_Create_InvalidPriority(t *testing.T) {
 func TestTaskController_Create_InvalidTitle(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.POST("/tasks", taskController.Create)
 
@@ -113,9 +130,9 @@ func TestTaskController_Create_InternalErrorFromTaskRepo(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, newTransactorMock(ctrl), nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.POST("/tasks", taskController.Create)
 
@@ -147,18 +164,22 @@ func TestTaskController_Update_TaskUpdated(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	mentionRepo := mockRepository.NewMockIMentionRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl), nil)
+	mentionService := service.NewMentionService(mentionRepo, taskRepo, userRepo, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, mentionService, nil, nil)
 
 	router.POST("/tasks/:id", taskController.Update)
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
 	values := url.Values{}
 	values.Set("Title", "Title")
-	values.Set("Description", "DescriptionDescription")
+	values.Set("Description", "Blocked by #5")
 	values.Set("Status", "OPEN")
 	values.Set("Priority", "1")
+	values.Set("Version", "1")
 	req.PostForm = values
 
 	w := httptest.NewRecorder()
@@ -171,14 +192,19 @@ func TestTaskController_Update_TaskUpdated(t *testing.T) {
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		UserID:      2,
+		Version:     1,
 	}
-	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(taskFromDB, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(taskFromDB, nil)
 	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.Task{ID: 5}, nil)
+	mentionRepo.EXPECT().ReplaceForTask(gomock.Any(), 1, []int{}, []int{5}).Return([]int{}, nil)
 
 	router.ServeHTTP(w, req)
 
 	response := w.Result()
 	require.Equal(t, http.StatusFound, response.StatusCode)
+	require.Equal(t, `"2"`, response.Header.Get("ETag"))
 	respBodyBytes, err := io.ReadAll(response.Body)
 	require.NoError(t, err)
 	require.Equal(t, "", string(respBodyBytes))
@@ -187,8 +213,8 @@ func TestTaskController_Update_TaskUpdated(t *testing.T) {
 func TestTaskController_Update_BadRequest(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.POST("/tasks/:id", taskController.Update)
 
@@ -198,6 +224,7 @@ func TestTaskController_Update_BadRequest(t *testing.T) {
 	values.Set("Description", "DescriptionDescription")
 	values.Set("Status", "OPEN")
 	values.Set("Priority", "1")
+	values.Set("Version", "1")
 	req.PostForm = values
 
 	w := httptest.NewRecorder()
@@ -217,9 +244,9 @@ func TestTaskController_Update_InternalError(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, newTransactorMock(ctrl), nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.POST("/tasks/:id", taskController.Update)
 
@@ -229,6 +256,7 @@ func TestTaskController_Update_InternalError(t *testing.T) {
 	values.Set("Description", "DescriptionDescription")
 	values.Set("Status", "OPEN")
 	values.Set("Priority", "1")
+	values.Set("Version", "1")
 	req.PostForm = values
 
 	w := httptest.NewRecorder()
@@ -241,6 +269,7 @@ func TestTaskController_Update_InternalError(t *testing.T) {
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		UserID:      2,
+		Version:     1,
 	}
 	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(taskFromDB, nil)
 	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New(""))
@@ -254,15 +283,112 @@ func TestTaskController_Update_InternalError(t *testing.T) {
 	require.Equal(t, `{"error":"internal server error"}`, string(respBodyBytes))
 }
 
+func TestTaskController_Update_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
+
+	router.POST("/tasks/:id", taskController.Update)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
+	req.Header.Set("If-Match", `"2"`)
+	values := url.Values{}
+	values.Set("Title", "Title")
+	values.Set("Description", "Description")
+	values.Set("Status", "OPEN")
+	values.Set("Priority", "1")
+	values.Set("Version", "1")
+	req.PostForm = values
+
+	w := httptest.NewRecorder()
+
+	taskFromDB := &repository.Task{ID: 1, Title: "Changed", Description: "Description", Status: "DONE", Version: 3}
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(taskFromDB, nil).Times(2)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusConflict, response.StatusCode)
+	require.Equal(t, `"3"`, response.Header.Get("ETag"))
+
+	var conflict struct {
+		Error string          `json:"error"`
+		Task  repository.Task `json:"task"`
+	}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&conflict))
+	require.Equal(t, "task was modified by another user", conflict.Error)
+	require.Equal(t, "Changed", conflict.Task.Title)
+	require.Equal(t, 3, conflict.Task.Version)
+}
+
+func TestTaskController_Update_VersionRequired(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	taskController := NewTaskController(service.NewTaskService(nil, nil, nil, nil, nil), nil, nil, nil, nil, nil, nil)
+
+	router.POST("/tasks/:id", taskController.Update)
+
+	for _, ifMatch := range []string{"", "*"} {
+		req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
+		req.Header.Set("If-Match", ifMatch)
+		values := url.Values{}
+		values.Set("Title", "Title")
+		values.Set("Description", "Description")
+		values.Set("Status", "OPEN")
+		values.Set("Priority", "1")
+		req.PostForm = values
+
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionRequired, w.Result().StatusCode)
+	}
+}
+
+func TestTaskController_Update_InvalidIfMatch(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	taskController := NewTaskController(service.NewTaskService(nil, nil, nil, nil, nil), nil, nil, nil, nil, nil, nil)
+
+	router.POST("/tasks/:id", taskController.Update)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
+	req.Header.Set("If-Match", `"abc"`)
+	values := url.Values{}
+	values.Set("Title", "Title")
+	values.Set("Description", "Description")
+	values.Set("Status", "OPEN")
+	values.Set("Priority", "1")
+	req.PostForm = values
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"error":"version is not a number"}`, string(respBodyBytes))
+}
+
 func TestTaskController_Delete_UserDeleted(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	router := test.SetUpTestRouter()
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl), attachmentService)
+	taskController := NewTaskController(taskService, userService, nil, attachmentService, nil, nil, nil)
 
 	router.POST("/:id/delete", taskController.Delete)
 
@@ -276,7 +402,13 @@ func TestTaskController_Delete_UserDeleted(t *testing.T) {
 
 	w := httptest.NewRecorder()
 
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).
+		Return([]repository.Attachment{{ID: 3, TaskID: 1, StorageKey: "tasks/1/3"}}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, Title: "Title"}, nil)
 	taskRepo.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	attachmentStorage.EXPECT().Delete(gomock.Any(), "tasks/1/3").Return(nil)
 
 	router.ServeHTTP(w, req)
 
@@ -290,8 +422,8 @@ func TestTaskController_Delete_UserDeleted(t *testing.T) {
 func TestTaskController_Delete_BadRequest(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.POST("/:id/delete", taskController.Delete)
 
@@ -320,9 +452,12 @@ func TestTaskController_Delete_InternalError(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, newTransactorMock(ctrl), attachmentService)
+	taskController := NewTaskController(taskService, userService, nil, attachmentService, nil, nil, nil)
 
 	router.POST("/:id/delete", taskController.Delete)
 
@@ -336,6 +471,9 @@ func TestTaskController_Delete_InternalError(t *testing.T) {
 
 	w := httptest.NewRecorder()
 
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).
+		Return([]repository.Attachment{{ID: 3, TaskID: 1, StorageKey: "tasks/1/3"}}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, Title: "Title"}, nil)
 	taskRepo.EXPECT().DeleteByID(gomock.Any(), gomock.Any()).Return(errors.New(""))
 
 	router.ServeHTTP(w, req)
@@ -352,10 +490,20 @@ func TestTaskController_GetByID_TaskReturned(t *testing.T) {
 	router := test.SetUpTestRouter()
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := service.NewTaskService(taskRepo, nil)
-	taskController := NewTaskController(taskService, nil)
-
-	router.POST("/tasks/:id", taskController.GetByID)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, nil, nil, nil, nil)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	checklistService := service.NewChecklistService(checklistRepo, taskRepo, nil)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, nil, nil)
+	mentionRepo := mockRepository.NewMockIMentionRepo(ctrl)
+	mentionService := service.NewMentionService(mentionRepo, taskRepo, nil, nil)
+	commentRepo := mockRepository.NewMockICommentRepo(ctrl)
+	commentService := service.NewCommentService(commentRepo, taskRepo, nil, nil, mentionService)
+	taskController := NewTaskController(
+		taskService, nil, checklistService, attachmentService, mentionService, commentService, nil)
+
+	router.POST("/tasks/:id", withSessionUser(&repository.User{ID: 2, Role: constant.UserRole}),
+		taskController.GetByID)
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
 
@@ -364,7 +512,7 @@ func TestTaskController_GetByID_TaskReturned(t *testing.T) {
 	taskFromDB := &repository.TaskWithLogin{
 		ID:          1,
 		Title:       "Title",
-		Description: "Description",
+		Description: "Follow-up of #2 and #3",
 		Status:      "OPEN",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -372,11 +520,79 @@ func TestTaskController_GetByID_TaskReturned(t *testing.T) {
 	}
 
 	taskRepo.EXPECT().GetTaskWithLoginByID(gomock.Any(), gomock.Any()).Return(taskFromDB, nil)
+	checklistRepo.EXPECT().GetByTaskID(gomock.Any(), 1).Return([]repository.ChecklistItem{
+		{ID: 1, TaskID: 1, Title: "first", Done: true, Position: 1},
+		{ID: 2, TaskID: 1, Title: "second", Position: 2},
+	}, nil)
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).Return([]repository.Attachment{
+		{ID: 3, TaskID: 1, FileName: "screenshot.png", ContentType: "image/png", Size: 10},
+	}, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil).Times(2)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.Task{ID: 2}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 3).Return(nil, pgx.ErrNoRows)
+	mentionRepo.EXPECT().GetBacklinks(gomock.Any(), 1).Return([]repository.TaskBacklink{{ID: 7, Title: "Release"}}, nil)
+	commentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).Return([]repository.Comment{
+		{ID: 4, TaskID: 1, UserID: 2, Body: "**Looks** good", UserLogin: "admin"},
+	}, nil)
 
 	router.ServeHTTP(w, req)
 
 	response := w.Result()
 	require.Equal(t, http.StatusOK, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(respBodyBytes), "Чеклист 1/2")
+	require.Contains(t, string(respBodyBytes), "screenshot.png")
+	require.Contains(t, string(respBodyBytes), `<a href="/tasks/2" rel="nofollow">#2</a> and #3`)
+	require.Contains(t, string(respBodyBytes), "#7 Release")
+	require.Contains(t, string(respBodyBytes), "<strong>Looks</strong> good")
+}
+
+func TestTaskController_GetByID_JSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
+
+	router.GET("/tasks/:id", withSessionUser(&repository.User{ID: 2, Role: constant.UserRole}),
+		taskController.GetByID)
+
+	t.Run("своя задача", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
+		req.Header.Set("Accept", "application/json")
+
+		w := httptest.NewRecorder()
+
+		taskRepo.EXPECT().GetByID(gomock.Any(), 1).
+			Return(&repository.Task{ID: 1, Title: "Title", Description: "**Release**", UserID: 2, Version: 4}, nil)
+
+		router.ServeHTTP(w, req)
+
+		response := w.Result()
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Equal(t, `"4"`, response.Header.Get("ETag"))
+
+		var task repository.Task
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&task))
+		require.Equal(t, 4, task.Version)
+		require.Equal(t, "Release", task.DescriptionText)
+	})
+
+	t.Run("чужая задача", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/tasks/5", nil)
+		req.Header.Set("Accept", "application/json")
+
+		w := httptest.NewRecorder()
+
+		taskRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.Task{ID: 5, UserID: 3}, nil)
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+	})
 }
 
 func TestTaskController_GetByID_BadRequest(t *testing.T) {
@@ -384,10 +600,11 @@ func TestTaskController_GetByID_BadRequest(t *testing.T) {
 	router := test.SetUpTestRouter()
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := service.NewTaskService(taskRepo, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(taskRepo, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
-	router.POST("/tasks/:id", taskController.GetByID)
+	router.POST("/tasks/:id", withSessionUser(&repository.User{ID: 2, Role: constant.UserRole}),
+		taskController.GetByID)
 
 	req := httptest.NewRequest(http.MethodPost, "/tasks/1", nil)
 
@@ -407,9 +624,9 @@ func TestTaskController_GetByUserLogin_TasksReturned(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, nil, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/user/:login", taskController.GetByUserLogin)
 
@@ -431,9 +648,9 @@ func TestTaskController_GetByPriority_TaskReturned(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, nil, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/by-priority/:priority", taskController.GetByPriority)
 
@@ -452,8 +669,8 @@ func TestTaskController_GetByPriority_TaskReturned(t *testing.T) {
 func TestTaskController_GetByPriority_BadRequest(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/by-priority/:priority", taskController.GetByPriority)
 
@@ -473,9 +690,9 @@ func TestTaskController_GetByStatus_TaskReturned(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, nil, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/by-status/:status", taskController.GetByStatus)
 
@@ -491,11 +708,62 @@ func TestTaskController_GetByStatus_TaskReturned(t *testing.T) {
 	require.Equal(t, http.StatusOK, response.StatusCode)
 }
 
+func TestTaskController_GetByStatus_RenderedDescription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := service.NewTaskService(taskRepo, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
+
+	router.GET("/tasks/by-status/:status", taskController.GetByStatus)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/by-status/OPEN?render=html", nil)
+
+	w := httptest.NewRecorder()
+
+	taskRepo.EXPECT().GetByStatus(gomock.Any(), gomock.Any()).
+		Return([]repository.Task{{ID: 1, Description: "**bold**"}}, nil)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	var tasks []repository.Task
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&tasks))
+	require.Equal(t, "**bold**", tasks[0].Description)
+	require.Equal(t, "bold", tasks[0].DescriptionText)
+	require.Equal(t, "<p><strong>bold</strong></p>\n", tasks[0].DescriptionHTML)
+}
+
+func TestTaskController_Preview(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	taskController := NewTaskController(nil, nil, nil, nil, service.NewMentionService(nil, nil, nil, nil), nil, nil)
+
+	router.POST("/tasks/preview", taskController.Preview)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/preview", nil)
+	values := url.Values{}
+	values.Set("Description", "*text*<script>alert(1)</script>")
+	req.PostForm = values
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "<p><em>text</em>alert(1)</p>\n", string(respBodyBytes))
+}
+
 func TestTaskController_GetByStatus_BadRequest(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/by-status/:status", taskController.GetByStatus)
 
@@ -515,9 +783,9 @@ func TestTaskController_Edit_TemplateReturned(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, nil, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/:id/edit", taskController.Edit)
 
@@ -539,9 +807,9 @@ func TestTaskController_Edit_InternalError(t *testing.T) {
 
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	userService := service.NewUserService(userRepo)
-	taskService := service.NewTaskService(taskRepo, userRepo)
-	taskController := NewTaskController(taskService, userService)
+	userService := service.NewUserService(userRepo, nil)
+	taskService := service.NewTaskService(taskRepo, userRepo, nil, nil, nil)
+	taskController := NewTaskController(taskService, userService, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/:id/edit", taskController.Edit)
 
@@ -560,8 +828,8 @@ func TestTaskController_Edit_InternalError(t *testing.T) {
 func TestTaskController_CreateTemplate_Unauthorized(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks/create", taskController.CreateTemplate)
 
@@ -578,8 +846,8 @@ func TestTaskController_CreateTemplate_Unauthorized(t *testing.T) {
 func TestTaskController_GetAll(t *testing.T) {
 	router := test.SetUpTestRouter()
 
-	taskService := service.NewTaskService(nil, nil)
-	taskController := NewTaskController(taskService, nil)
+	taskService := service.NewTaskService(nil, nil, nil, nil, nil)
+	taskController := NewTaskController(taskService, nil, nil, nil, nil, nil, nil)
 
 	router.GET("/tasks", taskController.CreateTemplate)
 
@@ -592,3 +860,67 @@ func TestTaskController_GetAll(t *testing.T) {
 	response := w.Result()
 	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
 }
+
+// newTransactorMock возвращает транзакции, которые просто выполняют переданную функцию.
+func newTransactorMock(ctrl *gomock.Controller) *mockRepository.MockITransactor {
+	transactor := mockRepository.NewMockITransactor(ctrl)
+	transactor.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return transactor
+}
+
+func TestTaskController_GetAll_SavedFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	taskController := NewTaskController(
+		service.NewTaskService(taskRepo, nil, nil, nil, nil),
+		nil,
+		service.NewChecklistService(checklistRepo, nil, nil),
+		nil,
+		nil,
+		nil,
+		service.NewSavedFilterService(savedFilterRepo),
+	)
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/tasks", withSessionUser(user), taskController.GetAll)
+
+	urgent := repository.SavedFilter{
+		ID:         4,
+		UserID:     2,
+		Name:       "Срочные",
+		Filter:     repository.TaskFilter{Priority: constant.High},
+		Visibility: constant.PrivateFilterVisibility,
+	}
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 4).Return(&urgent, nil)
+	savedFilterRepo.EXPECT().GetVisible(gomock.Any(), 2).Return([]repository.SavedFilter{urgent}, nil)
+	savedFilterRepo.EXPECT().GetSettings(gomock.Any(), 2).
+		Return([]repository.SavedFilterSettings{{FilterID: 4, UserID: 2, Pinned: true}}, nil)
+	taskRepo.EXPECT().IterateWithLogin(gomock.Any(), &repository.TaskFilter{
+		Priority: constant.High,
+		Status:   constant.OpenTaskStatus,
+		UserID:   2,
+	}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *repository.TaskFilter, fn func(task *repository.TaskWithLogin) error) error {
+			return fn(&repository.TaskWithLogin{ID: 7, Title: "Починить прод", UserLogin: "user"})
+		})
+	checklistRepo.EXPECT().GetProgressByTaskIDs(gomock.Any(), []int{7}).
+		Return(map[int]repository.ChecklistProgress{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks?filterId=4&status=OPEN", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	require.Contains(t, body, "Починить прод")
+	require.Contains(t, body, "<b>Срочные</b>")
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+var exportContentTypes = map[string]string{
+	service.CSVExportFormat:  "text/csv; charset=utf-8",
+	service.JSONExportFormat: "application/x-ndjson",
+	service.XLSXExportFormat: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+var exportFileNames = map[string]string{
+	service.CSVExportFormat:  "tasks.csv",
+	service.JSONExportFormat: "tasks.jsonl",
+	service.XLSXExportFormat: "tasks.xlsx",
+}
+
+type ITaskExportController interface {
+	Export(c *gin.Context)
+}
+
+type TaskExportController struct {
+	TaskExportService  service.ITaskExportService
+	SavedFilterService service.ISavedFilterService
+}
+
+func NewTaskExportController(
+	taskExportService service.ITaskExportService,
+	savedFilterService service.ISavedFilterService,
+) *TaskExportController {
+	return &TaskExportController{TaskExportService: taskExportService, SavedFilterService: savedFilterService}
+}
+
+// Export выгружает задачи в файл.
+// @Summary Export tasks
+// @Description выгружает задачи в CSV, JSON Lines или XLSX. Задачи читаются и отправляются по одной.
+// @Description Видимость как в списке задач: ADMIN выгружает все задачи, остальные - только свои.
+// @Description Столбцы: id, title, description, priority, status, createdAt, updatedAt, dueAt, userLogin, labels,
+// @Description version;
+// @Description по умолчанию - все, кроме version.
+// @Tags tasks
+// @Produce text/csv,application/x-ndjson,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (по умолчанию), json или xlsx"
+// @Param columns query string false "Столбцы через запятую"
+// @Param filterId query integer false "Сохранённый фильтр"
+// @Param status query string false "Статус задачи"
+// @Param priority query integer false "Приоритет задачи"
+// @Param userLogin query string false "Логин исполнителя"
+// @Param label query []string false "Метки, которые должны быть у задачи все одновременно" collectionFormat(multi)
+// @Param text query string false "Текст в названии или описании"
+// @Param sort query string false "Столбец сортировки, с минусом - по убыванию"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/export [get]
+// .
+func (e *TaskExportController) Export(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	filter, ok := taskFilterFromQuery(c, e.SavedFilterService, sessionUser)
+	if !ok {
+		return
+	}
+
+	var columns []string
+	if columnsQuery := c.Query("columns"); columnsQuery != "" {
+		columns = strings.Split(columnsQuery, ",")
+	}
+	format := c.DefaultQuery("format", service.CSVExportFormat)
+
+	clearWriteDeadline(c)
+	w := &fileResponseWriter{c: c, contentType: exportContentTypes[format], fileName: exportFileNames[format]}
+	err := e.TaskExportService.Export(c.Request.Context(), sessionUser, format, columns, filter, w)
+	if err != nil && !w.started {
+		writeServiceError(c, err)
+		return
+	} else if err != nil {
+		// заголовки и часть файла уже отправлены, сообщить об ошибке клиенту можно только обрывом ответа.
+		slog.ErrorContext(c.Request.Context(), "cannot export tasks", slog.Any("error", err))
+		c.Abort()
+	}
+}
+
+// fileResponseWriter отправляет заголовки файла только при первой записи, чтобы ошибку, случившуюся до начала
+// файла, можно было вернуть обычным JSON-ответом.
+type fileResponseWriter struct {
+	c           *gin.Context
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (w *fileResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", `attachment; filename="`+w.fileName+`"`)
+		w.c.Status(http.StatusOK)
+	}
+
+	return w.c.Writer.Write(p)
+}
@@ -0,0 +1,71 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskExportController_Export_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	exportController := NewTaskExportController(service.NewTaskExportService(taskRepo), nil)
+
+	router.GET("/tasks/export", withSessionUser(&repository.User{ID: 1, Role: constant.AdminRole}),
+		exportController.Export)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/export?columns=id,title&priority=2", nil)
+
+	w := httptest.NewRecorder()
+
+	taskRepo.EXPECT().IterateWithLogin(gomock.Any(), &repository.TaskFilter{Priority: 2}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *repository.TaskFilter, fn func(*repository.TaskWithLogin) error) error {
+			return fn(&repository.TaskWithLogin{ID: 5, Title: "Release"})
+		})
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/csv; charset=utf-8", response.Header.Get("Content-Type"))
+	require.Equal(t, `attachment; filename="tasks.csv"`, response.Header.Get("Content-Disposition"))
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "id,title\n5,Release\n", string(respBodyBytes))
+}
+
+func TestTaskExportController_Export_UnknownFormat(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	exportController := NewTaskExportController(service.NewTaskExportService(nil), nil)
+
+	router.GET("/tasks/export", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		exportController.Export)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/export?format=pdf", nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+	require.Equal(t, "application/json; charset=utf-8", response.Header.Get("Content-Type"))
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"error":"bad request"}`, string(respBodyBytes))
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ITaskImportController interface {
+	Import(c *gin.Context)
+}
+
+type TaskImportController struct {
+	TaskImportService service.ITaskImportService
+}
+
+func NewTaskImportController(taskImportService service.ITaskImportService) *TaskImportController {
+	return &TaskImportController{TaskImportService: taskImportService}
+}
+
+// Import импортирует задачи из CSV или JSON.
+// @Summary Import tasks
+// @Description импортирует задачи из CSV (первая строка - заголовки) или JSON (массив объектов), только для
+// @Description администраторов. Каждая запись проверяется по правилам создания задачи, исполнитель ищется по логину.
+// @Description Задачи создаются одной транзакцией и только если ошибок нет; с DryRun=true файл только проверяется.
+// @Description Ответ содержит столбцы файла, по которым составляется Mapping, и ошибки по записям.
+// @Tags tasks-admins
+// @Accept multipart/form-data
+// @Produce json
+// @Param File formData file true "Файл с задачами"
+// @Param Format formData string false "csv или json, по умолчанию - по расширению файла"
+// @Param Mapping formData string false "Сопоставление полей столбцам, например {\"title\":\"Summary\"}"
+// @Param DryRun formData boolean false "Только проверить файл"
+// @Success 200 {object} service.TaskImportReport "Файл проверен"
+// @Success 201 {object} service.TaskImportReport "Задачи созданы"
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 422 {object} service.TaskImportReport "В файле есть ошибки, задачи не созданы"
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/import [post]
+// .
+func (i *TaskImportController) Import(c *gin.Context) {
+	sessionUser := getSessionAdmin(c)
+	if sessionUser == nil {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxImportSize+multipartOverhead)
+	fileHeader, err := c.FormFile("File")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "file is not attached or too large"})
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("Format"))
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(fileHeader.Filename), "."))
+	}
+
+	mapping := make(map[string]string)
+	if mappingForm := c.PostForm("Mapping"); mappingForm != "" {
+		if err = json.Unmarshal([]byte(mappingForm), &mapping); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "mapping is not a JSON object"})
+			return
+		}
+	}
+
+	dryRun := false
+	if dryRunForm := c.PostForm("DryRun"); dryRunForm != "" {
+		if dryRun, err = strconv.ParseBool(dryRunForm); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "dry run is not a boolean"})
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+	defer file.Close()
+
+	report, err := i.TaskImportService.Import(c.Request.Context(), sessionUser, format, file, mapping, dryRun)
+	switch {
+	case err != nil:
+		writeServiceError(c, err)
+	case len(report.Errors) > 0:
+		c.JSON(http.StatusUnprocessableEntity, report)
+	case dryRun:
+		c.JSON(http.StatusOK, report)
+	default:
+		c.JSON(http.StatusCreated, report)
+	}
+}
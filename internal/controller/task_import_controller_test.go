@@ -0,0 +1,124 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskImportController_Import_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	importController := NewTaskImportController(service.NewTaskImportService(nil, userRepo, nil, nil))
+
+	router.POST("/tasks/import", withSessionUser(&repository.User{ID: 1, Role: constant.AdminRole}),
+		importController.Import)
+
+	req := newImportRequest(t, "tasks.csv", "Summary,description,priority,userLogin\nRelease,Publish,2,user\n",
+		map[string]string{"Mapping": `{"title":"Summary"}`, "DryRun": "true"})
+
+	w := httptest.NewRecorder()
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(&repository.User{ID: 2, Login: "user"}, nil)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	var report service.TaskImportReport
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&report))
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.Rows)
+	require.Empty(t, report.Errors)
+}
+
+func TestTaskImportController_Import_RowErrors(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	importController := NewTaskImportController(service.NewTaskImportService(nil, nil, nil, nil))
+
+	router.POST("/tasks/import", withSessionUser(&repository.User{ID: 1, Role: constant.AdminRole}),
+		importController.Import)
+
+	req := newImportRequest(t, "tasks.json", `[{"title":"Release","description":"","priority":2,"userLogin":"user"}]`,
+		nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+
+	var report service.TaskImportReport
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(t, []service.TaskImportError{{Row: 1, Error: "description is required"}}, report.Errors)
+}
+
+func TestTaskImportController_Import_FileMissing(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	importController := NewTaskImportController(service.NewTaskImportService(nil, nil, nil, nil))
+
+	router.POST("/tasks/import", withSessionUser(&repository.User{ID: 1, Role: constant.AdminRole}),
+		importController.Import)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/import", nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func newImportRequest(t *testing.T, fileName, content string, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("File", fileName)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestTaskImportController_Import_ForbiddenForUser(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	importController := NewTaskImportController(service.NewTaskImportService(nil, nil, nil, nil))
+
+	router.POST("/tasks/import", withSessionUser(&repository.User{ID: 2, Role: constant.UserRole}),
+		importController.Import)
+
+	req := newImportRequest(t, "tasks.csv", "title,description,priority,userLogin\nRelease,Publish,2,admin\n", nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+}
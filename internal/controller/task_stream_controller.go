@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/live"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ITaskStreamController interface {
+	Stream(c *gin.Context)
+}
+
+type TaskStreamController struct {
+	TaskStreamService service.ITaskStreamService
+	heartbeatInterval time.Duration
+}
+
+func NewTaskStreamController(
+	taskStreamService service.ITaskStreamService,
+	heartbeatInterval time.Duration,
+) *TaskStreamController {
+	return &TaskStreamController{
+		TaskStreamService: taskStreamService,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Stream передаёт изменения задач в формате Server-Sent Events.
+// @Summary Task changes stream
+// @Description передаёт события task.created, task.updated и task.deleted по задачам, которые видит пользователь:
+// @Description ADMIN - по всем, остальные - по своим. Идентификатор события передаётся в поле id; после
+// @Description переподключения пропущенные события отдаются по заголовку Last-Event-ID или параметру lastEventId.
+// @Description Если пропущено слишком много событий, приходит событие reset и страницу нужно загрузить заново.
+// @Tags tasks
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "ID последнего полученного события"
+// @Param lastEventId query int false "ID последнего полученного события, если заголовок нельзя передать"
+// @Success 200 {object} live.Event
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /tasks/events [get]
+// .
+func (t *TaskStreamController) Stream(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.DefaultQuery("lastEventId", "0")
+	}
+	lastEventIDInt, err := strconv.Atoi(lastEventID)
+	if err != nil || lastEventIDInt < 0 {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "bad request"})
+		return
+	}
+
+	events, err := t.TaskStreamService.Subscribe(c.Request.Context(), sessionUser, lastEventIDInt)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	clearWriteDeadline(c)
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(t.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err = writeEvent(c.Writer, &event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			// комментарий не доходит до обработчиков на странице, но не даёт прокси закрыть соединение.
+			if _, err = io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func writeEvent(w io.Writer, event *live.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if event.ID > 0 {
+		if _, err = fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	return err
+}
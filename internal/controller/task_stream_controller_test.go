@@ -0,0 +1,108 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/live"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskStreamController_Stream_MissedEventsReplayed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	broker := live.NewBroker(10)
+	// брокер остановлен, поэтому поток завершается сразу после пропущенных событий.
+	broker.Close()
+	streamService := service.NewTaskStreamService(broker, outboxRepo, userRepo, &config.Stream{ReplayLimit: 10})
+	streamController := NewTaskStreamController(streamService, time.Minute)
+
+	router.GET("/tasks/events", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		streamController.Stream)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/events", nil)
+	req.Header.Set("Last-Event-ID", "3")
+
+	w := httptest.NewRecorder()
+
+	payload, err := json.Marshal(&service.TaskEvent{Task: repository.Task{ID: 5, UserID: 1, Title: "Release"}})
+	require.NoError(t, err)
+	outboxRepo.EXPECT().GetAfter(gomock.Any(), 3, 11).Return([]repository.OutboxEvent{
+		{ID: 4, AggregateID: 5, Event: constant.TaskCreatedEvent, Payload: string(payload)},
+	}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.User{ID: 1, Login: "user"}, nil)
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(respBodyBytes), "id: 4\nevent: task.created\ndata: {\"id\":4,\"type\":\"task.created\"")
+	require.Contains(t, string(respBodyBytes), "\"userLogin\":\"user\"}\n\n")
+}
+
+func TestTaskStreamController_Stream_InvalidLastEventID(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	streamController := NewTaskStreamController(nil, time.Minute)
+
+	router.GET("/tasks/events", withSessionUser(&repository.User{ID: 1, Role: constant.UserRole}),
+		streamController.Stream)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/events?lastEventId=abc", nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestTaskStreamController_Stream_Unauthorized(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	streamController := NewTaskStreamController(nil, time.Minute)
+
+	router.GET("/tasks/events", streamController.Stream)
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/events", nil)
+
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	respBodyBytes, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "{\"error\":\"unauthorized\"}", string(respBodyBytes))
+}
+
+// withSessionUser кладёт пользователя в сессию запроса, как это делает вход в приложение.
+func withSessionUser(user *repository.User) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessions.Default(c).Set(constant.UserSessionKey, user)
+		c.Next()
+	}
+}
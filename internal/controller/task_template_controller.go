@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type ITaskTemplateController interface {
+	GetAll(c *gin.Context)
+	Choose(c *gin.Context)
+	Use(c *gin.Context)
+	CreateTask(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type TaskTemplateController struct {
+	TaskTemplateService service.ITaskTemplateService
+	UserService         service.IUserService
+	MentionService      service.IMentionService
+}
+
+func NewTaskTemplateController(
+	taskTemplateService service.ITaskTemplateService,
+	userService service.IUserService,
+	mentionService service.IMentionService,
+) *TaskTemplateController {
+	return &TaskTemplateController{
+		TaskTemplateService: taskTemplateService,
+		UserService:         userService,
+		MentionService:      mentionService,
+	}
+}
+
+// GetAll возвращает шаблоны задач, доступные пользователю.
+// @Summary Get task templates
+// @Description возвращает шаблоны задач: ADMIN видит все шаблоны, остальные - с видимостью ALL
+// @Tags templates
+// @Produce json
+// @Success 200 {array} repository.TaskTemplate
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates [get]
+// .
+func (t *TaskTemplateController) GetAll(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	templates, err := t.TaskTemplateService.GetVisible(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// Choose отображает список шаблонов, по которым можно создать задачу.
+// @Description отображает список доступных пользователю шаблонов задач
+// @Tags pages
+// @Produce html
+// @Success 200 {object} dto.TaskTemplatesTemplateData
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates/choose [get]
+// .
+func (t *TaskTemplateController) Choose(c *gin.Context) {
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	templates, err := t.TaskTemplateService.GetVisible(c.Request.Context(), sessionUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.HTML(http.StatusOK, "task_templates.html", dto.TaskTemplatesTemplateData{Templates: templates})
+}
+
+// Use отображает форму создания задачи по шаблону.
+// @Description отображает форму создания задачи по шаблону: поля для подстановок, приоритет и исполнителя
+// @Tags pages
+// @Produce html
+// @Param id path string true "Template ID"
+// @Success 200 {object} dto.TaskFromTemplateTemplateData
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates/{id}/use [get]
+// .
+func (t *TaskTemplateController) Use(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "template ID is not number"})
+		return
+	}
+
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	template, err := t.TaskTemplateService.Get(c.Request.Context(), sessionUser, templateID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	data := dto.TaskFromTemplateTemplateData{
+		Template:     template,
+		Placeholders: service.TemplatePlaceholders(template),
+		Users:        []repository.User{*sessionUser},
+	}
+	if sessionUser.Role == constant.AdminRole {
+		if data.Users, err = t.UserService.GetAll(c.Request.Context()); err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+			return
+		}
+	}
+
+	c.HTML(http.StatusOK, "task_from_template.html", data)
+}
+
+// CreateTask создаёт задачу по шаблону.
+// @Summary Create task from template
+// @Description создаёт задачу по шаблону: подстановки {{name}} в названии, описании и пунктах чеклиста заменяются
+// @Description значениями полей Values[name], все подстановки обязательны. К задаче добавляются метки и чеклист
+// @Description шаблона. USER создаёт задачи только на себя.
+// @Tags templates
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param UserLogin formData string true "Логин пользователя, которому назначена задача"
+// @Param Priority formData integer false "Приоритет задачи, по умолчанию - из шаблона"
+// @Param Values[version] formData string false "Значение подстановки {{version}}"
+// @Success 302 {string} Redirected to created task
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates/{id}/tasks [post]
+// .
+func (t *TaskTemplateController) CreateTask(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "template ID is not number"})
+		return
+	}
+
+	var priority int
+	if priorityForm := c.PostForm("Priority"); priorityForm != "" {
+		priority, err = strconv.Atoi(priorityForm)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "priority is not a number"})
+			return
+		}
+	}
+
+	sessionUser := getSessionUser(c)
+	if sessionUser == nil {
+		return
+	}
+
+	task, err := t.TaskTemplateService.CreateTask(c.Request.Context(),
+		sessionUser, templateID, c.PostForm("UserLogin"), priority, c.PostFormMap("Values"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+	// задача уже создана, поэтому ошибка упоминаний только логируется, как и при обычном создании задачи.
+	if _, err = t.MentionService.Sync(c.Request.Context(), sessionUser, task.ID, task.Description); err != nil {
+		slog.ErrorContext(c.Request.Context(), "cannot save task mentions",
+			slog.Int("taskId", task.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	c.Redirect(http.StatusFound, fmt.Sprintf("/tasks/%d", task.ID))
+}
+
+// Create создаёт шаблон задачи.
+// @Summary Create task template
+// @Description создаёт шаблон задачи, только для администраторов. Название, описание и пункты чеклиста могут
+// @Description содержать подстановки {{name}}. Видимость: ALL - всем пользователям, ADMIN - только администраторам.
+// @Tags templates-admins
+// @Accept json
+// @Produce json
+// @Param template body dto.TaskTemplateRequest true "Template"
+// @Success 201 {object} repository.TaskTemplate
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 409 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates [post]
+// .
+func (t *TaskTemplateController) Create(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	var request dto.TaskTemplateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	template := toTaskTemplate(&request)
+	if err := t.TaskTemplateService.Create(c.Request.Context(), template); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// Update изменяет шаблон задачи.
+// @Summary Update task template
+// @Description заменяет все поля шаблона задачи, только для администраторов. Созданные по шаблону задачи не меняются.
+// @Tags templates-admins
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param template body dto.TaskTemplateRequest true "Template"
+// @Success 200 {object} repository.TaskTemplate
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 409 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates/{id} [put]
+// .
+func (t *TaskTemplateController) Update(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "template ID is not number"})
+		return
+	}
+
+	var request dto.TaskTemplateRequest
+	if err = c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	template := toTaskTemplate(&request)
+	template.ID = templateID
+	if err = t.TaskTemplateService.Update(c.Request.Context(), template); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete удаляет шаблон задачи.
+// @Summary Delete task template
+// @Description удаляет шаблон задачи, только для администраторов. Созданные по шаблону задачи остаются.
+// @Tags templates-admins
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /templates/{id} [delete]
+// .
+func (t *TaskTemplateController) Delete(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "template ID is not number"})
+		return
+	}
+
+	if err = t.TaskTemplateService.Delete(c.Request.Context(), templateID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("template '%d' deleted", templateID)})
+}
+
+func toTaskTemplate(request *dto.TaskTemplateRequest) *repository.TaskTemplate {
+	return &repository.TaskTemplate{
+		Name:        request.Name,
+		Title:       request.Title,
+		Description: request.Description,
+		Priority:    request.Priority,
+		Labels:      request.Labels,
+		Checklist:   request.Checklist,
+		Visibility:  request.Visibility,
+	}
+}
@@ -0,0 +1,117 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskTemplateController_CreateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	mentionRepo := mockRepository.NewMockIMentionRepo(ctrl)
+	taskTemplateService := service.NewTaskTemplateService(templateRepo, taskRepo, userRepo, nil, checklistRepo,
+		outboxRepo, newTransactorMock(ctrl))
+	mentionService := service.NewMentionService(mentionRepo, taskRepo, userRepo, nil)
+	taskTemplateController := NewTaskTemplateController(taskTemplateService, nil, mentionService)
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.POST("/templates/:id/tasks", withSessionUser(user), taskTemplateController.CreateTask)
+
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&repository.TaskTemplate{
+		ID:          7,
+		Name:        "Релиз",
+		Title:       "Релиз {{version}}",
+		Description: "Выпустить {{version}}",
+		Priority:    constant.High,
+		Checklist:   []string{"Поставить тег {{version}}"},
+		Visibility:  constant.AllTemplateVisibility,
+	}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(user, nil)
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(15, nil)
+	checklistRepo.EXPECT().Create(gomock.Any(), &repository.ChecklistItem{TaskID: 15, Title: "Поставить тег 1.2"}).
+		Return(1, nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+	mentionRepo.EXPECT().ReplaceForTask(gomock.Any(), 15, []int{}, []int{}).Return([]int{}, nil)
+
+	values := url.Values{}
+	values.Set("UserLogin", "user")
+	values.Set("Values[version]", "1.2")
+	req := httptest.NewRequest(http.MethodPost, "/templates/7/tasks", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	response := w.Result()
+	require.Equal(t, http.StatusFound, response.StatusCode)
+	require.Equal(t, "/tasks/15", response.Header.Get("Location"))
+}
+
+func TestTaskTemplateController_Use_AdminTemplateHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	router := test.SetUpTestRouter()
+
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := service.NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+	taskTemplateController := NewTaskTemplateController(taskTemplateService, nil, nil)
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.GET("/templates/:id/use", withSessionUser(user), taskTemplateController.Use)
+
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&repository.TaskTemplate{
+		ID:         7,
+		Name:       "Увольнение",
+		Visibility: constant.AdminTemplateVisibility,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/templates/7/use", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestTaskTemplateController_ForbiddenForUser(t *testing.T) {
+	router := test.SetUpTestRouter()
+
+	taskTemplateService := service.NewTaskTemplateService(nil, nil, nil, nil, nil, nil, nil)
+	taskTemplateController := NewTaskTemplateController(taskTemplateService, nil, nil)
+
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.POST("/templates", withSessionUser(user), taskTemplateController.Create)
+	router.PUT("/templates/:id", withSessionUser(user), taskTemplateController.Update)
+	router.DELETE("/templates/:id", withSessionUser(user), taskTemplateController.Delete)
+
+	body := `{"name":"Релиз","title":"Релиз","description":"Выпустить версию","priority":2,"visibility":"ALL"}`
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/templates", strings.NewReader(body)),
+		httptest.NewRequest(http.MethodPut, "/templates/7", strings.NewReader(body)),
+		httptest.NewRequest(http.MethodDelete, "/templates/7", nil),
+	} {
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Result().StatusCode, req.Method)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/dto"
 	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/metrics"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	"github.com/romakorinenko/task-manager/internal/service"
 )
@@ -77,6 +78,7 @@ func (u *UserController) Login(c *gin.Context) {
 
 	user := u.UserService.GetByLogin(c.Request.Context(), username)
 	if user == nil {
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
 		c.JSON(http.StatusUnauthorized, dto.ResponseMap{"error": "invalid credentials"})
 		return
 	}
@@ -89,8 +91,10 @@ func (u *UserController) Login(c *gin.Context) {
 			return
 		}
 
+		metrics.Logins.WithLabelValues(metrics.LoginSuccess).Inc()
 		c.Redirect(http.StatusFound, "/tasks")
 	} else {
+		metrics.Logins.WithLabelValues(metrics.LoginFailure).Inc()
 		c.JSON(http.StatusUnauthorized, dto.ResponseMap{"error": "invalid credentials"})
 	}
 }
@@ -156,7 +160,7 @@ func (u *UserController) Create(c *gin.Context) {
 func (u *UserController) Block(c *gin.Context) {
 	userID := c.Param("id")
 
-	if u.UserService.GetUserRepository().BlockByID(c.Request.Context(), userID) {
+	if u.UserService.Block(c.Request.Context(), userID) {
 		c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("user '%s' blocked", userID)})
 	} else {
 		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "incorrect user ID"})
@@ -173,6 +177,11 @@ func (u *UserController) Block(c *gin.Context) {
 // @Router /users [get]
 // .
 func (u *UserController) GetAll(c *gin.Context) {
-	users := u.UserService.GetAll(c.Request.Context())
+	users, err := u.UserService.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
 	c.JSON(http.StatusOK, users)
 }
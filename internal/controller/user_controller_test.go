@@ -16,6 +16,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/romakorinenko/task-manager/internal/config"
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	"github.com/romakorinenko/task-manager/internal/service"
@@ -29,7 +30,8 @@ func TestUserController_Login(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	userRepo.Create(
@@ -87,7 +89,8 @@ func TestUserController_Logout(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	userRepo.Create(
@@ -124,7 +127,8 @@ func TestUserController_GetMainPage(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	userRepo.Create(
@@ -161,7 +165,8 @@ func TestUserController_Create(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	user := userRepo.Create(
@@ -240,7 +245,8 @@ func TestUserController_Block(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	user := userRepo.Create(
@@ -298,7 +304,8 @@ func TestUserController_GetAll(t *testing.T) {
 	defer DB.Close()
 
 	userRepo := repository.NewUserRepo(DB.DBPool)
-	userService := service.NewUserService(userRepo)
+	webhookService := service.NewWebhookService(repository.NewWebhookRepo(DB.DBPool), nil, &config.Webhooks{})
+	userService := service.NewUserService(userRepo, webhookService)
 	userController := NewUserController(userService)
 
 	r := test.SetUpTestRouter()
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/dto"
+	"github.com/romakorinenko/task-manager/internal/service"
+)
+
+type IWebhookController interface {
+	Create(c *gin.Context)
+	GetAll(c *gin.Context)
+	Delete(c *gin.Context)
+	GetDeliveries(c *gin.Context)
+	SendTest(c *gin.Context)
+	Redeliver(c *gin.Context)
+}
+
+type WebhookController struct {
+	WebhookService service.IWebhookService
+}
+
+func NewWebhookController(webhookService service.IWebhookService) *WebhookController {
+	return &WebhookController{WebhookService: webhookService}
+}
+
+// Create регистрирует подписку на события.
+// @Summary Create webhook
+// @Description регистрирует подписку на события задач и пользователей, только для администраторов.
+// @Description События: task.created, task.updated, task.deleted, user.created, user.blocked.
+// @Description Тело запроса подписчику подписано HMAC-SHA256 с секретом подписки в заголовке X-Webhook-Signature.
+// @Description Секрет возвращается только в ответе на этот запрос.
+// @Tags webhooks-admins
+// @Accept json
+// @Produce json
+// @Param webhook body dto.WebhookRequest true "Webhook"
+// @Success 201 {object} repository.Webhook
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks [post]
+// .
+func (w *WebhookController) Create(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	var request dto.WebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": err.Error()})
+		return
+	}
+
+	webhook, err := w.WebhookService.Create(c.Request.Context(), request.URL, request.Events, request.Secret)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// GetAll возвращает список подписок.
+// @Summary Get all webhooks
+// @Description возвращает список подписок без секретов, только для администраторов
+// @Tags webhooks-admins
+// @Produce json
+// @Success 200 {array} repository.Webhook
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks [get]
+// .
+func (w *WebhookController) GetAll(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	webhooks, err := w.WebhookService.GetAll(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ResponseMap{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// Delete удаляет подписку.
+// @Summary Delete webhook
+// @Description удаляет подписку вместе с журналом доставок, только для администраторов
+// @Tags webhooks-admins
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks/{id} [delete]
+// .
+func (w *WebhookController) Delete(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "webhook ID is not number"})
+		return
+	}
+
+	if err = w.WebhookService.Delete(c.Request.Context(), webhookID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("webhook '%d' deleted", webhookID)})
+}
+
+// GetDeliveries возвращает журнал доставок подписки.
+// @Summary Get webhook deliveries
+// @Description возвращает последние доставки подписки: статус, число попыток, ответ подписчика и последнюю ошибку.
+// @Description Доставка в статусе DEAD исчерпала все попытки и больше не повторяется.
+// @Tags webhooks-admins
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {array} repository.WebhookDelivery
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks/{id}/deliveries [get]
+// .
+func (w *WebhookController) GetDeliveries(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "webhook ID is not number"})
+		return
+	}
+
+	deliveries, err := w.WebhookService.GetDeliveries(c.Request.Context(), webhookID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// SendTest отправляет подписчику тестовое событие.
+// @Summary Send test webhook event
+// @Description сразу отправляет подписчику событие webhook.test и возвращает результат доставки
+// @Tags webhooks-admins
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} repository.WebhookDelivery
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks/{id}/test [post]
+// .
+func (w *WebhookController) SendTest(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "webhook ID is not number"})
+		return
+	}
+
+	delivery, err := w.WebhookService.SendTest(c.Request.Context(), webhookID)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Redeliver повторяет доставку, исчерпавшую все попытки.
+// @Summary Redeliver dead webhook delivery
+// @Description возвращает доставку из статуса DEAD в очередь с полным числом попыток
+// @Tags webhooks-admins
+// @Produce json
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} dto.ResponseMap
+// @Failure 400 {object} dto.ResponseMap
+// @Failure 401 {object} dto.ResponseMap
+// @Failure 403 {object} dto.ResponseMap
+// @Failure 404 {object} dto.ResponseMap
+// @Failure 500 {object} dto.ResponseMap
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+// .
+func (w *WebhookController) Redeliver(c *gin.Context) {
+	if getSessionAdmin(c) == nil {
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "webhook ID is not number"})
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ResponseMap{"error": "delivery ID is not number"})
+		return
+	}
+
+	if err = w.WebhookService.Redeliver(c.Request.Context(), webhookID, deliveryID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ResponseMap{"message": fmt.Sprintf("delivery '%d' queued", deliveryID)})
+}
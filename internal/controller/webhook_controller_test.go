@@ -0,0 +1,40 @@
+//go:build unit && !integration
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/service"
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookController_Create_OnlyForAdmin(t *testing.T) {
+	webhookController := NewWebhookController(service.NewWebhookService(nil, nil, nil))
+
+	router := test.SetUpTestRouter()
+	user := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	router.POST("/anonymous/webhooks", webhookController.Create)
+	router.POST("/user/webhooks", withSessionUser(user), webhookController.Create)
+
+	// секрет выбирает сам клиент, поэтому без проверки роли подписку с чужими событиями мог бы создать любой.
+	body := `{"url":"https://example.com/hook","events":["task.created"],"secret":"attacker-secret"}`
+
+	t.Run("анонимный запрос", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/anonymous/webhooks", strings.NewReader(body)))
+		require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+	})
+
+	t.Run("пользователь с ролью USER", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/user/webhooks", strings.NewReader(body)))
+		require.Equal(t, http.StatusForbidden, w.Result().StatusCode)
+	})
+}
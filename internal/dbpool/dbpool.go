@@ -5,6 +5,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/tracing"
 )
 
 func NewDBPool(ctx context.Context, dBCfg *config.DB) (*pgxpool.Pool, error) {
@@ -12,5 +13,6 @@ func NewDBPool(ctx context.Context, dBCfg *config.DB) (*pgxpool.Pool, error) {
 	if err != nil {
 		return nil, err
 	}
+	DBConfig.ConnConfig.Tracer = tracing.NewQueryTracer()
 	return pgxpool.NewWithConfig(ctx, DBConfig)
 }
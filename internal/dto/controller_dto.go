@@ -1,3 +1,55 @@
 package dto
 
+import "github.com/romakorinenko/task-manager/internal/repository"
+
 type ResponseMap map[string]string
+
+type UnreadCountResponse struct {
+	Count int `json:"count"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://ci.example.com/hooks/tasks"`
+	Events []string `json:"events" example:"task.created,task.updated"`
+	// Secret - ключ подписи запросов; если не указан, будет сгенерирован.
+	Secret string `json:"secret"`
+}
+
+// TaskConflictResponse возвращается, если задачу изменили после того, как клиент её прочитал.
+type TaskConflictResponse struct {
+	Error string           `json:"error"`
+	Task  *repository.Task `json:"task"`
+}
+
+// TaskBulkRequest - массовая операция над задачами из TaskIDs или, если они не заданы, над задачами по Filter
+// или сохранённому фильтру FilterID.
+type TaskBulkRequest struct {
+	// Action - status, priority, assign, label или delete.
+	Action string `json:"action" example:"status"`
+	// Value - новый статус, приоритет, логин исполнителя или метка; для delete не нужен.
+	Value    string                 `json:"value" example:"DONE"`
+	TaskIDs  []int                  `json:"taskIds" example:"1,2,3"`
+	Filter   *repository.TaskFilter `json:"filter"`
+	FilterID int                    `json:"filterId" example:"4"`
+}
+
+// SavedFilterRequest - сохранённый фильтр задач.
+type SavedFilterRequest struct {
+	Name   string                `json:"name" example:"Мои срочные"`
+	Filter repository.TaskFilter `json:"filter"`
+	// Visibility - PRIVATE (по умолчанию) - только владельцу, SHARED - всем пользователям,
+	// GLOBAL - всем пользователям и закреплён у всех, задаёт только ADMIN.
+	Visibility string `json:"visibility" example:"PRIVATE"`
+}
+
+// TaskTemplateRequest - шаблон задачи. Title, Description и пункты Checklist могут содержать подстановки {{name}}.
+type TaskTemplateRequest struct {
+	Name        string   `json:"name" example:"Релиз"`
+	Title       string   `json:"title" example:"Релиз {{version}}"`
+	Description string   `json:"description" example:"Выпустить версию {{version}}"`
+	Priority    int      `json:"priority" example:"2"`
+	Labels      []string `json:"labels" example:"release"`
+	Checklist   []string `json:"checklist" example:"Собрать changelog {{version}},Поставить тег"`
+	// Visibility - ALL (по умолчанию) или ADMIN.
+	Visibility string `json:"visibility" example:"ALL"`
+}
@@ -1,13 +1,57 @@
 package dto
 
 import (
+	"html/template"
+
 	"github.com/romakorinenko/task-manager/internal/repository"
 )
 
 type TasksWithLoginTemplateData struct {
-	Tasks []repository.TaskWithLogin
+	Tasks             []repository.TaskWithLogin
+	ChecklistProgress map[int]repository.ChecklistProgress
+	// PinnedFilters - закреплённые фильтры пользователя, Filter - условия, по которым отобраны Tasks,
+	// FilterID - выбранный сохранённый фильтр или 0.
+	PinnedFilters []repository.SavedFilter
+	Filter        *repository.TaskFilter
+	FilterID      int
+}
+
+type TaskTemplateData struct {
+	*repository.TaskWithLogin
+	DescriptionHTML   template.HTML `swaggertype:"string"`
+	MentionedIn       []repository.TaskBacklink
+	Checklist         []repository.ChecklistItem
+	ChecklistProgress repository.ChecklistProgress
+	Attachments       []repository.Attachment
+	Comments          []repository.Comment
 }
 
 type UsersTemplateData struct {
 	Users []repository.User
 }
+
+type NotificationsTemplateData struct {
+	Notifications []repository.Notification
+	Unread        int
+	Preferences   []repository.NotificationPreference
+	EventTitles   map[string]string
+	Email         string
+	EmailDigest   bool
+}
+
+type TaskTemplatesTemplateData struct {
+	Templates []repository.TaskTemplate
+}
+
+type TaskFromTemplateTemplateData struct {
+	Template     *repository.TaskTemplate
+	Placeholders []string
+	Users        []repository.User
+}
+
+type ChartsTemplateData struct {
+	From           string
+	To             string
+	Burndown       template.HTML `swaggertype:"string"`
+	CumulativeFlow template.HTML `swaggertype:"string"`
+}
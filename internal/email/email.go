@@ -0,0 +1,148 @@
+package email
+
+//go:generate mockgen -source=email.go -destination=mocks/email_mocks.go
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type ISender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type IQueue interface {
+	Enqueue(msg Message) error
+}
+
+// SMTPSender отправляет письма через SMTP-релей. STARTTLS используется, если сервер его поддерживает.
+type SMTPSender struct {
+	cfg *config.Email
+}
+
+func NewSMTPSender(cfg *config.Email) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+	body, err := buildMessage(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	dialer := net.Dialer{Timeout: s.cfg.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port)))
+	if err != nil {
+		return err
+	}
+	if s.cfg.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.cfg.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to.Address); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(body); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage собирает письмо multipart/alternative с текстовой и HTML-версией.
+func buildMessage(from, to *mail.Address, msg Message) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=UTF-8", content: msg.Text},
+		{contentType: "text/html; charset=UTF-8", content: msg.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err = encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var res bytes.Buffer
+	fmt.Fprintf(&res, "From: %s\r\n", from.String())
+	fmt.Fprintf(&res, "To: %s\r\n", to.String())
+	fmt.Fprintf(&res, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&res, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	res.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&res, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	res.Write(body.Bytes())
+
+	return res.Bytes(), nil
+}
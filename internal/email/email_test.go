@@ -0,0 +1,80 @@
+//go:build unit && !integration
+
+package email
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPSender_Send_MailCaptured(t *testing.T) {
+	server := test.RunSMTPCaptureServer(t)
+	sender := NewSMTPSender(server.Config)
+
+	msg, err := NotificationMessage("ivan@example.com", NotificationData{
+		EventTitle: "Мне назначена задача",
+		Message:    "Вам назначена задача «Release»",
+		TaskURL:    "http://localhost:8080/tasks/1",
+		CreatedAt:  time.Now(),
+	})
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), msg)
+	require.NoError(t, err)
+
+	mails := server.Mails()
+	require.Len(t, mails, 1)
+	require.Equal(t, "task-manager@localhost", mails[0].From)
+	require.Equal(t, []string{"ivan@example.com"}, mails[0].To)
+	require.Contains(t, mails[0].Data, "Subject: "+mime.QEncoding.Encode("UTF-8", "Вам назначена задача «Release»"))
+	require.Contains(t, mails[0].Data, "Content-Type: text/plain; charset=UTF-8")
+	require.Contains(t, mails[0].Data, "Content-Type: text/html; charset=UTF-8")
+	require.Contains(t, mails[0].Data, "http://localhost:8080/tasks/1")
+}
+
+func TestQueue_Send_RetriedUntilDelivered(t *testing.T) {
+	server := test.RunSMTPCaptureServer(t)
+	server.FailFirst(2)
+	queue := NewQueue(NewSMTPSender(server.Config), 10, 3, time.Millisecond)
+	queue.Start()
+
+	err := queue.Enqueue(Message{To: "ivan@example.com", Subject: "subject", Text: "text", HTML: "<p>text</p>"})
+	require.NoError(t, err)
+	queue.Close()
+
+	require.Len(t, server.Mails(), 1)
+}
+
+func TestQueue_Send_GivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	queue := NewQueue(senderFunc(func(context.Context, Message) error {
+		attempts++
+		return errors.New("connection refused")
+	}), 10, 3, time.Millisecond)
+	queue.Start()
+
+	require.NoError(t, queue.Enqueue(Message{To: "ivan@example.com"}))
+	queue.Close()
+
+	require.Equal(t, 3, attempts)
+	require.Equal(t, ErrQueueClosed, queue.Enqueue(Message{To: "ivan@example.com"}))
+}
+
+func TestQueue_Enqueue_DoesNotBlockWhenFull(t *testing.T) {
+	queue := NewQueue(nil, 1, 1, 0)
+
+	require.NoError(t, queue.Enqueue(Message{To: "ivan@example.com"}))
+	require.Equal(t, ErrQueueFull, queue.Enqueue(Message{To: "petr@example.com"}))
+}
+
+type senderFunc func(ctx context.Context, msg Message) error
+
+func (f senderFunc) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: email.go
+//
+// Generated by this command:
+//
+//	mockgen -source=email.go -destination=mocks/email_mocks.go
+//
+
+// Package mock_email is a generated GoMock package.
+package mock_email
+
+import (
+	context "context"
+	reflect "reflect"
+
+	email "github.com/romakorinenko/task-manager/internal/email"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISender is a mock of ISender interface.
+type MockISender struct {
+	ctrl     *gomock.Controller
+	recorder *MockISenderMockRecorder
+}
+
+// MockISenderMockRecorder is the mock recorder for MockISender.
+type MockISenderMockRecorder struct {
+	mock *MockISender
+}
+
+// NewMockISender creates a new mock instance.
+func NewMockISender(ctrl *gomock.Controller) *MockISender {
+	mock := &MockISender{ctrl: ctrl}
+	mock.recorder = &MockISenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISender) EXPECT() *MockISenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockISender) Send(ctx context.Context, msg email.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockISenderMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockISender)(nil).Send), ctx, msg)
+}
+
+// MockIQueue is a mock of IQueue interface.
+type MockIQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQueueMockRecorder
+}
+
+// MockIQueueMockRecorder is the mock recorder for MockIQueue.
+type MockIQueueMockRecorder struct {
+	mock *MockIQueue
+}
+
+// NewMockIQueue creates a new mock instance.
+func NewMockIQueue(ctrl *gomock.Controller) *MockIQueue {
+	mock := &MockIQueue{ctrl: ctrl}
+	mock.recorder = &MockIQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQueue) EXPECT() *MockIQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockIQueue) Enqueue(msg email.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockIQueueMockRecorder) Enqueue(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockIQueue)(nil).Enqueue), msg)
+}
@@ -0,0 +1,95 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	ErrQueueFull   = errors.New("email queue is full")
+	ErrQueueClosed = errors.New("email queue is closed")
+)
+
+// Queue отправляет письма в фоне, чтобы запросы пользователей не ждали SMTP-сервер.
+// Неудачная отправка повторяется maxAttempts раз с экспоненциально растущей паузой.
+type Queue struct {
+	sender      ISender
+	messages    chan Message
+	maxAttempts int
+	backoff     time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+func NewQueue(sender ISender, size, maxAttempts int, backoff time.Duration) *Queue {
+	return &Queue{
+		sender:      sender,
+		messages:    make(chan Message, size),
+		maxAttempts: max(maxAttempts, 1),
+		backoff:     backoff,
+		done:        make(chan struct{}),
+	}
+}
+
+// Start запускает обработчик очереди. Close можно вызывать только после Start.
+func (q *Queue) Start() {
+	go func() {
+		defer close(q.done)
+		for msg := range q.messages {
+			q.send(msg)
+		}
+	}()
+}
+
+// Enqueue ставит письмо в очередь не блокируясь. Если очередь переполнена, письмо не принимается.
+func (q *Queue) Enqueue(msg Message) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.messages <- msg:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close перестаёт принимать письма и ждёт отправки уже поставленных в очередь.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.messages)
+	}
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+func (q *Queue) send(msg Message) {
+	for attempt := 1; ; attempt++ {
+		err := q.sender.Send(context.Background(), msg)
+		if err == nil {
+			return
+		}
+		if attempt >= q.maxAttempts {
+			slog.Error("cannot send email",
+				slog.String("to", msg.To),
+				slog.String("subject", msg.Subject),
+				slog.Int("attempts", attempt),
+				slog.Any("error", err),
+			)
+			return
+		}
+
+		time.Sleep(q.backoff << (attempt - 1))
+	}
+}
@@ -0,0 +1,52 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+var (
+	htmlTemplates = htmlTemplate.Must(htmlTemplate.ParseFS(templates, "templates/*.html"))
+	textTemplates = textTemplate.Must(textTemplate.ParseFS(templates, "templates/*.txt"))
+)
+
+type NotificationData struct {
+	EventTitle string
+	Message    string
+	TaskURL    string
+	CreatedAt  time.Time
+}
+
+type DigestData struct {
+	Login         string
+	Notifications []NotificationData
+	InboxURL      string
+}
+
+// NotificationMessage собирает письмо об одном уведомлении.
+func NotificationMessage(to string, data NotificationData) (Message, error) {
+	return render(to, data.Message, "notification", data)
+}
+
+// DigestMessage собирает ежедневную сводку уведомлений.
+func DigestMessage(to, subject string, data DigestData) (Message, error) {
+	return render(to, subject, "digest", data)
+}
+
+func render(to, subject, name string, data any) (Message, error) {
+	var html, text bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return Message{}, err
+	}
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return Message{}, err
+	}
+
+	return Message{To: to, Subject: subject, HTML: html.String(), Text: text.String()}, nil
+}
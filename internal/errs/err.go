@@ -6,8 +6,50 @@ func (u UserExistsErr) Error() string {
 	return "user with the login already exists"
 }
 
+type TemplateExistsErr struct{}
+
+func (t TemplateExistsErr) Error() string {
+	return "task template with the name already exists"
+}
+
+type FilterExistsErr struct{}
+
+func (f FilterExistsErr) Error() string {
+	return "saved filter with the name already exists"
+}
+
 type BadReqErr struct{}
 
 func (b BadReqErr) Error() string {
 	return "bad request"
 }
+
+type NotFoundErr struct{}
+
+func (n NotFoundErr) Error() string {
+	return "not found"
+}
+
+type ForbiddenErr struct{}
+
+func (f ForbiddenErr) Error() string {
+	return "forbidden"
+}
+
+type TooLargeErr struct{}
+
+func (t TooLargeErr) Error() string {
+	return "file is too large"
+}
+
+type UnsupportedMediaTypeErr struct{}
+
+func (u UnsupportedMediaTypeErr) Error() string {
+	return "unsupported media type"
+}
+
+type ConflictErr struct{}
+
+func (c ConflictErr) Error() string {
+	return "task was modified by another user"
+}
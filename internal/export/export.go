@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout - формат дат в CSV и XLSX. В JSON даты записываются в RFC 3339.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Writer записывает таблицу построчно, не накапливая строки в памяти. Значения строки идут в порядке столбцов,
+// переданных при создании; поддерживаются string, int, time.Time, []string и nil.
+type Writer interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// NewCSVWriter создаёт Writer для CSV и сразу записывает строку заголовков.
+func NewCSVWriter(w io.Writer, columns []string) (Writer, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer}, nil
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, 0, len(values))
+	for _, value := range values {
+		record = append(record, formatText(value))
+	}
+
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type jsonWriter struct {
+	encoder *json.Encoder
+	columns []string
+}
+
+// NewJSONWriter создаёт Writer для JSON Lines: каждая строка - отдельный объект с ключами-столбцами.
+func NewJSONWriter(w io.Writer, columns []string) Writer {
+	return &jsonWriter{encoder: json.NewEncoder(w), columns: columns}
+}
+
+func (j *jsonWriter) WriteRow(values []any) error {
+	object := make(map[string]any, len(values))
+	for i, value := range values {
+		object[j.columns[i]] = value
+	}
+
+	return j.encoder.Encode(object)
+}
+
+func (j *jsonWriter) Close() error {
+	return nil
+}
+
+// formatText приводит значение к тексту ячейки CSV или XLSX.
+func formatText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.Format(TimeLayout)
+	case []string:
+		return strings.Join(v, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
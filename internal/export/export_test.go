@@ -0,0 +1,84 @@
+//go:build unit && !integration
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var exportedAt = time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+
+func TestCSVWriter_WriteRow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewCSVWriter(buf, []string{"id", "title", "createdAt", "labels"})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteRow([]any{1, "Release, v2", exportedAt, []string{"backend", "urgent"}}))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "id,title,createdAt,labels\n1,\"Release, v2\",2026-10-19 12:30:00,\"backend, urgent\"\n",
+		buf.String())
+}
+
+func TestJSONWriter_WriteRow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := NewJSONWriter(buf, []string{"id", "title", "labels"})
+
+	require.NoError(t, writer.WriteRow([]any{1, "Release", []string{"backend"}}))
+	require.NoError(t, writer.WriteRow([]any{2, "Docs", []string{}}))
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "{\"id\":1,\"labels\":[\"backend\"],\"title\":\"Release\"}\n"+
+		"{\"id\":2,\"labels\":[],\"title\":\"Docs\"}\n", buf.String())
+}
+
+func TestXLSXWriter_WriteRow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewXLSXWriter(buf, []string{"id", "title"})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteRow([]any{1, "Fix <b> & \x01"}))
+	require.NoError(t, writer.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(archive.File))
+	var sheet []byte
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			content, openErr := file.Open()
+			require.NoError(t, openErr)
+			sheet, err = io.ReadAll(content)
+			require.NoError(t, err)
+		}
+	}
+	require.ElementsMatch(t, []string{
+		"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels",
+		"xl/worksheets/sheet1.xml",
+	}, names)
+
+	var worksheet struct {
+		Rows []struct {
+			Cells []struct {
+				Type   string `xml:"t,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(sheet, &worksheet))
+	require.Len(t, worksheet.Rows, 2)
+	require.Equal(t, "title", worksheet.Rows[0].Cells[1].Inline)
+	require.Equal(t, "n", worksheet.Rows[1].Cells[0].Type)
+	require.Equal(t, "1", worksheet.Rows[1].Cells[0].Value)
+	require.Equal(t, "Fix <b> & �", worksheet.Rows[1].Cells[1].Inline)
+}
@@ -0,0 +1,131 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// maxXLSXCellLen - ограничение Excel на длину текста в ячейке.
+const maxXLSXCellLen = 32767
+
+// Служебные части книги с одним листом. Лист записывается последним, строка за строкой.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Tasks" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+}
+
+// NewXLSXWriter создаёт Writer для книги Excel с одним листом и записывает строку заголовков.
+// Книга пишется в w по мере добавления строк и завершается в Close.
+func NewXLSXWriter(w io.Writer, columns []string) (Writer, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		partWriter, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetWriter, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer := &xlsxWriter{archive: archive, sheet: bufio.NewWriter(sheetWriter)}
+	_, err = writer.sheet.WriteString(xml.Header +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]any, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column)
+	}
+	if err = writer.WriteRow(header); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+func (x *xlsxWriter) WriteRow(values []any) error {
+	if _, err := x.sheet.WriteString("<row>"); err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		var err error
+		if number, ok := value.(int); ok {
+			_, err = x.sheet.WriteString(`<c t="n"><v>` + strconv.Itoa(number) + `</v></c>`)
+		} else {
+			err = x.writeText(formatText(value))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) writeText(text string) error {
+	if utf8.RuneCountInString(text) > maxXLSXCellLen {
+		text = string([]rune(text)[:maxXLSXCellLen])
+	}
+
+	if _, err := x.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+		return err
+	}
+	// EscapeText заменяет недопустимые в XML символы, иначе Excel не откроет файл.
+	if err := xml.EscapeText(x.sheet, []byte(text)); err != nil {
+		return err
+	}
+	_, err := x.sheet.WriteString(`</t></is></c>`)
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := x.sheet.WriteString("</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.archive.Close()
+}
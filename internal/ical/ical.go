@@ -0,0 +1,110 @@
+// Package ical записывает календари в формате iCalendar (RFC 5545).
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLen - длина строки в октетах, после которой строка переносится.
+const maxLineLen = 75
+
+const timeLayout = "20060102T150405Z"
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Writer записывает календарь построчно. Первая ошибка записи запоминается и возвращается из Close.
+type Writer struct {
+	writer *bufio.Writer
+	err    error
+}
+
+// NewWriter начинает календарь с именем name. refresh - как часто клиенту обновлять календарь.
+func NewWriter(w io.Writer, name string, refresh time.Duration) *Writer {
+	writer := &Writer{writer: bufio.NewWriter(w)}
+	writer.Begin("VCALENDAR")
+	writer.Property("VERSION", "2.0")
+	writer.Property("PRODID", "-//task-manager//calendar//RU")
+	writer.Property("CALSCALE", "GREGORIAN")
+	writer.Text("X-WR-CALNAME", name)
+	writer.Property("REFRESH-INTERVAL;VALUE=DURATION", duration(refresh))
+	writer.Property("X-PUBLISHED-TTL", duration(refresh))
+
+	return writer
+}
+
+// Begin открывает компонент, например VEVENT или VTODO.
+func (w *Writer) Begin(component string) {
+	w.Property("BEGIN", component)
+}
+
+// End закрывает компонент.
+func (w *Writer) End(component string) {
+	w.Property("END", component)
+}
+
+// Property записывает свойство со значением как есть.
+func (w *Writer) Property(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// Text записывает текстовое свойство, экранируя спецсимволы.
+func (w *Writer) Text(name, value string) {
+	w.Property(name, textEscaper.Replace(value))
+}
+
+// TextList записывает свойство со списком текстовых значений через запятую, например CATEGORIES.
+func (w *Writer) TextList(name string, values []string) {
+	escaped := make([]string, 0, len(values))
+	for _, value := range values {
+		escaped = append(escaped, textEscaper.Replace(value))
+	}
+	w.Property(name, strings.Join(escaped, ","))
+}
+
+// Time записывает свойство с датой и временем в UTC.
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, t.UTC().Format(timeLayout))
+}
+
+// Close завершает календарь и сбрасывает буфер.
+func (w *Writer) Close() error {
+	w.End("VCALENDAR")
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.writer.Flush()
+}
+
+// line записывает строку, разбивая её на части не длиннее maxLineLen октетов, не разрывая символы UTF-8.
+// Каждая часть после первой начинается с пробела.
+func (w *Writer) line(line string) {
+	if w.err != nil {
+		return
+	}
+
+	limit := maxLineLen
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if _, w.err = w.writer.WriteString(line[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+		line = line[cut:]
+		// ведущий пробел продолжения тоже входит в длину строки.
+		limit = maxLineLen - 1
+	}
+	_, w.err = w.writer.WriteString(line + "\r\n")
+}
+
+// duration записывает длительность в минутах, как её понимают календари: PT15M.
+func duration(d time.Duration) string {
+	return "PT" + strconv.Itoa(int(d.Minutes())) + "M"
+}
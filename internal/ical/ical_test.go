@@ -0,0 +1,57 @@
+//go:build unit && !integration
+
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Calendar(t *testing.T) {
+	var out strings.Builder
+	writer := NewWriter(&out, "Задачи", 15*time.Minute)
+	writer.Begin("VEVENT")
+	writer.Text("SUMMARY", "Release; then, docs\\notes\nnext line")
+	writer.TextList("CATEGORIES", []string{"backend", "a,b"})
+	writer.Time("DTSTART", time.Date(2026, 10, 20, 15, 4, 5, 0, time.FixedZone("MSK", 3*60*60)))
+	writer.End("VEVENT")
+	require.NoError(t, writer.Close())
+
+	require.Equal(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//task-manager//calendar//RU\r\n"+
+		"CALSCALE:GREGORIAN\r\n"+
+		"X-WR-CALNAME:Задачи\r\n"+
+		"REFRESH-INTERVAL;VALUE=DURATION:PT15M\r\n"+
+		"X-PUBLISHED-TTL:PT15M\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		`SUMMARY:Release\; then\, docs\\notes\nnext line`+"\r\n"+
+		`CATEGORIES:backend,a\,b`+"\r\n"+
+		"DTSTART:20261020T120405Z\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n", out.String())
+}
+
+func TestWriter_LongLinesFolded(t *testing.T) {
+	var out strings.Builder
+	writer := NewWriter(&out, "", time.Hour)
+	description := strings.Repeat("задача ", 30)
+	writer.Text("DESCRIPTION", description)
+	require.NoError(t, writer.Close())
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), maxLineLen)
+		require.True(t, utf8.ValidString(line))
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+	require.Contains(t, unfolded.String(), "\nDESCRIPTION:"+description+"\n")
+}
@@ -0,0 +1,104 @@
+package live
+
+import (
+	"sync"
+
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// ResetEvent сообщает клиенту, что пропущенных событий слишком много и страницу нужно загрузить заново.
+const ResetEvent = "reset"
+
+// Event - изменение задачи, отправляемое открытым страницам. ID совпадает с идентификатором события в outbox
+// и используется клиентом как Last-Event-ID при переподключении.
+// PreviousUserID - прежний исполнитель, если задачу переназначили.
+type Event struct {
+	ID             int             `json:"id"`
+	Type           string          `json:"type"`
+	Task           repository.Task `json:"task"`
+	UserLogin      string          `json:"userLogin"`
+	PreviousUserID int             `json:"previousUserId,omitempty"`
+}
+
+// Broker рассылает события всем подписчикам внутри процесса.
+type Broker struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+func NewBroker(bufferSize int) *Broker {
+	return &Broker{
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscription - подписка на события брокера. Канал Events закрывается, когда подписка закрыта, брокер
+// остановлен или подписчик не успевает читать события.
+type Subscription struct {
+	broker *Broker
+	events chan Event
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close отписывается от брокера. Повторный вызов ничего не делает.
+func (s *Subscription) Close() {
+	s.broker.remove(s)
+}
+
+func (b *Broker) Subscribe() *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription := &Subscription{broker: b, events: make(chan Event, b.bufferSize)}
+	if b.closed {
+		close(subscription.events)
+		return subscription
+	}
+	b.subscribers[subscription] = struct{}{}
+
+	return subscription
+}
+
+// Publish отправляет событие всем подписчикам, не блокируясь. Подписчик с заполненным буфером отключается:
+// клиент переподключится и получит пропущенные события по Last-Event-ID.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscription := range b.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			delete(b.subscribers, subscription)
+			close(subscription.events)
+		}
+	}
+}
+
+// Close отключает всех подписчиков; новые подписки сразу закрыты.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscription := range b.subscribers {
+		delete(b.subscribers, subscription)
+		close(subscription.events)
+	}
+}
+
+func (b *Broker) remove(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[subscription]; ok {
+		delete(b.subscribers, subscription)
+		close(subscription.events)
+	}
+}
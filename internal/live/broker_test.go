@@ -0,0 +1,48 @@
+//go:build unit && !integration
+
+package live
+
+import (
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker_Publish_AllSubscribersReceived(t *testing.T) {
+	broker := NewBroker(1)
+	first := broker.Subscribe()
+	second := broker.Subscribe()
+
+	broker.Publish(Event{ID: 1, Task: repository.Task{ID: 5}})
+
+	require.Equal(t, 1, (<-first.Events()).ID)
+	require.Equal(t, 1, (<-second.Events()).ID)
+}
+
+func TestBroker_Publish_SlowSubscriberDropped(t *testing.T) {
+	broker := NewBroker(1)
+	slow := broker.Subscribe()
+
+	broker.Publish(Event{ID: 1})
+	broker.Publish(Event{ID: 2})
+
+	event, ok := <-slow.Events()
+	require.True(t, ok)
+	require.Equal(t, 1, event.ID)
+	_, ok = <-slow.Events()
+	require.False(t, ok)
+	slow.Close()
+}
+
+func TestBroker_Close_SubscriptionsClosed(t *testing.T) {
+	broker := NewBroker(1)
+	subscription := broker.Subscribe()
+
+	broker.Close()
+	_, ok := <-subscription.Events()
+	require.False(t, ok)
+
+	_, ok = <-broker.Subscribe().Events()
+	require.False(t, ok)
+}
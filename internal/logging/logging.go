@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+)
+
+const (
+	JSONFormat = "json"
+	TextFormat = "text"
+)
+
+type requestIDKey struct{}
+
+// NewLogger создаёт логгер с уровнем и форматом из конфигурации. К каждой записи, сделанной с контекстом запроса
+// (slog.InfoContext, slog.ErrorContext, ...), добавляется requestId.
+func NewLogger(w io.Writer, cfg *config.Logging) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch cfg.Format {
+	case JSONFormat:
+		handler = slog.NewJSONHandler(w, options)
+	case TextFormat:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// WithRequestID возвращает контекст, записи с которым помечаются идентификатором запроса id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID возвращает идентификатор запроса из контекста или пустую строку.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("requestId", id))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
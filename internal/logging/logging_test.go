@@ -0,0 +1,49 @@
+//go:build unit && !integration
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_RequestIDAdded(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &config.Logging{Level: "info", Format: JSONFormat})
+	require.NoError(t, err)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.With(slog.String("component", "test")).DebugContext(ctx, "hidden")
+	logger.With(slog.String("component", "test")).ErrorContext(ctx, "cannot get users")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "cannot get users", record["msg"])
+	require.Equal(t, "req-1", record["requestId"])
+	require.Equal(t, "test", record["component"])
+}
+
+func TestNewLogger_TextWithoutRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &config.Logging{Level: "debug", Format: TextFormat})
+	require.NoError(t, err)
+
+	logger.DebugContext(context.Background(), "started")
+
+	require.Contains(t, buf.String(), "level=DEBUG msg=started")
+	require.NotContains(t, buf.String(), "requestId")
+}
+
+func TestNewLogger_InvalidConfig(t *testing.T) {
+	_, err := NewLogger(&bytes.Buffer{}, &config.Logging{Level: "verbose", Format: JSONFormat})
+	require.Error(t, err)
+
+	_, err = NewLogger(&bytes.Buffer{}, &config.Logging{Level: "info", Format: "xml"})
+	require.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+)
+
+const highlightStyle = "github"
+
+var (
+	renderer = goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(highlightStyle),
+				highlighting.WithFormatOptions(chromahtml.WithClasses(false)),
+			),
+		),
+		goldmark.WithParserOptions(parser.WithASTTransformers(newReferencesExtension())),
+	)
+
+	// подсветка кода chroma задаётся inline-стилями, поэтому разрешаем только безопасные css-свойства.
+	htmlPolicy = func() *bluemonday.Policy {
+		policy := bluemonday.UGCPolicy()
+		policy.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").
+			OnElements("span", "pre")
+		policy.RequireNoFollowOnLinks(true)
+		policy.AddTargetBlankToFullyQualifiedLinks(true)
+		return policy
+	}()
+
+	textPolicy = bluemonday.StrictPolicy()
+
+	spacesRegexp = regexp.MustCompile(`\s+`)
+)
+
+// ToHTML превращает markdown в безопасный для вставки в шаблон HTML.
+func ToHTML(source string) template.HTML {
+	return render(source, parser.NewContext())
+}
+
+// ToHTMLWithReferences как ToHTML, но превращает известные упоминания @login и #123 в ссылки.
+func ToHTMLWithReferences(source string, refs References) template.HTML {
+	pc := parser.NewContext()
+	pc.Set(referencesContextKey, refs)
+	return render(source, pc)
+}
+
+func render(source string, pc parser.Context) template.HTML {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf, parser.WithContext(pc)); err != nil {
+		slog.Error("cannot render markdown", slog.Any("error", err))
+		return template.HTML(template.HTMLEscapeString(source)) //nolint:gosec // исходный текст экранирован
+	}
+
+	return template.HTML(htmlPolicy.SanitizeBytes(buf.Bytes())) //nolint:gosec // санитизировано bluemonday
+}
+
+// TemplateFuncs - функции для html-шаблонов: {{markdown .Description}} и {{markdownText .Description}}.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"markdown":     ToHTML,
+		"markdownText": ToText,
+	}
+}
+
+// ToText возвращает текст без разметки в одну строку, например для таблиц и JSON-ответов.
+func ToText(source string) string {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return strings.TrimSpace(spacesRegexp.ReplaceAllString(source, " "))
+	}
+
+	text := html.UnescapeString(textPolicy.Sanitize(addBlockSpaces(buf.String())))
+	return strings.TrimSpace(spacesRegexp.ReplaceAllString(text, " "))
+}
+
+// addBlockSpaces не даёт склеиться словам соседних блоков после удаления тегов.
+func addBlockSpaces(renderedHTML string) string {
+	return strings.NewReplacer("</p>", "</p> ", "</li>", "</li> ", "<br>", " ", "</h", " </h", "</pre>", "</pre> ").
+		Replace(renderedHTML)
+}
@@ -0,0 +1,55 @@
+//go:build unit && !integration
+
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTML_MarkdownRendered(t *testing.T) {
+	rendered := string(ToHTML("# Title\n\n- *first*\n- second"))
+
+	require.Contains(t, rendered, "<h1>Title</h1>")
+	require.Contains(t, rendered, "<li><em>first</em></li>")
+}
+
+func TestToHTML_CodeHighlighted(t *testing.T) {
+	rendered := string(ToHTML("```go\nfunc main() {}\n```"))
+
+	require.Contains(t, rendered, "<pre style=")
+	require.Contains(t, rendered, `<span style="color: #000; font-weight: bold">func</span>`)
+}
+
+func TestToHTML_UnsafeHTMLRemoved(t *testing.T) {
+	rendered := string(ToHTML(
+		"<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>\n\n[link](javascript:alert(1))"))
+
+	require.NotContains(t, rendered, "<script")
+	require.NotContains(t, rendered, "onerror")
+	require.NotContains(t, rendered, "javascript:")
+}
+
+func TestToText_MarkupStripped(t *testing.T) {
+	text := ToText("# Title\n\nSome **bold** text & [link](https://example.com)\n\n- a\n- b")
+
+	require.Equal(t, "Title Some bold text & link a b", text)
+}
+
+func TestExtractReferences_CodeAndLinksSkipped(t *testing.T) {
+	logins, taskIDs := ExtractReferences("@ivan_petrov see #12, #12 and `@admin #3`, mail a@b.com, [x](#4) or a#5")
+
+	require.Equal(t, []string{"ivan_petrov"}, logins)
+	require.Equal(t, []int{12}, taskIDs)
+}
+
+func TestToHTMLWithReferences_KnownReferencesLinked(t *testing.T) {
+	rendered := string(ToHTMLWithReferences("ask @ivan or @blocked about #1 and #2", References{
+		Logins:  map[string]bool{"ivan": true},
+		TaskIDs: map[int]bool{1: true},
+	}))
+
+	require.Equal(t, `<p>ask <a href="/tasks/user/ivan" rel="nofollow">@ivan</a> or @blocked about `+
+		`<a href="/tasks/1" rel="nofollow">#1</a> and #2</p>`+"\n", rendered)
+}
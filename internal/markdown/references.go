@@ -0,0 +1,173 @@
+package markdown
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// referenceRegexp находит упоминания @login и ссылки на задачи #123,
+// не цепляя e-mail (user@host) и якоря внутри слов (a#1).
+var referenceRegexp = regexp.MustCompile(`(?:^|[^\w@#/])(@[A-Za-z0-9_.\-]*[A-Za-z0-9_]|#[0-9]+)\b`)
+
+var referencesContextKey = parser.NewContextKey()
+
+// References - упоминания, которые можно превратить в ссылки. Остальные остаются простым текстом.
+type References struct {
+	Logins  map[string]bool
+	TaskIDs map[int]bool
+}
+
+// ExtractReferences возвращает логины и номера задач, упомянутые в тексте вне блоков кода и ссылок.
+func ExtractReferences(source string) ([]string, []int) {
+	logins := make([]string, 0)
+	taskIDs := make([]int, 0)
+	seenLogins := make(map[string]bool)
+	seenTaskIDs := make(map[int]bool)
+
+	src := []byte(source)
+	document := renderer.Parser().Parse(text.NewReader(src))
+	walkReferenceTexts(document, func(textNode *ast.Text) {
+		segment := textNode.Segment
+		for _, match := range referenceRegexp.FindAllSubmatch(segment.Value(src), -1) {
+			login, taskID := parseReference(string(match[1]))
+			if login != "" && !seenLogins[login] {
+				seenLogins[login] = true
+				logins = append(logins, login)
+			}
+			if taskID != 0 && !seenTaskIDs[taskID] {
+				seenTaskIDs[taskID] = true
+				taskIDs = append(taskIDs, taskID)
+			}
+		}
+	})
+
+	return logins, taskIDs
+}
+
+type referencesTransformer struct{}
+
+func (r referencesTransformer) Transform(document *ast.Document, reader text.Reader, pc parser.Context) {
+	refs, ok := pc.Get(referencesContextKey).(References)
+	if !ok {
+		return
+	}
+
+	src := reader.Source()
+	textNodes := make([]*ast.Text, 0)
+	walkReferenceTexts(document, func(textNode *ast.Text) {
+		textNodes = append(textNodes, textNode)
+	})
+
+	for _, textNode := range textNodes {
+		linkReferences(textNode, src, refs)
+	}
+}
+
+// linkReferences разбивает текстовый узел на текст и ссылки на известные упоминания.
+func linkReferences(textNode *ast.Text, src []byte, refs References) {
+	segment := textNode.Segment
+	value := segment.Value(src)
+	matches := referenceRegexp.FindAllSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return
+	}
+
+	parent := textNode.Parent()
+	start := 0
+	for _, match := range matches {
+		reference := string(value[match[2]:match[3]])
+		destination := referenceDestination(reference, refs)
+		if destination == "" {
+			continue
+		}
+
+		if match[2] > start {
+			parent.InsertBefore(parent, textNode, ast.NewTextSegment(text.NewSegment(
+				segment.Start+start, segment.Start+match[2])))
+		}
+		link := ast.NewLink()
+		link.Destination = []byte(destination)
+		link.AppendChild(link, ast.NewTextSegment(text.NewSegment(segment.Start+match[2], segment.Start+match[3])))
+		parent.InsertBefore(parent, textNode, link)
+		start = match[3]
+	}
+
+	if start == 0 {
+		return
+	}
+	rest := ast.NewTextSegment(text.NewSegment(segment.Start+start, segment.Stop))
+	rest.SetSoftLineBreak(textNode.SoftLineBreak())
+	rest.SetHardLineBreak(textNode.HardLineBreak())
+	parent.ReplaceChild(parent, textNode, rest)
+}
+
+func referenceDestination(reference string, refs References) string {
+	login, taskID := parseReference(reference)
+	switch {
+	case login != "" && refs.Logins[login]:
+		return "/tasks/user/" + url.PathEscape(login)
+	case taskID != 0 && refs.TaskIDs[taskID]:
+		return fmt.Sprintf("/tasks/%d", taskID)
+	default:
+		return ""
+	}
+}
+
+func parseReference(reference string) (string, int) {
+	if reference[0] == '@' {
+		return reference[1:], 0
+	}
+
+	taskID, err := strconv.Atoi(reference[1:])
+	if err != nil {
+		return "", 0
+	}
+
+	return "", taskID
+}
+
+// walkReferenceTexts обходит текстовые узлы, в которых могут быть упоминания: код и ссылки пропускаются.
+func walkReferenceTexts(document ast.Node, fn func(textNode *ast.Text)) {
+	_ = ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.CodeSpan, *ast.Link, *ast.AutoLink, *ast.Image, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			mergeNextTexts(n)
+			fn(n)
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
+
+// mergeNextTexts склеивает соседние текстовые узлы: парсер режет текст на символах-разделителях,
+// например на "_" в логине ivan_petrov.
+func mergeNextTexts(textNode *ast.Text) {
+	for !textNode.SoftLineBreak() && !textNode.HardLineBreak() {
+		next, ok := textNode.NextSibling().(*ast.Text)
+		if !ok || next.Segment.Start != textNode.Segment.Stop {
+			return
+		}
+
+		textNode.Segment = textNode.Segment.WithStop(next.Segment.Stop)
+		textNode.SetSoftLineBreak(next.SoftLineBreak())
+		textNode.SetHardLineBreak(next.HardLineBreak())
+		textNode.Parent().RemoveChild(textNode.Parent(), next)
+	}
+}
+
+func newReferencesExtension() util.PrioritizedValue {
+	return util.Prioritized(referencesTransformer{}, 1000)
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// collectTimeout ограничивает запрос к базе при сборе метрик, чтобы зависшая база не держала скрейп.
+const collectTimeout = 5 * time.Second
+
+// TaskCollector отдаёт число задач в каждом статусе и приоритете. Значения считаются в базе при каждом скрейпе,
+// поэтому всегда совпадают с данными, а не с тем, что видел этот экземпляр приложения.
+type TaskCollector struct {
+	reportRepository repository.IReportRepo
+	tasks            *prometheus.Desc
+}
+
+func NewTaskCollector(reportRepository repository.IReportRepo) *TaskCollector {
+	return &TaskCollector{
+		reportRepository: reportRepository,
+		tasks: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "tasks"),
+			"Number of tasks by status and priority.",
+			[]string{"status", "priority"},
+			nil,
+		),
+	}
+}
+
+func (t *TaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- t.tasks
+}
+
+func (t *TaskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	byPriority, err := t.reportRepository.CountByPriority(ctx, 0)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(t.tasks, err)
+		return
+	}
+
+	for _, counts := range byPriority {
+		priority := strconv.Itoa(counts.Priority)
+		statusCounts := map[string]int{
+			constant.OpenTaskStatus:       counts.Open,
+			constant.InProgressTaskStatus: counts.InProgress,
+			constant.DoneTaskStatus:       counts.Done,
+		}
+		for _, status := range constant.TaskStatuses {
+			ch <- prometheus.MustNewConstMetric(
+				t.tasks, prometheus.GaugeValue, float64(statusCounts[status]), status, priority,
+			)
+		}
+	}
+}
+
+// DBPoolCollector отдаёт состояние пула соединений с базой из pgxpool.Pool.Stat.
+type DBPoolCollector struct {
+	dbPool *pgxpool.Pool
+
+	acquiredConns       *prometheus.Desc
+	idleConns           *prometheus.Desc
+	constructingConns   *prometheus.Desc
+	totalConns          *prometheus.Desc
+	maxConns            *prometheus.Desc
+	acquires            *prometheus.Desc
+	acquireDuration     *prometheus.Desc
+	canceledAcquires    *prometheus.Desc
+	emptyAcquires       *prometheus.Desc
+	newConns            *prometheus.Desc
+	maxLifetimeDestroys *prometheus.Desc
+	maxIdleDestroys     *prometheus.Desc
+}
+
+func NewDBPoolCollector(dbPool *pgxpool.Pool) *DBPoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &DBPoolCollector{
+		dbPool:              dbPool,
+		acquiredConns:       desc("acquired_connections", "Number of currently acquired connections."),
+		idleConns:           desc("idle_connections", "Number of currently idle connections."),
+		constructingConns:   desc("constructing_connections", "Number of connections being established."),
+		totalConns:          desc("total_connections", "Total number of connections in the pool."),
+		maxConns:            desc("max_connections", "Maximum size of the pool."),
+		acquires:            desc("acquires_total", "Number of successful acquires from the pool."),
+		acquireDuration:     desc("acquire_duration_seconds_total", "Total time spent on successful acquires."),
+		canceledAcquires:    desc("canceled_acquires_total", "Number of acquires canceled by a context."),
+		emptyAcquires:       desc("empty_acquires_total", "Number of acquires that waited for a connection."),
+		newConns:            desc("new_connections_total", "Number of new connections opened."),
+		maxLifetimeDestroys: desc("max_lifetime_destroys_total", "Number of connections closed by max lifetime."),
+		maxIdleDestroys:     desc("max_idle_destroys_total", "Number of connections closed by max idle time."),
+	}
+}
+
+func (d *DBPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(d, ch)
+}
+
+func (d *DBPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := d.dbPool.Stat()
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(d.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(d.idleConns, float64(stat.IdleConns()))
+	gauge(d.constructingConns, float64(stat.ConstructingConns()))
+	gauge(d.totalConns, float64(stat.TotalConns()))
+	gauge(d.maxConns, float64(stat.MaxConns()))
+	counter(d.acquires, float64(stat.AcquireCount()))
+	counter(d.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(d.canceledAcquires, float64(stat.CanceledAcquireCount()))
+	counter(d.emptyAcquires, float64(stat.EmptyAcquireCount()))
+	counter(d.newConns, float64(stat.NewConnsCount()))
+	counter(d.maxLifetimeDestroys, float64(stat.MaxLifetimeDestroyCount()))
+	counter(d.maxIdleDestroys, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "task_manager"
+
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// unmatchedRoute - метка route запросов, для которых не нашёлся маршрут. Путь запроса в метку не попадает,
+// чтобы случайные адреса не порождали новые ряды.
+const unmatchedRoute = "unmatched"
+
+var (
+	// TasksCreated - сколько задач создано.
+	TasksCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_created_total",
+		Help:      "Number of created tasks.",
+	})
+	// TasksCompleted - сколько раз задачи переводились в DONE.
+	TasksCompleted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_completed_total",
+		Help:      "Number of task transitions to DONE.",
+	})
+	// Logins - попытки входа по результату: LoginSuccess или LoginFailure.
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+	// HTTPRequestDuration - время обработки HTTP-запросов по маршруту, методу и коду ответа.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+// Middleware замеряет время обработки каждого запроса. Маршрут берётся шаблоном (/tasks/:id), а не путём запроса.
+func Middleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	HTTPRequestDuration.
+		WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).
+		Observe(time.Since(start).Seconds())
+}
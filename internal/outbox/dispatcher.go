@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// maxErrorLength - размер колонки outbox.last_error.
+const maxErrorLength = 500
+
+// Handler - обработчик событий outbox внутри процесса. Name должен быть уникальным и не меняться между
+// версиями: по нему запоминается, какие события обработчик уже обработал.
+type Handler interface {
+	Name() string
+	Handle(ctx context.Context, event *repository.OutboxEvent) error
+}
+
+// Dispatcher передаёт сохранённые события зарегистрированным обработчикам. Событие доставляется хотя бы один
+// раз: пока хотя бы один обработчик возвращает ошибку, оно повторяется, а обработчики, уже обработавшие его,
+// повторно не вызываются. События одной задачи обрабатываются строго по порядку.
+type Dispatcher struct {
+	outboxRepository repository.IOutboxRepo
+	handlers         []Handler
+	cfg              *config.Outbox
+}
+
+func NewDispatcher(outboxRepository repository.IOutboxRepo, cfg *config.Outbox, handlers ...Handler) *Dispatcher {
+	return &Dispatcher{
+		outboxRepository: outboxRepository,
+		handlers:         handlers,
+		cfg:              cfg,
+	}
+}
+
+// Dispatch обрабатывает одну пачку неопубликованных событий. Если событие задачи не обработано,
+// следующие события этой задачи ждут его повтора. После maxAttempts неудачных попыток событие
+// помечается FAILED и больше не задерживает остальные.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	events, err := d.outboxRepository.GetPending(ctx, d.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	blockedAggregates := make(map[int]bool)
+	for i := range events {
+		event := &events[i]
+		if blockedAggregates[event.AggregateID] {
+			continue
+		}
+
+		if handleErr := d.publish(ctx, event); handleErr != nil {
+			event.Attempts++
+			event.LastError = truncateError(handleErr.Error())
+			if event.Attempts >= d.cfg.MaxAttempts {
+				event.Status = constant.FailedOutboxStatus
+				slog.Error("cannot publish outbox event",
+					slog.Int("eventId", event.ID),
+					slog.String("event", event.Event),
+					slog.Int("attempts", event.Attempts),
+					slog.Any("error", handleErr),
+				)
+			} else {
+				blockedAggregates[event.AggregateID] = true
+			}
+		} else {
+			event.Status = constant.PublishedOutboxStatus
+			event.LastError = ""
+		}
+
+		if err = d.outboxRepository.Update(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run обрабатывает события каждые pollInterval, пока не отменён ctx.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Dispatch(ctx); err != nil {
+			slog.Error("cannot dispatch outbox events", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) publish(ctx context.Context, event *repository.OutboxEvent) error {
+	for _, handler := range d.handlers {
+		processed, err := d.outboxRepository.IsProcessed(ctx, handler.Name(), event.ID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			continue
+		}
+
+		if err = handler.Handle(ctx, event); err != nil {
+			return err
+		}
+		if err = d.outboxRepository.MarkProcessed(ctx, handler.Name(), event.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func truncateError(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxErrorLength {
+		return s
+	}
+
+	return string(runes[:maxErrorLength])
+}
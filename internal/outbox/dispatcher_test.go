@@ -0,0 +1,143 @@
+//go:build unit && !integration
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var outboxConfig = &config.Outbox{BatchSize: 10, MaxAttempts: 3}
+
+type testHandler struct {
+	name    string
+	handled []int
+	failFor map[int]error
+}
+
+func (h *testHandler) Name() string {
+	return h.name
+}
+
+func (h *testHandler) Handle(_ context.Context, event *repository.OutboxEvent) error {
+	if err := h.failFor[event.ID]; err != nil {
+		return err
+	}
+	h.handled = append(h.handled, event.ID)
+
+	return nil
+}
+
+func TestDispatcher_Dispatch_EventsPublished(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	handler := &testHandler{name: "test"}
+	dispatcher := NewDispatcher(outboxRepo, outboxConfig, handler)
+
+	outboxRepo.EXPECT().GetPending(gomock.Any(), 10).Return([]repository.OutboxEvent{
+		{ID: 1, AggregateID: 5, Status: constant.PendingOutboxStatus},
+		{ID: 2, AggregateID: 5, Status: constant.PendingOutboxStatus},
+	}, nil)
+	outboxRepo.EXPECT().IsProcessed(gomock.Any(), "test", gomock.Any()).Return(false, nil).Times(2)
+	outboxRepo.EXPECT().MarkProcessed(gomock.Any(), "test", gomock.Any()).Return(nil).Times(2)
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.PublishedOutboxStatus, event.Status)
+			return nil
+		}).Times(2)
+
+	err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, handler.handled)
+}
+
+func TestDispatcher_Dispatch_FailedEventBlocksTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	handler := &testHandler{name: "test", failFor: map[int]error{1: errors.New("smtp unavailable")}}
+	dispatcher := NewDispatcher(outboxRepo, outboxConfig, handler)
+
+	outboxRepo.EXPECT().GetPending(gomock.Any(), 10).Return([]repository.OutboxEvent{
+		{ID: 1, AggregateID: 5, Status: constant.PendingOutboxStatus},
+		{ID: 2, AggregateID: 5, Status: constant.PendingOutboxStatus},
+		{ID: 3, AggregateID: 6, Status: constant.PendingOutboxStatus},
+	}, nil)
+	outboxRepo.EXPECT().IsProcessed(gomock.Any(), "test", gomock.Any()).Return(false, nil).Times(2)
+	outboxRepo.EXPECT().MarkProcessed(gomock.Any(), "test", 3).Return(nil)
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.PendingOutboxStatus, event.Status)
+			require.Equal(t, 1, event.Attempts)
+			require.Equal(t, "smtp unavailable", event.LastError)
+			return nil
+		})
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, 3, event.ID)
+			require.Equal(t, constant.PublishedOutboxStatus, event.Status)
+			return nil
+		})
+
+	err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, handler.handled)
+}
+
+func TestDispatcher_Dispatch_FailedAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	handler := &testHandler{name: "test", failFor: map[int]error{1: errors.New("smtp unavailable")}}
+	dispatcher := NewDispatcher(outboxRepo, outboxConfig, handler)
+
+	outboxRepo.EXPECT().GetPending(gomock.Any(), 10).Return([]repository.OutboxEvent{
+		{ID: 1, AggregateID: 5, Status: constant.PendingOutboxStatus, Attempts: 2},
+		{ID: 2, AggregateID: 5, Status: constant.PendingOutboxStatus},
+	}, nil)
+	outboxRepo.EXPECT().IsProcessed(gomock.Any(), "test", gomock.Any()).Return(false, nil).Times(2)
+	outboxRepo.EXPECT().MarkProcessed(gomock.Any(), "test", 2).Return(nil)
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.FailedOutboxStatus, event.Status)
+			require.Equal(t, 3, event.Attempts)
+			return nil
+		})
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	// событие, исчерпавшее попытки, больше не задерживает следующие события задачи.
+	require.Equal(t, []int{2}, handler.handled)
+}
+
+func TestDispatcher_Dispatch_ProcessedHandlerSkipped(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	processedHandler := &testHandler{name: "processed"}
+	pendingHandler := &testHandler{name: "pending"}
+	dispatcher := NewDispatcher(outboxRepo, outboxConfig, processedHandler, pendingHandler)
+
+	outboxRepo.EXPECT().GetPending(gomock.Any(), 10).Return([]repository.OutboxEvent{
+		{ID: 1, AggregateID: 5, Status: constant.PendingOutboxStatus, Attempts: 1},
+	}, nil)
+	outboxRepo.EXPECT().IsProcessed(gomock.Any(), "processed", 1).Return(true, nil)
+	outboxRepo.EXPECT().IsProcessed(gomock.Any(), "pending", 1).Return(false, nil)
+	outboxRepo.EXPECT().MarkProcessed(gomock.Any(), "pending", 1).Return(nil)
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := dispatcher.Dispatch(ctx)
+	require.NoError(t, err)
+	require.Empty(t, processedHandler.handled)
+	require.Equal(t, []int{1}, pendingHandler.handled)
+}
@@ -0,0 +1,118 @@
+package repository
+
+//go:generate mockgen -source=attachment_repository.go -destination=mocks/attachment_repository_mocks.go
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const AttachmentsTableName = "task_attachments"
+
+type Attachment struct {
+	ID          int       `db:"id" json:"id"`
+	TaskID      int       `db:"task_id" json:"taskId"`
+	UserID      int       `db:"user_id" json:"userId"`
+	FileName    string    `db:"file_name" json:"fileName"`
+	ContentType string    `db:"content_type" json:"contentType"`
+	Size        int64     `db:"size" json:"size"`
+	StorageKey  string    `db:"storage_key" json:"-"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+var AttachmentStruct = sqlbuilder.NewStruct(new(Attachment))
+
+type IAttachmentRepo interface {
+	NextID(ctx context.Context) (int, error)
+	Create(ctx context.Context, attachment *Attachment) error
+	GetByID(ctx context.Context, attachmentID int) (*Attachment, error)
+	GetByTaskID(ctx context.Context, taskID int) ([]Attachment, error)
+	DeleteByID(ctx context.Context, attachmentID int) error
+}
+
+type AttachmentRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewAttachmentRepo(dbPool *pgxpool.Pool) *AttachmentRepo {
+	return &AttachmentRepo{dbPool: dbPool}
+}
+
+// NextID резервирует идентификатор заранее: он нужен для ключа в хранилище до записи метаданных.
+func (a *AttachmentRepo) NextID(ctx context.Context) (int, error) {
+	rows, err := a.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "task_attachments_sequence"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next attachment id")
+}
+
+func (a *AttachmentRepo) Create(ctx context.Context, attachment *Attachment) error {
+	attachment.CreatedAt = time.Now()
+	sql, args := AttachmentStruct.InsertInto(AttachmentsTableName, attachment).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := a.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (a *AttachmentRepo) GetByID(ctx context.Context, attachmentID int) (*Attachment, error) {
+	sb := AttachmentStruct.SelectFrom(AttachmentsTableName)
+	sql, args := sb.Where(sb.Equal("id", attachmentID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+	row := a.dbPool.QueryRow(ctx, sql, args...)
+
+	var attachment Attachment
+	if err := row.Scan(AttachmentStruct.Addr(&attachment)...); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (a *AttachmentRepo) GetByTaskID(ctx context.Context, taskID int) ([]Attachment, error) {
+	sb := AttachmentStruct.SelectFrom(AttachmentsTableName)
+	sql, args := sb.Where(sb.Equal("task_id", taskID)).
+		OrderBy("id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := a.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Attachment, 0)
+	for rows.Next() {
+		var attachment Attachment
+		if rowScanErr := rows.Scan(AttachmentStruct.Addr(&attachment)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, attachment)
+	}
+
+	return res, rows.Err()
+}
+
+func (a *AttachmentRepo) DeleteByID(ctx context.Context, attachmentID int) error {
+	db := AttachmentStruct.DeleteFrom(AttachmentsTableName)
+	sql, args := db.Where(db.Equal("id", attachmentID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := a.dbPool.Exec(ctx, sql, args...)
+	return err
+}
@@ -0,0 +1,209 @@
+package repository
+
+//go:generate mockgen -source=backup_repository.go -destination=mocks/backup_repository_mocks.go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BackupTables - таблицы с данными приложения в порядке зависимостей: каждая таблица ссылается только на
+// таблицы, стоящие перед ней. В этом порядке данные загружаются при восстановлении.
+var BackupTables = []string{
+	UsersTableName,
+	TasksTableName,
+	ChecklistItemsTableName,
+	TaskHistoryTableName,
+	AttachmentsTableName,
+	TaskMentionsTableName,
+	TaskReferencesTableName,
+	CommentsTableName,
+	LabelsTableName,
+	WatchersTableName,
+	NotificationsTableName,
+	NotificationPreferencesTableName,
+	CalendarFeedsTableName,
+	WebhooksTableName,
+	WebhookDeliveriesTableName,
+	OutboxTableName,
+	OutboxProcessedTableName,
+	TaskTemplatesTableName,
+	SavedFiltersTableName,
+	SavedFilterSettingsTableName,
+	TaskStatusHistoryTableName,
+	TaskStatusSnapshotsTableName,
+	TaskDueRemindersTableName,
+}
+
+// BackupSequences - последовательности, из которых выдаются идентификаторы сущностей.
+var BackupSequences = []string{
+	"users_sequence",
+	"tasks_sequence",
+	"task_checklist_items_sequence",
+	"task_history_sequence",
+	"task_attachments_sequence",
+	"task_comments_sequence",
+	"notifications_sequence",
+	"webhooks_sequence",
+	"webhook_deliveries_sequence",
+	"outbox_sequence",
+	"task_templates_sequence",
+	"saved_filters_sequence",
+}
+
+// SequenceValue - состояние последовательности, как его возвращает и принимает setval.
+type SequenceValue struct {
+	LastValue int64
+	IsCalled  bool
+}
+
+// IBackupRepo читает и загружает таблицы целиком. Имена таблиц и последовательностей подставляются в запросы,
+// поэтому передаются только значения из BackupTables и BackupSequences.
+type IBackupRepo interface {
+	WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error
+	GetSchemaVersion(ctx context.Context) (int64, error)
+	GetColumns(ctx context.Context, table string) ([]string, error)
+	IterateRows(ctx context.Context, table string, fn func(row json.RawMessage) error) error
+	InsertRows(ctx context.Context, table string, columns []string, rows []json.RawMessage) error
+	IsEmpty(ctx context.Context, table string, ignoredIDs []int) (bool, error)
+	DeleteAll(ctx context.Context, table string) error
+	GetSequence(ctx context.Context, name string) (*SequenceValue, error)
+	SetSequence(ctx context.Context, name string, value *SequenceValue) error
+}
+
+type BackupRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewBackupRepo(dbPool *pgxpool.Pool) *BackupRepo {
+	return &BackupRepo{dbPool: dbPool}
+}
+
+// WithinSnapshot открывает транзакцию только для чтения с уровнем изоляции REPEATABLE READ и передаёт её в fn
+// через контекст, как WithinTx. Все таблицы читаются из одного снимка базы и согласованы между собой.
+func (b *BackupRepo) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	return pgx.BeginTxFunc(ctx, b.dbPool, txOptions, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// GetSchemaVersion возвращает версию последней применённой миграции goose.
+func (b *BackupRepo) GetSchemaVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := conn(ctx, b.dbPool).QueryRow(ctx,
+		`SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`,
+	).Scan(&version)
+
+	return version, err
+}
+
+// GetColumns возвращает столбцы таблицы в порядке их объявления.
+func (b *BackupRepo) GetColumns(ctx context.Context, table string) ([]string, error) {
+	rows, err := conn(ctx, b.dbPool).Query(ctx,
+		`SELECT column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position`,
+		table,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// IterateRows передаёт в fn строки таблицы объектами JSON по одной, не загружая таблицу в память.
+func (b *BackupRepo) IterateRows(ctx context.Context, table string, fn func(row json.RawMessage) error) error {
+	rows, err := conn(ctx, b.dbPool).Query(ctx, fmt.Sprintf("SELECT row_to_json(t) FROM %s t", quote(table)))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row json.RawMessage
+		if rowScanErr := rows.Scan(&row); rowScanErr != nil {
+			return rowScanErr
+		}
+		if fnErr := fn(row); fnErr != nil {
+			return fnErr
+		}
+	}
+
+	return rows.Err()
+}
+
+// InsertRows вставляет строки-объекты JSON одним запросом. Заполняются только столбцы columns, остальные получают
+// значения по умолчанию, поэтому копию, сделанную до добавления столбца, можно загрузить в новую схему.
+func (b *BackupRepo) InsertRows(ctx context.Context, table string, columns []string, rows []json.RawMessage) error {
+	quotedColumns := make([]string, 0, len(columns))
+	for _, column := range columns {
+		quotedColumns = append(quotedColumns, quote(column))
+	}
+	columnList := strings.Join(quotedColumns, ", ")
+
+	records, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, b.dbPool).Exec(ctx,
+		fmt.Sprintf("INSERT INTO %[1]s (%[2]s) SELECT %[2]s FROM json_populate_recordset(NULL::%[1]s, $1)",
+			quote(table), columnList),
+		string(records),
+	)
+	return err
+}
+
+// IsEmpty сообщает, что в таблице нет строк, кроме строк с идентификаторами ignoredIDs.
+func (b *BackupRepo) IsEmpty(ctx context.Context, table string, ignoredIDs []int) (bool, error) {
+	var exists bool
+	var err error
+	if len(ignoredIDs) == 0 {
+		err = conn(ctx, b.dbPool).QueryRow(ctx,
+			fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", quote(table)),
+		).Scan(&exists)
+	} else {
+		err = conn(ctx, b.dbPool).QueryRow(ctx,
+			fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE NOT id = ANY($1))", quote(table)),
+			ignoredIDs,
+		).Scan(&exists)
+	}
+
+	return !exists, err
+}
+
+func (b *BackupRepo) DeleteAll(ctx context.Context, table string) error {
+	_, err := conn(ctx, b.dbPool).Exec(ctx, fmt.Sprintf("DELETE FROM %s", quote(table)))
+	return err
+}
+
+func (b *BackupRepo) GetSequence(ctx context.Context, name string) (*SequenceValue, error) {
+	var value SequenceValue
+	err := conn(ctx, b.dbPool).QueryRow(ctx,
+		fmt.Sprintf("SELECT last_value, is_called FROM %s", quote(name)),
+	).Scan(&value.LastValue, &value.IsCalled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+func (b *BackupRepo) SetSequence(ctx context.Context, name string, value *SequenceValue) error {
+	_, err := conn(ctx, b.dbPool).Exec(ctx,
+		"SELECT setval($1::text::regclass, $2, $3)",
+		name, value.LastValue, value.IsCalled,
+	)
+	return err
+}
+
+// quote экранирует имя таблицы, столбца или последовательности для подстановки в запрос.
+func quote(identifier string) string {
+	return pgx.Identifier{identifier}.Sanitize()
+}
@@ -0,0 +1,74 @@
+package repository
+
+//go:generate mockgen -source=calendar_repository.go -destination=mocks/calendar_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const CalendarFeedsTableName = "calendar_feeds"
+
+// CalendarFeed - календарь пользователя, доступный по секретному токену без входа в систему.
+type CalendarFeed struct {
+	UserID int    `db:"user_id" json:"userId"`
+	Token  string `db:"token" json:"token"`
+	// AllTasks - в календарь попадают задачи всех пользователей, только для ADMIN.
+	AllTasks  bool      `db:"all_tasks" json:"allTasks"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+var CalendarFeedStruct = sqlbuilder.NewStruct(new(CalendarFeed))
+
+type ICalendarRepo interface {
+	Save(ctx context.Context, feed *CalendarFeed) error
+	GetByUserID(ctx context.Context, userID int) (*CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*CalendarFeed, error)
+}
+
+type CalendarRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewCalendarRepo(dbPool *pgxpool.Pool) *CalendarRepo {
+	return &CalendarRepo{dbPool: dbPool}
+}
+
+// Save создаёт календарь пользователя или заменяет его токен и настройки; прежний токен перестаёт действовать.
+func (c *CalendarRepo) Save(ctx context.Context, feed *CalendarFeed) error {
+	_, err := c.dbPool.Exec(ctx,
+		`INSERT INTO calendar_feeds (user_id, token, all_tasks, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token = EXCLUDED.token, all_tasks = EXCLUDED.all_tasks, created_at = EXCLUDED.created_at`,
+		feed.UserID, feed.Token, feed.AllTasks, feed.CreatedAt,
+	)
+	return err
+}
+
+func (c *CalendarRepo) GetByUserID(ctx context.Context, userID int) (*CalendarFeed, error) {
+	sb := CalendarFeedStruct.SelectFrom(CalendarFeedsTableName)
+	sql, args := sb.Where(sb.Equal("user_id", userID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return c.get(ctx, sql, args)
+}
+
+func (c *CalendarRepo) GetByToken(ctx context.Context, token string) (*CalendarFeed, error) {
+	sb := CalendarFeedStruct.SelectFrom(CalendarFeedsTableName)
+	sql, args := sb.Where(sb.Equal("token", token)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return c.get(ctx, sql, args)
+}
+
+func (c *CalendarRepo) get(ctx context.Context, sql string, args []interface{}) (*CalendarFeed, error) {
+	var feed CalendarFeed
+	if err := c.dbPool.QueryRow(ctx, sql, args...).Scan(CalendarFeedStruct.Addr(&feed)...); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+//go:generate mockgen -source=checklist_repository.go -destination=mocks/checklist_repository_mocks.go
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	ChecklistItemsTableName = "task_checklist_items"
+	// maxChecklistCreateAttempts - сколько раз Create пробует занять позицию в конце чеклиста.
+	maxChecklistCreateAttempts = 3
+)
+
+type ChecklistItem struct {
+	ID        int       `db:"id" json:"id"`
+	TaskID    int       `db:"task_id" json:"taskId"`
+	Title     string    `db:"title" json:"title"`
+	Done      bool      `db:"done" json:"done"`
+	Position  int       `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type ChecklistProgress struct {
+	TaskID int `db:"task_id" json:"taskId"`
+	Done   int `db:"done" json:"done"`
+	Total  int `db:"total" json:"total"`
+}
+
+var (
+	ChecklistItemStruct     = sqlbuilder.NewStruct(new(ChecklistItem))
+	ChecklistProgressStruct = sqlbuilder.NewStruct(new(ChecklistProgress))
+)
+
+type IChecklistRepo interface {
+	Create(ctx context.Context, item *ChecklistItem) (int, error)
+	GetByID(ctx context.Context, itemID int) (*ChecklistItem, error)
+	GetByTaskID(ctx context.Context, taskID int) ([]ChecklistItem, error)
+	GetProgressByTaskIDs(ctx context.Context, taskIDs []int) (map[int]ChecklistProgress, error)
+	SetDone(ctx context.Context, itemID int, done bool) error
+	Move(ctx context.Context, taskID, itemID, position int) error
+	DeleteByID(ctx context.Context, itemID int) error
+}
+
+type ChecklistRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewChecklistRepo(dbPool *pgxpool.Pool) *ChecklistRepo {
+	return &ChecklistRepo{dbPool: dbPool}
+}
+
+// Create добавляет пункт в конец чеклиста. Поддерживает транзакцию из контекста.
+// Позиция вычисляется в том же запросе, что и вставка, а уникальность позиции в задаче проверяет база: если
+// параллельно добавленный пункт занял ту же позицию, вставка повторяется.
+func (c *ChecklistRepo) Create(ctx context.Context, item *ChecklistItem) (int, error) {
+	ID, err := c.generateNextChecklistItemID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	item.ID = ID
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	for attempt := 1; ; attempt++ {
+		err = conn(ctx, c.dbPool).QueryRow(ctx,
+			`INSERT INTO task_checklist_items (id, task_id, title, done, position, created_at, updated_at)
+			SELECT $1, $2, $3, $4, COALESCE(MAX(position), 0) + 1, $5, $6 FROM task_checklist_items WHERE task_id = $2
+			RETURNING position`,
+			item.ID, item.TaskID, item.Title, item.Done, item.CreatedAt, item.UpdatedAt,
+		).Scan(&item.Position)
+		if err == nil || !isUniqueViolation(err) || attempt == maxChecklistCreateAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return item.ID, nil
+}
+
+func (c *ChecklistRepo) GetByID(ctx context.Context, itemID int) (*ChecklistItem, error) {
+	sb := ChecklistItemStruct.SelectFrom(ChecklistItemsTableName)
+	sql, args := sb.Where(sb.Equal("id", itemID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+	row := c.dbPool.QueryRow(ctx, sql, args...)
+
+	var item ChecklistItem
+	if err := row.Scan(ChecklistItemStruct.Addr(&item)...); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (c *ChecklistRepo) GetByTaskID(ctx context.Context, taskID int) ([]ChecklistItem, error) {
+	sb := ChecklistItemStruct.SelectFrom(ChecklistItemsTableName)
+	sql, args := sb.Where(sb.Equal("task_id", taskID)).
+		OrderBy("position", "id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := c.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]ChecklistItem, 0)
+	for rows.Next() {
+		var item ChecklistItem
+		if rowScanErr := rows.Scan(ChecklistItemStruct.Addr(&item)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, item)
+	}
+
+	return res, rows.Err()
+}
+
+func (c *ChecklistRepo) GetProgressByTaskIDs(ctx context.Context, taskIDs []int) (map[int]ChecklistProgress, error) {
+	res := make(map[int]ChecklistProgress, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return res, nil
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("task_id", "COUNT(*) FILTER (WHERE done) AS done", "COUNT(*) AS total").
+		From(ChecklistItemsTableName).
+		Where(sb.In("task_id", sqlbuilder.Flatten(taskIDs)...)).
+		GroupBy("task_id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := c.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var progress ChecklistProgress
+		if rowScanErr := rows.Scan(ChecklistProgressStruct.Addr(&progress)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res[progress.TaskID] = progress
+	}
+
+	return res, rows.Err()
+}
+
+func (c *ChecklistRepo) SetDone(ctx context.Context, itemID int, done bool) error {
+	ub := sqlbuilder.Update(ChecklistItemsTableName)
+	sql, args := ub.Where(ub.Equal("id", itemID)).
+		Set(
+			ub.Assign("done", done),
+			ub.Assign("updated_at", time.Now()),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := c.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// Move ставит пункт на позицию position (начиная с 1) и перенумеровывает остальные пункты задачи.
+func (c *ChecklistRepo) Move(ctx context.Context, taskID, itemID, position int) error {
+	tx, err := c.dbPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx,
+		"SELECT id FROM task_checklist_items WHERE task_id = $1 ORDER BY position, id FOR UPDATE", taskID)
+	if err != nil {
+		return err
+	}
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if rowScanErr := rows.Scan(&id); rowScanErr != nil {
+			rows.Close()
+			return rowScanErr
+		}
+		if id != itemID {
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return rowsErr
+	}
+
+	index := min(max(position-1, 0), len(ids))
+	ids = append(ids[:index], append([]int{itemID}, ids[index:]...)...)
+
+	// позиции меняются одним запросом: уникальность позиции проверяется в конце запроса, а не после каждой строки.
+	if _, execErr := tx.Exec(ctx,
+		"UPDATE task_checklist_items SET position = array_position($1::BIGINT[], id), updated_at = $2 "+
+			"WHERE id = ANY($1::BIGINT[])", ids, time.Now(),
+	); execErr != nil {
+		return execErr
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (c *ChecklistRepo) DeleteByID(ctx context.Context, itemID int) error {
+	db := ChecklistItemStruct.DeleteFrom(ChecklistItemsTableName)
+	sql, args := db.Where(db.Equal("id", itemID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := c.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (c *ChecklistRepo) generateNextChecklistItemID(ctx context.Context) (int, error) {
+	rows, err := conn(ctx, c.dbPool).Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "task_checklist_items_sequence"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next checklist item id")
+}
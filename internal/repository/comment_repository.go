@@ -0,0 +1,89 @@
+package repository
+
+//go:generate mockgen -source=comment_repository.go -destination=mocks/comment_repository_mocks.go
+
+import (
+	"context"
+	"html/template"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const CommentsTableName = "task_comments"
+
+type Comment struct {
+	ID        int       `db:"id" json:"id"`
+	TaskID    int       `db:"task_id" json:"taskId"`
+	UserID    int       `db:"user_id" json:"userId"`
+	Body      string    `db:"body" json:"body"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+
+	UserLogin string `db:"-" json:"userLogin"`
+	// BodyHTML - текст комментария, отрендеренный из Markdown со ссылками на упомянутых пользователей и задачи.
+	BodyHTML template.HTML `db:"-" json:"bodyHtml" swaggertype:"string"`
+}
+
+var CommentStruct = sqlbuilder.NewStruct(new(Comment))
+
+type ICommentRepo interface {
+	Create(ctx context.Context, comment *Comment) error
+	GetByTaskID(ctx context.Context, taskID int) ([]Comment, error)
+}
+
+type CommentRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewCommentRepo(dbPool *pgxpool.Pool) *CommentRepo {
+	return &CommentRepo{dbPool: dbPool}
+}
+
+func (c *CommentRepo) Create(ctx context.Context, comment *Comment) error {
+	var ID int
+	if err := c.dbPool.QueryRow(ctx, "SELECT nextval('task_comments_sequence')").Scan(&ID); err != nil {
+		return err
+	}
+
+	comment.ID = ID
+	comment.CreatedAt = time.Now()
+	sql, args := CommentStruct.InsertInto(CommentsTableName, comment).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := c.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetByTaskID возвращает комментарии задачи с логинами авторов в порядке добавления.
+func (c *CommentRepo) GetByTaskID(ctx context.Context, taskID int) ([]Comment, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select(
+		"task_comments.id", "task_comments.task_id", "task_comments.user_id", "task_comments.body",
+		"task_comments.created_at", "users.login",
+	).
+		From(CommentsTableName).
+		Join("users", "task_comments.user_id = users.id").
+		Where(sb.Equal("task_comments.task_id", taskID)).
+		OrderBy("task_comments.id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := c.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Comment, 0)
+	for rows.Next() {
+		var comment Comment
+		rowScanErr := rows.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Body, &comment.CreatedAt,
+			&comment.UserLogin)
+		if rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, comment)
+	}
+
+	return res, rows.Err()
+}
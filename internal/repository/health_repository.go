@@ -0,0 +1,86 @@
+package repository
+
+//go:generate mockgen -source=health_repository.go -destination=mocks/health_repository_mocks.go
+
+import (
+	"context"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolStat - состояние пула соединений с базой.
+type PoolStat struct {
+	TotalConns    int32 `json:"totalConns" example:"4"`
+	IdleConns     int32 `json:"idleConns" example:"3"`
+	AcquiredConns int32 `json:"acquiredConns" example:"1"`
+	MaxConns      int32 `json:"maxConns" example:"10"`
+}
+
+type IHealthRepo interface {
+	Ping(ctx context.Context) error
+	GetMigrationVersion(ctx context.Context) (int64, error)
+	CountOutboxByStatus(ctx context.Context) (map[string]int, error)
+	GetPoolStat() *PoolStat
+}
+
+type HealthRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewHealthRepo(dbPool *pgxpool.Pool) *HealthRepo {
+	return &HealthRepo{dbPool: dbPool}
+}
+
+// Ping берёт соединение из пула и проверяет, что база отвечает.
+func (h *HealthRepo) Ping(ctx context.Context) error {
+	return h.dbPool.Ping(ctx)
+}
+
+// GetMigrationVersion возвращает версию последней применённой миграции goose.
+func (h *HealthRepo) GetMigrationVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := h.dbPool.QueryRow(ctx,
+		`SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`,
+	).Scan(&version)
+
+	return version, err
+}
+
+// CountOutboxByStatus возвращает число событий outbox в каждом статусе. Статусы без событий не возвращаются.
+func (h *HealthRepo) CountOutboxByStatus(ctx context.Context) (map[string]int, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("status", "COUNT(*)").
+		From(OutboxTableName).
+		GroupBy("status").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := h.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if rowScanErr := rows.Scan(&status, &count); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func (h *HealthRepo) GetPoolStat() *PoolStat {
+	stat := h.dbPool.Stat()
+
+	return &PoolStat{
+		TotalConns:    stat.TotalConns(),
+		IdleConns:     stat.IdleConns(),
+		AcquiredConns: stat.AcquiredConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+}
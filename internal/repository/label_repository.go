@@ -0,0 +1,36 @@
+package repository
+
+//go:generate mockgen -source=label_repository.go -destination=mocks/label_repository_mocks.go
+
+import (
+	"context"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const LabelsTableName = "task_labels"
+
+type ILabelRepo interface {
+	Add(ctx context.Context, taskID int, label string) error
+}
+
+type LabelRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewLabelRepo(dbPool *pgxpool.Pool) *LabelRepo {
+	return &LabelRepo{dbPool: dbPool}
+}
+
+// Add добавляет задаче метку. Повторное добавление той же метки ничего не меняет.
+func (l *LabelRepo) Add(ctx context.Context, taskID int, label string) error {
+	ib := sqlbuilder.InsertInto(LabelsTableName)
+	sql, args := ib.Cols("task_id", "label").
+		Values(taskID, label).
+		SQL("ON CONFLICT DO NOTHING").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := conn(ctx, l.dbPool).Exec(ctx, sql, args...)
+	return err
+}
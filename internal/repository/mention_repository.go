@@ -0,0 +1,133 @@
+package repository
+
+//go:generate mockgen -source=mention_repository.go -destination=mocks/mention_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	TaskMentionsTableName   = "task_mentions"
+	TaskReferencesTableName = "task_references"
+)
+
+// TaskBacklink - задача, в описании которой упомянута другая задача.
+type TaskBacklink struct {
+	ID    int    `db:"id" json:"id"`
+	Title string `db:"title" json:"title"`
+}
+
+var TaskBacklinkStruct = sqlbuilder.NewStruct(new(TaskBacklink))
+
+type IMentionRepo interface {
+	ReplaceForTask(ctx context.Context, taskID int, userIDs, referencedTaskIDs []int) ([]int, error)
+	GetBacklinks(ctx context.Context, taskID int) ([]TaskBacklink, error)
+}
+
+type MentionRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewMentionRepo(dbPool *pgxpool.Pool) *MentionRepo {
+	return &MentionRepo{dbPool: dbPool}
+}
+
+// ReplaceForTask заменяет упомянутых пользователей и задачи для задачи taskID
+// и возвращает пользователей, которые не были упомянуты в ней раньше.
+func (m *MentionRepo) ReplaceForTask(ctx context.Context,
+	taskID int,
+	userIDs, referencedTaskIDs []int,
+) ([]int, error) {
+	tx, err := m.dbPool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	rows, err := tx.Query(ctx, "SELECT user_id FROM task_mentions WHERE task_id = $1 FOR UPDATE", taskID)
+	if err != nil {
+		return nil, err
+	}
+	mentioned := make(map[int]bool)
+	for rows.Next() {
+		var userID int
+		if rowScanErr := rows.Scan(&userID); rowScanErr != nil {
+			rows.Close()
+			return nil, rowScanErr
+		}
+		mentioned[userID] = true
+	}
+	rows.Close()
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	if _, execErr := tx.Exec(ctx, "DELETE FROM task_mentions WHERE task_id = $1", taskID); execErr != nil {
+		return nil, execErr
+	}
+	if _, execErr := tx.Exec(ctx, "DELETE FROM task_references WHERE source_task_id = $1", taskID); execErr != nil {
+		return nil, execErr
+	}
+
+	now := time.Now()
+	newUserIDs := make([]int, 0)
+	if len(userIDs) > 0 {
+		ib := sqlbuilder.NewInsertBuilder().InsertInto(TaskMentionsTableName).Cols("task_id", "user_id", "created_at")
+		for _, userID := range userIDs {
+			ib.Values(taskID, userID, now)
+			if !mentioned[userID] {
+				newUserIDs = append(newUserIDs, userID)
+			}
+		}
+		sql, args := ib.BuildWithFlavor(sqlbuilder.PostgreSQL)
+		if _, execErr := tx.Exec(ctx, sql, args...); execErr != nil {
+			return nil, execErr
+		}
+	}
+
+	if len(referencedTaskIDs) > 0 {
+		ib := sqlbuilder.NewInsertBuilder().InsertInto(TaskReferencesTableName).
+			Cols("source_task_id", "target_task_id", "created_at")
+		for _, referencedTaskID := range referencedTaskIDs {
+			ib.Values(taskID, referencedTaskID, now)
+		}
+		sql, args := ib.BuildWithFlavor(sqlbuilder.PostgreSQL)
+		if _, execErr := tx.Exec(ctx, sql, args...); execErr != nil {
+			return nil, execErr
+		}
+	}
+
+	return newUserIDs, tx.Commit(ctx)
+}
+
+// GetBacklinks возвращает задачи, в описании которых упомянута задача taskID.
+func (m *MentionRepo) GetBacklinks(ctx context.Context, taskID int) ([]TaskBacklink, error) {
+	sb := TaskBacklinkStruct.SelectFrom(TasksTableName)
+	sql, args := sb.Join(TaskReferencesTableName, "task_references.source_task_id = tasks.id").
+		Where(sb.Equal("task_references.target_task_id", taskID)).
+		OrderBy("tasks.id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := m.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TaskBacklink, 0)
+	for rows.Next() {
+		var backlink TaskBacklink
+		if rowScanErr := rows.Scan(TaskBacklinkStruct.Addr(&backlink)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, backlink)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=attachment_repository.go -destination=mocks/attachment_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAttachmentRepo is a mock of IAttachmentRepo interface.
+type MockIAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAttachmentRepoMockRecorder
+}
+
+// MockIAttachmentRepoMockRecorder is the mock recorder for MockIAttachmentRepo.
+type MockIAttachmentRepoMockRecorder struct {
+	mock *MockIAttachmentRepo
+}
+
+// NewMockIAttachmentRepo creates a new mock instance.
+func NewMockIAttachmentRepo(ctrl *gomock.Controller) *MockIAttachmentRepo {
+	mock := &MockIAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockIAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAttachmentRepo) EXPECT() *MockIAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAttachmentRepo) Create(ctx context.Context, attachment *repository.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAttachmentRepoMockRecorder) Create(ctx, attachment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAttachmentRepo)(nil).Create), ctx, attachment)
+}
+
+// DeleteByID mocks base method.
+func (m *MockIAttachmentRepo) DeleteByID(ctx context.Context, attachmentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockIAttachmentRepoMockRecorder) DeleteByID(ctx, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockIAttachmentRepo)(nil).DeleteByID), ctx, attachmentID)
+}
+
+// GetByID mocks base method.
+func (m *MockIAttachmentRepo) GetByID(ctx context.Context, attachmentID int) (*repository.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, attachmentID)
+	ret0, _ := ret[0].(*repository.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIAttachmentRepoMockRecorder) GetByID(ctx, attachmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIAttachmentRepo)(nil).GetByID), ctx, attachmentID)
+}
+
+// GetByTaskID mocks base method.
+func (m *MockIAttachmentRepo) GetByTaskID(ctx context.Context, taskID int) ([]repository.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]repository.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTaskID indicates an expected call of GetByTaskID.
+func (mr *MockIAttachmentRepoMockRecorder) GetByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskID", reflect.TypeOf((*MockIAttachmentRepo)(nil).GetByTaskID), ctx, taskID)
+}
+
+// NextID mocks base method.
+func (m *MockIAttachmentRepo) NextID(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextID", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextID indicates an expected call of NextID.
+func (mr *MockIAttachmentRepoMockRecorder) NextID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextID", reflect.TypeOf((*MockIAttachmentRepo)(nil).NextID), ctx)
+}
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backup_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=backup_repository.go -destination=mocks/backup_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIBackupRepo is a mock of IBackupRepo interface.
+type MockIBackupRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBackupRepoMockRecorder
+}
+
+// MockIBackupRepoMockRecorder is the mock recorder for MockIBackupRepo.
+type MockIBackupRepoMockRecorder struct {
+	mock *MockIBackupRepo
+}
+
+// NewMockIBackupRepo creates a new mock instance.
+func NewMockIBackupRepo(ctrl *gomock.Controller) *MockIBackupRepo {
+	mock := &MockIBackupRepo{ctrl: ctrl}
+	mock.recorder = &MockIBackupRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBackupRepo) EXPECT() *MockIBackupRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteAll mocks base method.
+func (m *MockIBackupRepo) DeleteAll(ctx context.Context, table string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx, table)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockIBackupRepoMockRecorder) DeleteAll(ctx, table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockIBackupRepo)(nil).DeleteAll), ctx, table)
+}
+
+// GetColumns mocks base method.
+func (m *MockIBackupRepo) GetColumns(ctx context.Context, table string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumns", ctx, table)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumns indicates an expected call of GetColumns.
+func (mr *MockIBackupRepoMockRecorder) GetColumns(ctx, table any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumns", reflect.TypeOf((*MockIBackupRepo)(nil).GetColumns), ctx, table)
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockIBackupRepo) GetSchemaVersion(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockIBackupRepoMockRecorder) GetSchemaVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockIBackupRepo)(nil).GetSchemaVersion), ctx)
+}
+
+// GetSequence mocks base method.
+func (m *MockIBackupRepo) GetSequence(ctx context.Context, name string) (*repository.SequenceValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSequence", ctx, name)
+	ret0, _ := ret[0].(*repository.SequenceValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSequence indicates an expected call of GetSequence.
+func (mr *MockIBackupRepoMockRecorder) GetSequence(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSequence", reflect.TypeOf((*MockIBackupRepo)(nil).GetSequence), ctx, name)
+}
+
+// InsertRows mocks base method.
+func (m *MockIBackupRepo) InsertRows(ctx context.Context, table string, columns []string, rows []json.RawMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRows", ctx, table, columns, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRows indicates an expected call of InsertRows.
+func (mr *MockIBackupRepoMockRecorder) InsertRows(ctx, table, columns, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRows", reflect.TypeOf((*MockIBackupRepo)(nil).InsertRows), ctx, table, columns, rows)
+}
+
+// IsEmpty mocks base method.
+func (m *MockIBackupRepo) IsEmpty(ctx context.Context, table string, ignoredIDs []int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmpty", ctx, table, ignoredIDs)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmpty indicates an expected call of IsEmpty.
+func (mr *MockIBackupRepoMockRecorder) IsEmpty(ctx, table, ignoredIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockIBackupRepo)(nil).IsEmpty), ctx, table, ignoredIDs)
+}
+
+// IterateRows mocks base method.
+func (m *MockIBackupRepo) IterateRows(ctx context.Context, table string, fn func(json.RawMessage) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateRows", ctx, table, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateRows indicates an expected call of IterateRows.
+func (mr *MockIBackupRepoMockRecorder) IterateRows(ctx, table, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateRows", reflect.TypeOf((*MockIBackupRepo)(nil).IterateRows), ctx, table, fn)
+}
+
+// SetSequence mocks base method.
+func (m *MockIBackupRepo) SetSequence(ctx context.Context, name string, value *repository.SequenceValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSequence", ctx, name, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSequence indicates an expected call of SetSequence.
+func (mr *MockIBackupRepoMockRecorder) SetSequence(ctx, name, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSequence", reflect.TypeOf((*MockIBackupRepo)(nil).SetSequence), ctx, name, value)
+}
+
+// WithinSnapshot mocks base method.
+func (m *MockIBackupRepo) WithinSnapshot(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinSnapshot", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinSnapshot indicates an expected call of WithinSnapshot.
+func (mr *MockIBackupRepoMockRecorder) WithinSnapshot(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinSnapshot", reflect.TypeOf((*MockIBackupRepo)(nil).WithinSnapshot), ctx, fn)
+}
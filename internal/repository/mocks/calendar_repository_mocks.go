@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendar_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=calendar_repository.go -destination=mocks/calendar_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICalendarRepo is a mock of ICalendarRepo interface.
+type MockICalendarRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockICalendarRepoMockRecorder
+}
+
+// MockICalendarRepoMockRecorder is the mock recorder for MockICalendarRepo.
+type MockICalendarRepoMockRecorder struct {
+	mock *MockICalendarRepo
+}
+
+// NewMockICalendarRepo creates a new mock instance.
+func NewMockICalendarRepo(ctrl *gomock.Controller) *MockICalendarRepo {
+	mock := &MockICalendarRepo{ctrl: ctrl}
+	mock.recorder = &MockICalendarRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICalendarRepo) EXPECT() *MockICalendarRepoMockRecorder {
+	return m.recorder
+}
+
+// GetByToken mocks base method.
+func (m *MockICalendarRepo) GetByToken(ctx context.Context, token string) (*repository.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", ctx, token)
+	ret0, _ := ret[0].(*repository.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockICalendarRepoMockRecorder) GetByToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockICalendarRepo)(nil).GetByToken), ctx, token)
+}
+
+// GetByUserID mocks base method.
+func (m *MockICalendarRepo) GetByUserID(ctx context.Context, userID int) (*repository.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*repository.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockICalendarRepoMockRecorder) GetByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockICalendarRepo)(nil).GetByUserID), ctx, userID)
+}
+
+// Save mocks base method.
+func (m *MockICalendarRepo) Save(ctx context.Context, feed *repository.CalendarFeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, feed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockICalendarRepoMockRecorder) Save(ctx, feed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockICalendarRepo)(nil).Save), ctx, feed)
+}
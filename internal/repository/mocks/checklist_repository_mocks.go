@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: checklist_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=checklist_repository.go -destination=mocks/checklist_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIChecklistRepo is a mock of IChecklistRepo interface.
+type MockIChecklistRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIChecklistRepoMockRecorder
+}
+
+// MockIChecklistRepoMockRecorder is the mock recorder for MockIChecklistRepo.
+type MockIChecklistRepoMockRecorder struct {
+	mock *MockIChecklistRepo
+}
+
+// NewMockIChecklistRepo creates a new mock instance.
+func NewMockIChecklistRepo(ctrl *gomock.Controller) *MockIChecklistRepo {
+	mock := &MockIChecklistRepo{ctrl: ctrl}
+	mock.recorder = &MockIChecklistRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIChecklistRepo) EXPECT() *MockIChecklistRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIChecklistRepo) Create(ctx context.Context, item *repository.ChecklistItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIChecklistRepoMockRecorder) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIChecklistRepo)(nil).Create), ctx, item)
+}
+
+// DeleteByID mocks base method.
+func (m *MockIChecklistRepo) DeleteByID(ctx context.Context, itemID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockIChecklistRepoMockRecorder) DeleteByID(ctx, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockIChecklistRepo)(nil).DeleteByID), ctx, itemID)
+}
+
+// GetByID mocks base method.
+func (m *MockIChecklistRepo) GetByID(ctx context.Context, itemID int) (*repository.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, itemID)
+	ret0, _ := ret[0].(*repository.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIChecklistRepoMockRecorder) GetByID(ctx, itemID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIChecklistRepo)(nil).GetByID), ctx, itemID)
+}
+
+// GetByTaskID mocks base method.
+func (m *MockIChecklistRepo) GetByTaskID(ctx context.Context, taskID int) ([]repository.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]repository.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTaskID indicates an expected call of GetByTaskID.
+func (mr *MockIChecklistRepoMockRecorder) GetByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskID", reflect.TypeOf((*MockIChecklistRepo)(nil).GetByTaskID), ctx, taskID)
+}
+
+// GetProgressByTaskIDs mocks base method.
+func (m *MockIChecklistRepo) GetProgressByTaskIDs(ctx context.Context, taskIDs []int) (map[int]repository.ChecklistProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgressByTaskIDs", ctx, taskIDs)
+	ret0, _ := ret[0].(map[int]repository.ChecklistProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgressByTaskIDs indicates an expected call of GetProgressByTaskIDs.
+func (mr *MockIChecklistRepoMockRecorder) GetProgressByTaskIDs(ctx, taskIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgressByTaskIDs", reflect.TypeOf((*MockIChecklistRepo)(nil).GetProgressByTaskIDs), ctx, taskIDs)
+}
+
+// Move mocks base method.
+func (m *MockIChecklistRepo) Move(ctx context.Context, taskID, itemID, position int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, taskID, itemID, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockIChecklistRepoMockRecorder) Move(ctx, taskID, itemID, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockIChecklistRepo)(nil).Move), ctx, taskID, itemID, position)
+}
+
+// SetDone mocks base method.
+func (m *MockIChecklistRepo) SetDone(ctx context.Context, itemID int, done bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDone", ctx, itemID, done)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDone indicates an expected call of SetDone.
+func (mr *MockIChecklistRepoMockRecorder) SetDone(ctx, itemID, done any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDone", reflect.TypeOf((*MockIChecklistRepo)(nil).SetDone), ctx, itemID, done)
+}
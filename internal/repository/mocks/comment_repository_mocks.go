@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=comment_repository.go -destination=mocks/comment_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICommentRepo is a mock of ICommentRepo interface.
+type MockICommentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockICommentRepoMockRecorder
+}
+
+// MockICommentRepoMockRecorder is the mock recorder for MockICommentRepo.
+type MockICommentRepoMockRecorder struct {
+	mock *MockICommentRepo
+}
+
+// NewMockICommentRepo creates a new mock instance.
+func NewMockICommentRepo(ctrl *gomock.Controller) *MockICommentRepo {
+	mock := &MockICommentRepo{ctrl: ctrl}
+	mock.recorder = &MockICommentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICommentRepo) EXPECT() *MockICommentRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockICommentRepo) Create(ctx context.Context, comment *repository.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICommentRepoMockRecorder) Create(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICommentRepo)(nil).Create), ctx, comment)
+}
+
+// GetByTaskID mocks base method.
+func (m *MockICommentRepo) GetByTaskID(ctx context.Context, taskID int) ([]repository.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]repository.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTaskID indicates an expected call of GetByTaskID.
+func (mr *MockICommentRepoMockRecorder) GetByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskID", reflect.TypeOf((*MockICommentRepo)(nil).GetByTaskID), ctx, taskID)
+}
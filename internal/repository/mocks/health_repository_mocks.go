@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=health_repository.go -destination=mocks/health_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHealthRepo is a mock of IHealthRepo interface.
+type MockIHealthRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthRepoMockRecorder
+}
+
+// MockIHealthRepoMockRecorder is the mock recorder for MockIHealthRepo.
+type MockIHealthRepoMockRecorder struct {
+	mock *MockIHealthRepo
+}
+
+// NewMockIHealthRepo creates a new mock instance.
+func NewMockIHealthRepo(ctrl *gomock.Controller) *MockIHealthRepo {
+	mock := &MockIHealthRepo{ctrl: ctrl}
+	mock.recorder = &MockIHealthRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthRepo) EXPECT() *MockIHealthRepoMockRecorder {
+	return m.recorder
+}
+
+// CountOutboxByStatus mocks base method.
+func (m *MockIHealthRepo) CountOutboxByStatus(ctx context.Context) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOutboxByStatus", ctx)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOutboxByStatus indicates an expected call of CountOutboxByStatus.
+func (mr *MockIHealthRepoMockRecorder) CountOutboxByStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOutboxByStatus", reflect.TypeOf((*MockIHealthRepo)(nil).CountOutboxByStatus), ctx)
+}
+
+// GetMigrationVersion mocks base method.
+func (m *MockIHealthRepo) GetMigrationVersion(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationVersion", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationVersion indicates an expected call of GetMigrationVersion.
+func (mr *MockIHealthRepoMockRecorder) GetMigrationVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationVersion", reflect.TypeOf((*MockIHealthRepo)(nil).GetMigrationVersion), ctx)
+}
+
+// GetPoolStat mocks base method.
+func (m *MockIHealthRepo) GetPoolStat() *repository.PoolStat {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoolStat")
+	ret0, _ := ret[0].(*repository.PoolStat)
+	return ret0
+}
+
+// GetPoolStat indicates an expected call of GetPoolStat.
+func (mr *MockIHealthRepoMockRecorder) GetPoolStat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoolStat", reflect.TypeOf((*MockIHealthRepo)(nil).GetPoolStat))
+}
+
+// Ping mocks base method.
+func (m *MockIHealthRepo) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockIHealthRepoMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIHealthRepo)(nil).Ping), ctx)
+}
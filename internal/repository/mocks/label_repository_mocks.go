@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: label_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=label_repository.go -destination=mocks/label_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILabelRepo is a mock of ILabelRepo interface.
+type MockILabelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockILabelRepoMockRecorder
+}
+
+// MockILabelRepoMockRecorder is the mock recorder for MockILabelRepo.
+type MockILabelRepoMockRecorder struct {
+	mock *MockILabelRepo
+}
+
+// NewMockILabelRepo creates a new mock instance.
+func NewMockILabelRepo(ctrl *gomock.Controller) *MockILabelRepo {
+	mock := &MockILabelRepo{ctrl: ctrl}
+	mock.recorder = &MockILabelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILabelRepo) EXPECT() *MockILabelRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockILabelRepo) Add(ctx context.Context, taskID int, label string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, taskID, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockILabelRepoMockRecorder) Add(ctx, taskID, label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockILabelRepo)(nil).Add), ctx, taskID, label)
+}
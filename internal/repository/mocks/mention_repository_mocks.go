@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mention_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mention_repository.go -destination=mocks/mention_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMentionRepo is a mock of IMentionRepo interface.
+type MockIMentionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMentionRepoMockRecorder
+}
+
+// MockIMentionRepoMockRecorder is the mock recorder for MockIMentionRepo.
+type MockIMentionRepoMockRecorder struct {
+	mock *MockIMentionRepo
+}
+
+// NewMockIMentionRepo creates a new mock instance.
+func NewMockIMentionRepo(ctrl *gomock.Controller) *MockIMentionRepo {
+	mock := &MockIMentionRepo{ctrl: ctrl}
+	mock.recorder = &MockIMentionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMentionRepo) EXPECT() *MockIMentionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBacklinks mocks base method.
+func (m *MockIMentionRepo) GetBacklinks(ctx context.Context, taskID int) ([]repository.TaskBacklink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", ctx, taskID)
+	ret0, _ := ret[0].([]repository.TaskBacklink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockIMentionRepoMockRecorder) GetBacklinks(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockIMentionRepo)(nil).GetBacklinks), ctx, taskID)
+}
+
+// ReplaceForTask mocks base method.
+func (m *MockIMentionRepo) ReplaceForTask(ctx context.Context, taskID int, userIDs, referencedTaskIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceForTask", ctx, taskID, userIDs, referencedTaskIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceForTask indicates an expected call of ReplaceForTask.
+func (mr *MockIMentionRepoMockRecorder) ReplaceForTask(ctx, taskID, userIDs, referencedTaskIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceForTask", reflect.TypeOf((*MockIMentionRepo)(nil).ReplaceForTask), ctx, taskID, userIDs, referencedTaskIDs)
+}
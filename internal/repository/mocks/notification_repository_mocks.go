@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=notification_repository.go -destination=mocks/notification_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockINotificationRepo is a mock of INotificationRepo interface.
+type MockINotificationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationRepoMockRecorder
+}
+
+// MockINotificationRepoMockRecorder is the mock recorder for MockINotificationRepo.
+type MockINotificationRepoMockRecorder struct {
+	mock *MockINotificationRepo
+}
+
+// NewMockINotificationRepo creates a new mock instance.
+func NewMockINotificationRepo(ctrl *gomock.Controller) *MockINotificationRepo {
+	mock := &MockINotificationRepo{ctrl: ctrl}
+	mock.recorder = &MockINotificationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationRepo) EXPECT() *MockINotificationRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockINotificationRepo) CountUnread(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockINotificationRepoMockRecorder) CountUnread(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockINotificationRepo)(nil).CountUnread), ctx, userID)
+}
+
+// Create mocks base method.
+func (m *MockINotificationRepo) Create(ctx context.Context, notification *repository.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockINotificationRepoMockRecorder) Create(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockINotificationRepo)(nil).Create), ctx, notification)
+}
+
+// GetByUserID mocks base method.
+func (m *MockINotificationRepo) GetByUserID(ctx context.Context, userID, limit int) ([]repository.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID, limit)
+	ret0, _ := ret[0].([]repository.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockINotificationRepoMockRecorder) GetByUserID(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockINotificationRepo)(nil).GetByUserID), ctx, userID, limit)
+}
+
+// GetNotEmailed mocks base method.
+func (m *MockINotificationRepo) GetNotEmailed(ctx context.Context, userID int) ([]repository.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotEmailed", ctx, userID)
+	ret0, _ := ret[0].([]repository.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotEmailed indicates an expected call of GetNotEmailed.
+func (mr *MockINotificationRepoMockRecorder) GetNotEmailed(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotEmailed", reflect.TypeOf((*MockINotificationRepo)(nil).GetNotEmailed), ctx, userID)
+}
+
+// GetPreferences mocks base method.
+func (m *MockINotificationRepo) GetPreferences(ctx context.Context, userID int) ([]repository.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].([]repository.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockINotificationRepoMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockINotificationRepo)(nil).GetPreferences), ctx, userID)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockINotificationRepo) MarkAllRead(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockINotificationRepoMockRecorder) MarkAllRead(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockINotificationRepo)(nil).MarkAllRead), ctx, userID)
+}
+
+// MarkEmailed mocks base method.
+func (m *MockINotificationRepo) MarkEmailed(ctx context.Context, notificationIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailed", ctx, notificationIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailed indicates an expected call of MarkEmailed.
+func (mr *MockINotificationRepoMockRecorder) MarkEmailed(ctx, notificationIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailed", reflect.TypeOf((*MockINotificationRepo)(nil).MarkEmailed), ctx, notificationIDs)
+}
+
+// MarkRead mocks base method.
+func (m *MockINotificationRepo) MarkRead(ctx context.Context, userID, notificationID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, notificationID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockINotificationRepoMockRecorder) MarkRead(ctx, userID, notificationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockINotificationRepo)(nil).MarkRead), ctx, userID, notificationID)
+}
+
+// SetPreference mocks base method.
+func (m *MockINotificationRepo) SetPreference(ctx context.Context, preference *repository.NotificationPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", ctx, preference)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockINotificationRepoMockRecorder) SetPreference(ctx, preference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockINotificationRepo)(nil).SetPreference), ctx, preference)
+}
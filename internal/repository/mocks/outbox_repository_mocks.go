@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox_repository.go -destination=mocks/outbox_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOutboxRepo is a mock of IOutboxRepo interface.
+type MockIOutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxRepoMockRecorder
+}
+
+// MockIOutboxRepoMockRecorder is the mock recorder for MockIOutboxRepo.
+type MockIOutboxRepoMockRecorder struct {
+	mock *MockIOutboxRepo
+}
+
+// NewMockIOutboxRepo creates a new mock instance.
+func NewMockIOutboxRepo(ctrl *gomock.Controller) *MockIOutboxRepo {
+	mock := &MockIOutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockIOutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutboxRepo) EXPECT() *MockIOutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIOutboxRepo) Add(ctx context.Context, event *repository.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIOutboxRepoMockRecorder) Add(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIOutboxRepo)(nil).Add), ctx, event)
+}
+
+// GetAfter mocks base method.
+func (m *MockIOutboxRepo) GetAfter(ctx context.Context, afterID, limit int) ([]repository.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAfter", ctx, afterID, limit)
+	ret0, _ := ret[0].([]repository.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAfter indicates an expected call of GetAfter.
+func (mr *MockIOutboxRepoMockRecorder) GetAfter(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAfter", reflect.TypeOf((*MockIOutboxRepo)(nil).GetAfter), ctx, afterID, limit)
+}
+
+// GetPending mocks base method.
+func (m *MockIOutboxRepo) GetPending(ctx context.Context, limit int) ([]repository.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, limit)
+	ret0, _ := ret[0].([]repository.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockIOutboxRepoMockRecorder) GetPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockIOutboxRepo)(nil).GetPending), ctx, limit)
+}
+
+// IsProcessed mocks base method.
+func (m *MockIOutboxRepo) IsProcessed(ctx context.Context, handler string, eventID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProcessed", ctx, handler, eventID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProcessed indicates an expected call of IsProcessed.
+func (mr *MockIOutboxRepoMockRecorder) IsProcessed(ctx, handler, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProcessed", reflect.TypeOf((*MockIOutboxRepo)(nil).IsProcessed), ctx, handler, eventID)
+}
+
+// MarkProcessed mocks base method.
+func (m *MockIOutboxRepo) MarkProcessed(ctx context.Context, handler string, eventID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProcessed", ctx, handler, eventID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProcessed indicates an expected call of MarkProcessed.
+func (mr *MockIOutboxRepoMockRecorder) MarkProcessed(ctx, handler, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProcessed", reflect.TypeOf((*MockIOutboxRepo)(nil).MarkProcessed), ctx, handler, eventID)
+}
+
+// Update mocks base method.
+func (m *MockIOutboxRepo) Update(ctx context.Context, event *repository.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIOutboxRepoMockRecorder) Update(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIOutboxRepo)(nil).Update), ctx, event)
+}
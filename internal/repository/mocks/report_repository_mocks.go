@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=report_repository.go -destination=mocks/report_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIReportRepo is a mock of IReportRepo interface.
+type MockIReportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReportRepoMockRecorder
+}
+
+// MockIReportRepoMockRecorder is the mock recorder for MockIReportRepo.
+type MockIReportRepoMockRecorder struct {
+	mock *MockIReportRepo
+}
+
+// NewMockIReportRepo creates a new mock instance.
+func NewMockIReportRepo(ctrl *gomock.Controller) *MockIReportRepo {
+	mock := &MockIReportRepo{ctrl: ctrl}
+	mock.recorder = &MockIReportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReportRepo) EXPECT() *MockIReportRepoMockRecorder {
+	return m.recorder
+}
+
+// CountByAssignee mocks base method.
+func (m *MockIReportRepo) CountByAssignee(ctx context.Context, userID int) ([]repository.AssigneeStatusCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAssignee", ctx, userID)
+	ret0, _ := ret[0].([]repository.AssigneeStatusCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAssignee indicates an expected call of CountByAssignee.
+func (mr *MockIReportRepoMockRecorder) CountByAssignee(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAssignee", reflect.TypeOf((*MockIReportRepo)(nil).CountByAssignee), ctx, userID)
+}
+
+// CountByPriority mocks base method.
+func (m *MockIReportRepo) CountByPriority(ctx context.Context, userID int) ([]repository.PriorityStatusCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPriority", ctx, userID)
+	ret0, _ := ret[0].([]repository.PriorityStatusCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByPriority indicates an expected call of CountByPriority.
+func (mr *MockIReportRepoMockRecorder) CountByPriority(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPriority", reflect.TypeOf((*MockIReportRepo)(nil).CountByPriority), ctx, userID)
+}
+
+// CountCompletedByWeek mocks base method.
+func (m *MockIReportRepo) CountCompletedByWeek(ctx context.Context, userID int, since time.Time) ([]repository.WeekCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCompletedByWeek", ctx, userID, since)
+	ret0, _ := ret[0].([]repository.WeekCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCompletedByWeek indicates an expected call of CountCompletedByWeek.
+func (mr *MockIReportRepoMockRecorder) CountCompletedByWeek(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCompletedByWeek", reflect.TypeOf((*MockIReportRepo)(nil).CountCompletedByWeek), ctx, userID, since)
+}
+
+// CountCreatedByWeek mocks base method.
+func (m *MockIReportRepo) CountCreatedByWeek(ctx context.Context, userID int, since time.Time) ([]repository.WeekCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedByWeek", ctx, userID, since)
+	ret0, _ := ret[0].([]repository.WeekCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedByWeek indicates an expected call of CountCreatedByWeek.
+func (mr *MockIReportRepoMockRecorder) CountCreatedByWeek(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedByWeek", reflect.TypeOf((*MockIReportRepo)(nil).CountCreatedByWeek), ctx, userID, since)
+}
+
+// CountOpenByAge mocks base method.
+func (m *MockIReportRepo) CountOpenByAge(ctx context.Context, userID int, bounds []time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByAge", ctx, userID, bounds)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByAge indicates an expected call of CountOpenByAge.
+func (mr *MockIReportRepoMockRecorder) CountOpenByAge(ctx, userID, bounds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByAge", reflect.TypeOf((*MockIReportRepo)(nil).CountOpenByAge), ctx, userID, bounds)
+}
+
+// GetCycleTime mocks base method.
+func (m *MockIReportRepo) GetCycleTime(ctx context.Context, userID int, since time.Time) (*repository.CycleTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCycleTime", ctx, userID, since)
+	ret0, _ := ret[0].(*repository.CycleTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCycleTime indicates an expected call of GetCycleTime.
+func (mr *MockIReportRepoMockRecorder) GetCycleTime(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCycleTime", reflect.TypeOf((*MockIReportRepo)(nil).GetCycleTime), ctx, userID, since)
+}
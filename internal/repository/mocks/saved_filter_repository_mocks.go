@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: saved_filter_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=saved_filter_repository.go -destination=mocks/saved_filter_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISavedFilterRepo is a mock of ISavedFilterRepo interface.
+type MockISavedFilterRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockISavedFilterRepoMockRecorder
+}
+
+// MockISavedFilterRepoMockRecorder is the mock recorder for MockISavedFilterRepo.
+type MockISavedFilterRepoMockRecorder struct {
+	mock *MockISavedFilterRepo
+}
+
+// NewMockISavedFilterRepo creates a new mock instance.
+func NewMockISavedFilterRepo(ctrl *gomock.Controller) *MockISavedFilterRepo {
+	mock := &MockISavedFilterRepo{ctrl: ctrl}
+	mock.recorder = &MockISavedFilterRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISavedFilterRepo) EXPECT() *MockISavedFilterRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockISavedFilterRepo) Create(ctx context.Context, filter *repository.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISavedFilterRepoMockRecorder) Create(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISavedFilterRepo)(nil).Create), ctx, filter)
+}
+
+// DeleteByID mocks base method.
+func (m *MockISavedFilterRepo) DeleteByID(ctx context.Context, filterID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, filterID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockISavedFilterRepoMockRecorder) DeleteByID(ctx, filterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockISavedFilterRepo)(nil).DeleteByID), ctx, filterID)
+}
+
+// GetByID mocks base method.
+func (m *MockISavedFilterRepo) GetByID(ctx context.Context, filterID int) (*repository.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, filterID)
+	ret0, _ := ret[0].(*repository.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockISavedFilterRepoMockRecorder) GetByID(ctx, filterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockISavedFilterRepo)(nil).GetByID), ctx, filterID)
+}
+
+// GetNotifySettings mocks base method.
+func (m *MockISavedFilterRepo) GetNotifySettings(ctx context.Context) ([]repository.SavedFilterSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifySettings", ctx)
+	ret0, _ := ret[0].([]repository.SavedFilterSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifySettings indicates an expected call of GetNotifySettings.
+func (mr *MockISavedFilterRepoMockRecorder) GetNotifySettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifySettings", reflect.TypeOf((*MockISavedFilterRepo)(nil).GetNotifySettings), ctx)
+}
+
+// GetSettings mocks base method.
+func (m *MockISavedFilterRepo) GetSettings(ctx context.Context, userID int) ([]repository.SavedFilterSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, userID)
+	ret0, _ := ret[0].([]repository.SavedFilterSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockISavedFilterRepoMockRecorder) GetSettings(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockISavedFilterRepo)(nil).GetSettings), ctx, userID)
+}
+
+// GetVisible mocks base method.
+func (m *MockISavedFilterRepo) GetVisible(ctx context.Context, userID int) ([]repository.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisible", ctx, userID)
+	ret0, _ := ret[0].([]repository.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVisible indicates an expected call of GetVisible.
+func (mr *MockISavedFilterRepoMockRecorder) GetVisible(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisible", reflect.TypeOf((*MockISavedFilterRepo)(nil).GetVisible), ctx, userID)
+}
+
+// SaveSettings mocks base method.
+func (m *MockISavedFilterRepo) SaveSettings(ctx context.Context, settings *repository.SavedFilterSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSettings indicates an expected call of SaveSettings.
+func (mr *MockISavedFilterRepoMockRecorder) SaveSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSettings", reflect.TypeOf((*MockISavedFilterRepo)(nil).SaveSettings), ctx, settings)
+}
+
+// Update mocks base method.
+func (m *MockISavedFilterRepo) Update(ctx context.Context, filter *repository.SavedFilter) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, filter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockISavedFilterRepoMockRecorder) Update(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockISavedFilterRepo)(nil).Update), ctx, filter)
+}
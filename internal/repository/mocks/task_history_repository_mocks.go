@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_history_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=task_history_repository.go -destination=mocks/task_history_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITaskHistoryRepo is a mock of ITaskHistoryRepo interface.
+type MockITaskHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaskHistoryRepoMockRecorder
+}
+
+// MockITaskHistoryRepoMockRecorder is the mock recorder for MockITaskHistoryRepo.
+type MockITaskHistoryRepoMockRecorder struct {
+	mock *MockITaskHistoryRepo
+}
+
+// NewMockITaskHistoryRepo creates a new mock instance.
+func NewMockITaskHistoryRepo(ctrl *gomock.Controller) *MockITaskHistoryRepo {
+	mock := &MockITaskHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockITaskHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaskHistoryRepo) EXPECT() *MockITaskHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockITaskHistoryRepo) Create(ctx context.Context, history *repository.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockITaskHistoryRepoMockRecorder) Create(ctx, history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITaskHistoryRepo)(nil).Create), ctx, history)
+}
+
+// GetByTaskID mocks base method.
+func (m *MockITaskHistoryRepo) GetByTaskID(ctx context.Context, taskID int) ([]repository.TaskHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]repository.TaskHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTaskID indicates an expected call of GetByTaskID.
+func (mr *MockITaskHistoryRepoMockRecorder) GetByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskID", reflect.TypeOf((*MockITaskHistoryRepo)(nil).GetByTaskID), ctx, taskID)
+}
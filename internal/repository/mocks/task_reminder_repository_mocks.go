@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_reminder_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=task_reminder_repository.go -destination=mocks/task_reminder_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITaskReminderRepo is a mock of ITaskReminderRepo interface.
+type MockITaskReminderRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaskReminderRepoMockRecorder
+}
+
+// MockITaskReminderRepoMockRecorder is the mock recorder for MockITaskReminderRepo.
+type MockITaskReminderRepoMockRecorder struct {
+	mock *MockITaskReminderRepo
+}
+
+// NewMockITaskReminderRepo creates a new mock instance.
+func NewMockITaskReminderRepo(ctrl *gomock.Controller) *MockITaskReminderRepo {
+	mock := &MockITaskReminderRepo{ctrl: ctrl}
+	mock.recorder = &MockITaskReminderRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaskReminderRepo) EXPECT() *MockITaskReminderRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockITaskReminderRepo) Create(ctx context.Context, taskID int, dueAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, taskID, dueAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockITaskReminderRepoMockRecorder) Create(ctx, taskID, dueAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITaskReminderRepo)(nil).Create), ctx, taskID, dueAt)
+}
@@ -12,6 +12,7 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	repository "github.com/romakorinenko/task-manager/internal/repository"
 	gomock "go.uber.org/mock/gomock"
@@ -69,6 +70,21 @@ func (mr *MockITaskRepoMockRecorder) DeleteByID(ctx, taskID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockITaskRepo)(nil).DeleteByID), ctx, taskID)
 }
 
+// GetByFilter mocks base method.
+func (m *MockITaskRepo) GetByFilter(ctx context.Context, filter *repository.TaskFilter) ([]repository.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", ctx, filter)
+	ret0, _ := ret[0].([]repository.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockITaskRepoMockRecorder) GetByFilter(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockITaskRepo)(nil).GetByFilter), ctx, filter)
+}
+
 // GetByID mocks base method.
 func (m *MockITaskRepo) GetByID(ctx context.Context, taskID int) (*repository.Task, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +145,21 @@ func (mr *MockITaskRepoMockRecorder) GetByUserLogin(ctx, userLogin any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserLogin", reflect.TypeOf((*MockITaskRepo)(nil).GetByUserLogin), ctx, userLogin)
 }
 
+// GetDueBetween mocks base method.
+func (m *MockITaskRepo) GetDueBetween(ctx context.Context, from, to time.Time) ([]repository.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueBetween", ctx, from, to)
+	ret0, _ := ret[0].([]repository.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueBetween indicates an expected call of GetDueBetween.
+func (mr *MockITaskRepoMockRecorder) GetDueBetween(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueBetween", reflect.TypeOf((*MockITaskRepo)(nil).GetDueBetween), ctx, from, to)
+}
+
 // GetTaskWithLoginByID mocks base method.
 func (m *MockITaskRepo) GetTaskWithLoginByID(ctx context.Context, taskID int) (*repository.TaskWithLogin, error) {
 	m.ctrl.T.Helper()
@@ -174,6 +205,50 @@ func (mr *MockITaskRepoMockRecorder) GetTasksWithLoginByUserID(ctx, userID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksWithLoginByUserID", reflect.TypeOf((*MockITaskRepo)(nil).GetTasksWithLoginByUserID), ctx, userID)
 }
 
+// GetWithDueDate mocks base method.
+func (m *MockITaskRepo) GetWithDueDate(ctx context.Context, userID int) ([]repository.TaskWithLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithDueDate", ctx, userID)
+	ret0, _ := ret[0].([]repository.TaskWithLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithDueDate indicates an expected call of GetWithDueDate.
+func (mr *MockITaskRepoMockRecorder) GetWithDueDate(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithDueDate", reflect.TypeOf((*MockITaskRepo)(nil).GetWithDueDate), ctx, userID)
+}
+
+// IterateWithLogin mocks base method.
+func (m *MockITaskRepo) IterateWithLogin(ctx context.Context, filter *repository.TaskFilter, fn func(*repository.TaskWithLogin) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateWithLogin", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateWithLogin indicates an expected call of IterateWithLogin.
+func (mr *MockITaskRepoMockRecorder) IterateWithLogin(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateWithLogin", reflect.TypeOf((*MockITaskRepo)(nil).IterateWithLogin), ctx, filter, fn)
+}
+
+// MatchesFilter mocks base method.
+func (m *MockITaskRepo) MatchesFilter(ctx context.Context, taskID int, filter *repository.TaskFilter) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchesFilter", ctx, taskID, filter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchesFilter indicates an expected call of MatchesFilter.
+func (mr *MockITaskRepoMockRecorder) MatchesFilter(ctx, taskID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchesFilter", reflect.TypeOf((*MockITaskRepo)(nil).MatchesFilter), ctx, taskID, filter)
+}
+
 // Update mocks base method.
 func (m *MockITaskRepo) Update(ctx context.Context, task *repository.Task) error {
 	m.ctrl.T.Helper()
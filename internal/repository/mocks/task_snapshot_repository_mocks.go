@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_snapshot_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=task_snapshot_repository.go -destination=mocks/task_snapshot_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITaskSnapshotRepo is a mock of ITaskSnapshotRepo interface.
+type MockITaskSnapshotRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaskSnapshotRepoMockRecorder
+}
+
+// MockITaskSnapshotRepoMockRecorder is the mock recorder for MockITaskSnapshotRepo.
+type MockITaskSnapshotRepoMockRecorder struct {
+	mock *MockITaskSnapshotRepo
+}
+
+// NewMockITaskSnapshotRepo creates a new mock instance.
+func NewMockITaskSnapshotRepo(ctrl *gomock.Controller) *MockITaskSnapshotRepo {
+	mock := &MockITaskSnapshotRepo{ctrl: ctrl}
+	mock.recorder = &MockITaskSnapshotRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaskSnapshotRepo) EXPECT() *MockITaskSnapshotRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDaily mocks base method.
+func (m *MockITaskSnapshotRepo) GetDaily(ctx context.Context, userID int, from, to time.Time) ([]repository.DailyStatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDaily", ctx, userID, from, to)
+	ret0, _ := ret[0].([]repository.DailyStatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDaily indicates an expected call of GetDaily.
+func (mr *MockITaskSnapshotRepoMockRecorder) GetDaily(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaily", reflect.TypeOf((*MockITaskSnapshotRepo)(nil).GetDaily), ctx, userID, from, to)
+}
+
+// SaveDaily mocks base method.
+func (m *MockITaskSnapshotRepo) SaveDaily(ctx context.Context, day time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDaily", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDaily indicates an expected call of SaveDaily.
+func (mr *MockITaskSnapshotRepoMockRecorder) SaveDaily(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDaily", reflect.TypeOf((*MockITaskSnapshotRepo)(nil).SaveDaily), ctx, day)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_status_history_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=task_status_history_repository.go -destination=mocks/task_status_history_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITaskStatusHistoryRepo is a mock of ITaskStatusHistoryRepo interface.
+type MockITaskStatusHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaskStatusHistoryRepoMockRecorder
+}
+
+// MockITaskStatusHistoryRepoMockRecorder is the mock recorder for MockITaskStatusHistoryRepo.
+type MockITaskStatusHistoryRepoMockRecorder struct {
+	mock *MockITaskStatusHistoryRepo
+}
+
+// NewMockITaskStatusHistoryRepo creates a new mock instance.
+func NewMockITaskStatusHistoryRepo(ctrl *gomock.Controller) *MockITaskStatusHistoryRepo {
+	mock := &MockITaskStatusHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockITaskStatusHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaskStatusHistoryRepo) EXPECT() *MockITaskStatusHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockITaskStatusHistoryRepo) Add(ctx context.Context, change *repository.TaskStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockITaskStatusHistoryRepoMockRecorder) Add(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockITaskStatusHistoryRepo)(nil).Add), ctx, change)
+}
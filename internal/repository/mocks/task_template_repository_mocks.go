@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: task_template_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=task_template_repository.go -destination=mocks/task_template_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITaskTemplateRepo is a mock of ITaskTemplateRepo interface.
+type MockITaskTemplateRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockITaskTemplateRepoMockRecorder
+}
+
+// MockITaskTemplateRepoMockRecorder is the mock recorder for MockITaskTemplateRepo.
+type MockITaskTemplateRepoMockRecorder struct {
+	mock *MockITaskTemplateRepo
+}
+
+// NewMockITaskTemplateRepo creates a new mock instance.
+func NewMockITaskTemplateRepo(ctrl *gomock.Controller) *MockITaskTemplateRepo {
+	mock := &MockITaskTemplateRepo{ctrl: ctrl}
+	mock.recorder = &MockITaskTemplateRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITaskTemplateRepo) EXPECT() *MockITaskTemplateRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockITaskTemplateRepo) Create(ctx context.Context, template *repository.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockITaskTemplateRepoMockRecorder) Create(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockITaskTemplateRepo)(nil).Create), ctx, template)
+}
+
+// DeleteByID mocks base method.
+func (m *MockITaskTemplateRepo) DeleteByID(ctx context.Context, templateID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, templateID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockITaskTemplateRepoMockRecorder) DeleteByID(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockITaskTemplateRepo)(nil).DeleteByID), ctx, templateID)
+}
+
+// GetByID mocks base method.
+func (m *MockITaskTemplateRepo) GetByID(ctx context.Context, templateID int) (*repository.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, templateID)
+	ret0, _ := ret[0].(*repository.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockITaskTemplateRepoMockRecorder) GetByID(ctx, templateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockITaskTemplateRepo)(nil).GetByID), ctx, templateID)
+}
+
+// GetByVisibility mocks base method.
+func (m *MockITaskTemplateRepo) GetByVisibility(ctx context.Context, visibilities []string) ([]repository.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVisibility", ctx, visibilities)
+	ret0, _ := ret[0].([]repository.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVisibility indicates an expected call of GetByVisibility.
+func (mr *MockITaskTemplateRepoMockRecorder) GetByVisibility(ctx, visibilities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVisibility", reflect.TypeOf((*MockITaskTemplateRepo)(nil).GetByVisibility), ctx, visibilities)
+}
+
+// Update mocks base method.
+func (m *MockITaskTemplateRepo) Update(ctx context.Context, template *repository.TaskTemplate) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, template)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockITaskTemplateRepoMockRecorder) Update(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockITaskTemplateRepo)(nil).Update), ctx, template)
+}
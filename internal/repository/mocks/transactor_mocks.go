@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=transactor.go -destination=mocks/transactor_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgx "github.com/jackc/pgx/v5"
+	pgconn "github.com/jackc/pgx/v5/pgconn"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITransactor is a mock of ITransactor interface.
+type MockITransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockITransactorMockRecorder
+}
+
+// MockITransactorMockRecorder is the mock recorder for MockITransactor.
+type MockITransactorMockRecorder struct {
+	mock *MockITransactor
+}
+
+// NewMockITransactor creates a new mock instance.
+func NewMockITransactor(ctrl *gomock.Controller) *MockITransactor {
+	mock := &MockITransactor{ctrl: ctrl}
+	mock.recorder = &MockITransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITransactor) EXPECT() *MockITransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTx mocks base method.
+func (m *MockITransactor) WithinTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockITransactorMockRecorder) WithinTx(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockITransactor)(nil).WithinTx), ctx, fn)
+}
+
+// Mockquerier is a mock of querier interface.
+type Mockquerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockquerierMockRecorder
+}
+
+// MockquerierMockRecorder is the mock recorder for Mockquerier.
+type MockquerierMockRecorder struct {
+	mock *Mockquerier
+}
+
+// NewMockquerier creates a new mock instance.
+func NewMockquerier(ctrl *gomock.Controller) *Mockquerier {
+	mock := &Mockquerier{ctrl: ctrl}
+	mock.recorder = &MockquerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockquerier) EXPECT() *MockquerierMockRecorder {
+	return m.recorder
+}
+
+// Exec mocks base method.
+func (m *Mockquerier) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range arguments {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockquerierMockRecorder) Exec(ctx, sql any, arguments ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, arguments...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*Mockquerier)(nil).Exec), varargs...)
+}
+
+// Query mocks base method.
+func (m *Mockquerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Query", varargs...)
+	ret0, _ := ret[0].(pgx.Rows)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockquerierMockRecorder) Query(ctx, sql any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*Mockquerier)(nil).Query), varargs...)
+}
+
+// QueryRow mocks base method.
+func (m *Mockquerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, sql}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "QueryRow", varargs...)
+	ret0, _ := ret[0].(pgx.Row)
+	return ret0
+}
+
+// QueryRow indicates an expected call of QueryRow.
+func (mr *MockquerierMockRecorder) QueryRow(ctx, sql any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, sql}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryRow", reflect.TypeOf((*Mockquerier)(nil).QueryRow), varargs...)
+}
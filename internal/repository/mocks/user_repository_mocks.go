@@ -69,11 +69,12 @@ func (mr *MockIUserRepoMockRecorder) Create(ctx, user any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockIUserRepo) GetAll(ctx context.Context) []repository.User {
+func (m *MockIUserRepo) GetAll(ctx context.Context) ([]repository.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]repository.User)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -82,6 +83,21 @@ func (mr *MockIUserRepoMockRecorder) GetAll(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIUserRepo)(nil).GetAll), ctx)
 }
 
+// GetByID mocks base method.
+func (m *MockIUserRepo) GetByID(ctx context.Context, userID int) (*repository.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, userID)
+	ret0, _ := ret[0].(*repository.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIUserRepoMockRecorder) GetByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIUserRepo)(nil).GetByID), ctx, userID)
+}
+
 // GetByLogin mocks base method.
 func (m *MockIUserRepo) GetByLogin(ctx context.Context, userLogin string) (*repository.User, error) {
 	m.ctrl.T.Helper()
@@ -96,3 +112,17 @@ func (mr *MockIUserRepoMockRecorder) GetByLogin(ctx, userLogin any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockIUserRepo)(nil).GetByLogin), ctx, userLogin)
 }
+
+// UpdateEmailSettings mocks base method.
+func (m *MockIUserRepo) UpdateEmailSettings(ctx context.Context, userID int, email string, digest bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailSettings", ctx, userID, email, digest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailSettings indicates an expected call of UpdateEmailSettings.
+func (mr *MockIUserRepoMockRecorder) UpdateEmailSettings(ctx, userID, email, digest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailSettings", reflect.TypeOf((*MockIUserRepo)(nil).UpdateEmailSettings), ctx, userID, email, digest)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: watcher_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=watcher_repository.go -destination=mocks/watcher_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWatcherRepo is a mock of IWatcherRepo interface.
+type MockIWatcherRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWatcherRepoMockRecorder
+}
+
+// MockIWatcherRepoMockRecorder is the mock recorder for MockIWatcherRepo.
+type MockIWatcherRepoMockRecorder struct {
+	mock *MockIWatcherRepo
+}
+
+// NewMockIWatcherRepo creates a new mock instance.
+func NewMockIWatcherRepo(ctrl *gomock.Controller) *MockIWatcherRepo {
+	mock := &MockIWatcherRepo{ctrl: ctrl}
+	mock.recorder = &MockIWatcherRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWatcherRepo) EXPECT() *MockIWatcherRepoMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIWatcherRepo) Add(ctx context.Context, taskID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIWatcherRepoMockRecorder) Add(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIWatcherRepo)(nil).Add), ctx, taskID, userID)
+}
+
+// Delete mocks base method.
+func (m *MockIWatcherRepo) Delete(ctx context.Context, taskID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIWatcherRepoMockRecorder) Delete(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIWatcherRepo)(nil).Delete), ctx, taskID, userID)
+}
+
+// GetUserIDs mocks base method.
+func (m *MockIWatcherRepo) GetUserIDs(ctx context.Context, taskID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDs", ctx, taskID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDs indicates an expected call of GetUserIDs.
+func (mr *MockIWatcherRepoMockRecorder) GetUserIDs(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDs", reflect.TypeOf((*MockIWatcherRepo)(nil).GetUserIDs), ctx, taskID)
+}
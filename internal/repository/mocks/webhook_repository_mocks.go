@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_repository.go -destination=mocks/webhook_repository_mocks.go
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repository "github.com/romakorinenko/task-manager/internal/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookRepo is a mock of IWebhookRepo interface.
+type MockIWebhookRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookRepoMockRecorder
+}
+
+// MockIWebhookRepoMockRecorder is the mock recorder for MockIWebhookRepo.
+type MockIWebhookRepoMockRecorder struct {
+	mock *MockIWebhookRepo
+}
+
+// NewMockIWebhookRepo creates a new mock instance.
+func NewMockIWebhookRepo(ctrl *gomock.Controller) *MockIWebhookRepo {
+	mock := &MockIWebhookRepo{ctrl: ctrl}
+	mock.recorder = &MockIWebhookRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookRepo) EXPECT() *MockIWebhookRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookRepo) Create(ctx context.Context, webhook *repository.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookRepoMockRecorder) Create(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookRepo)(nil).Create), ctx, webhook)
+}
+
+// CreateDelivery mocks base method.
+func (m *MockIWebhookRepo) CreateDelivery(ctx context.Context, delivery *repository.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockIWebhookRepoMockRecorder) CreateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockIWebhookRepo)(nil).CreateDelivery), ctx, delivery)
+}
+
+// DeleteByID mocks base method.
+func (m *MockIWebhookRepo) DeleteByID(ctx context.Context, webhookID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, webhookID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockIWebhookRepoMockRecorder) DeleteByID(ctx, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockIWebhookRepo)(nil).DeleteByID), ctx, webhookID)
+}
+
+// GetAll mocks base method.
+func (m *MockIWebhookRepo) GetAll(ctx context.Context) ([]repository.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]repository.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockIWebhookRepoMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockIWebhookRepo)(nil).GetAll), ctx)
+}
+
+// GetByEvent mocks base method.
+func (m *MockIWebhookRepo) GetByEvent(ctx context.Context, event string) ([]repository.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEvent", ctx, event)
+	ret0, _ := ret[0].([]repository.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEvent indicates an expected call of GetByEvent.
+func (mr *MockIWebhookRepoMockRecorder) GetByEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEvent", reflect.TypeOf((*MockIWebhookRepo)(nil).GetByEvent), ctx, event)
+}
+
+// GetByID mocks base method.
+func (m *MockIWebhookRepo) GetByID(ctx context.Context, webhookID int) (*repository.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, webhookID)
+	ret0, _ := ret[0].(*repository.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockIWebhookRepoMockRecorder) GetByID(ctx, webhookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIWebhookRepo)(nil).GetByID), ctx, webhookID)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockIWebhookRepo) GetDeliveries(ctx context.Context, webhookID, limit int) ([]repository.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, webhookID, limit)
+	ret0, _ := ret[0].([]repository.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockIWebhookRepoMockRecorder) GetDeliveries(ctx, webhookID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockIWebhookRepo)(nil).GetDeliveries), ctx, webhookID, limit)
+}
+
+// GetDeliveryByID mocks base method.
+func (m *MockIWebhookRepo) GetDeliveryByID(ctx context.Context, deliveryID int) (*repository.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryByID", ctx, deliveryID)
+	ret0, _ := ret[0].(*repository.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryByID indicates an expected call of GetDeliveryByID.
+func (mr *MockIWebhookRepoMockRecorder) GetDeliveryByID(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryByID", reflect.TypeOf((*MockIWebhookRepo)(nil).GetDeliveryByID), ctx, deliveryID)
+}
+
+// GetDueDeliveries mocks base method.
+func (m *MockIWebhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]repository.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]repository.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueDeliveries indicates an expected call of GetDueDeliveries.
+func (mr *MockIWebhookRepoMockRecorder) GetDueDeliveries(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueDeliveries", reflect.TypeOf((*MockIWebhookRepo)(nil).GetDueDeliveries), ctx, now, limit)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockIWebhookRepo) UpdateDelivery(ctx context.Context, delivery *repository.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockIWebhookRepoMockRecorder) UpdateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockIWebhookRepo)(nil).UpdateDelivery), ctx, delivery)
+}
@@ -0,0 +1,246 @@
+package repository
+
+//go:generate mockgen -source=notification_repository.go -destination=mocks/notification_repository_mocks.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	NotificationsTableName           = "notifications"
+	NotificationPreferencesTableName = "notification_preferences"
+)
+
+// ErrNotificationExists - уведомление об этом событии outbox уже создано при прошлой обработке события.
+var ErrNotificationExists = errors.New("notification for the event already exists")
+
+type Notification struct {
+	ID        int       `db:"id" json:"id"`
+	UserID    int       `db:"user_id" json:"userId"`
+	TaskID    int       `db:"task_id" json:"taskId"`
+	Event     string    `db:"event" json:"event"`
+	Message   string    `db:"message" json:"message"`
+	Read      bool      `db:"is_read" json:"read"`
+	Emailed   bool      `db:"emailed" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	// EventID - событие outbox, по которому создано уведомление, 0 - уведомление создано не из outbox.
+	EventID int `db:"event_id" json:"-"`
+}
+
+type NotificationPreference struct {
+	UserID  int    `db:"user_id" json:"userId"`
+	Event   string `db:"event" json:"event"`
+	Enabled bool   `db:"enabled" json:"enabled"`
+}
+
+var (
+	NotificationStruct           = sqlbuilder.NewStruct(new(Notification))
+	NotificationPreferenceStruct = sqlbuilder.NewStruct(new(NotificationPreference))
+)
+
+type INotificationRepo interface {
+	Create(ctx context.Context, notification *Notification) error
+	GetByUserID(ctx context.Context, userID, limit int) ([]Notification, error)
+	CountUnread(ctx context.Context, userID int) (int, error)
+	MarkRead(ctx context.Context, userID, notificationID int) (bool, error)
+	MarkAllRead(ctx context.Context, userID int) error
+	GetNotEmailed(ctx context.Context, userID int) ([]Notification, error)
+	MarkEmailed(ctx context.Context, notificationIDs []int) error
+	GetPreferences(ctx context.Context, userID int) ([]NotificationPreference, error)
+	SetPreference(ctx context.Context, preference *NotificationPreference) error
+}
+
+type NotificationRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewNotificationRepo(dbPool *pgxpool.Pool) *NotificationRepo {
+	return &NotificationRepo{dbPool: dbPool}
+}
+
+// Create сохраняет уведомление. Для события outbox пользователь получает не больше одного уведомления каждого типа:
+// повторная попытка вернёт ErrNotificationExists. Уведомление создаётся в транзакции из контекста, если она открыта.
+func (n *NotificationRepo) Create(ctx context.Context, notification *Notification) error {
+	ID, err := n.generateNextNotificationID(ctx)
+	if err != nil {
+		return err
+	}
+
+	notification.ID = ID
+	notification.CreatedAt = time.Now()
+	sql, args := NotificationStruct.InsertInto(NotificationsTableName, notification).
+		SQL("ON CONFLICT (event_id, user_id, event) WHERE event_id <> 0 DO NOTHING").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := conn(ctx, n.dbPool).Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotificationExists
+	}
+
+	return nil
+}
+
+// GetByUserID возвращает последние limit уведомлений пользователя, новые первыми.
+func (n *NotificationRepo) GetByUserID(ctx context.Context, userID, limit int) ([]Notification, error) {
+	sb := NotificationStruct.SelectFrom(NotificationsTableName)
+	sql, args := sb.Where(sb.Equal("user_id", userID)).
+		OrderBy("id").Desc().
+		Limit(limit).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := n.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Notification, 0)
+	for rows.Next() {
+		var notification Notification
+		if rowScanErr := rows.Scan(NotificationStruct.Addr(&notification)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, notification)
+	}
+
+	return res, rows.Err()
+}
+
+func (n *NotificationRepo) CountUnread(ctx context.Context, userID int) (int, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("COUNT(*)").
+		From(NotificationsTableName).
+		Where(sb.Equal("user_id", userID), sb.Equal("is_read", false)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var count int
+	if err := n.dbPool.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkRead отмечает уведомление прочитанным. Возвращает false, если у пользователя нет такого уведомления.
+func (n *NotificationRepo) MarkRead(ctx context.Context, userID, notificationID int) (bool, error) {
+	ub := sqlbuilder.Update(NotificationsTableName)
+	sql, args := ub.Where(ub.Equal("id", notificationID), ub.Equal("user_id", userID)).
+		Set(ub.Assign("is_read", true)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := n.dbPool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (n *NotificationRepo) MarkAllRead(ctx context.Context, userID int) error {
+	ub := sqlbuilder.Update(NotificationsTableName)
+	sql, args := ub.Where(ub.Equal("user_id", userID), ub.Equal("is_read", false)).
+		Set(ub.Assign("is_read", true)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := n.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetNotEmailed возвращает уведомления пользователя, ещё не отправленные на почту, старые первыми.
+func (n *NotificationRepo) GetNotEmailed(ctx context.Context, userID int) ([]Notification, error) {
+	sb := NotificationStruct.SelectFrom(NotificationsTableName)
+	sql, args := sb.Where(sb.Equal("user_id", userID), sb.Equal("emailed", false)).
+		OrderBy("id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := n.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Notification, 0)
+	for rows.Next() {
+		var notification Notification
+		if rowScanErr := rows.Scan(NotificationStruct.Addr(&notification)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, notification)
+	}
+
+	return res, rows.Err()
+}
+
+func (n *NotificationRepo) MarkEmailed(ctx context.Context, notificationIDs []int) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	ub := sqlbuilder.Update(NotificationsTableName)
+	sql, args := ub.Where(ub.In("id", sqlbuilder.Flatten(notificationIDs)...)).
+		Set(ub.Assign("emailed", true)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := conn(ctx, n.dbPool).Exec(ctx, sql, args...)
+	return err
+}
+
+// GetPreferences возвращает сохранённые настройки пользователя. Для событий без записи действует значение по умолчанию.
+func (n *NotificationRepo) GetPreferences(ctx context.Context, userID int) ([]NotificationPreference, error) {
+	sb := NotificationPreferenceStruct.SelectFrom(NotificationPreferencesTableName)
+	sql, args := sb.Where(sb.Equal("user_id", userID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := n.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]NotificationPreference, 0)
+	for rows.Next() {
+		var preference NotificationPreference
+		if rowScanErr := rows.Scan(NotificationPreferenceStruct.Addr(&preference)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, preference)
+	}
+
+	return res, rows.Err()
+}
+
+func (n *NotificationRepo) SetPreference(ctx context.Context, preference *NotificationPreference) error {
+	_, err := n.dbPool.Exec(ctx,
+		`INSERT INTO notification_preferences (user_id, event, enabled) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, event) DO UPDATE SET enabled = EXCLUDED.enabled`,
+		preference.UserID, preference.Event, preference.Enabled,
+	)
+	return err
+}
+
+func (n *NotificationRepo) generateNextNotificationID(ctx context.Context) (int, error) {
+	rows, err := n.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "notifications_sequence"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next notification id")
+}
@@ -0,0 +1,147 @@
+package repository
+
+//go:generate mockgen -source=outbox_repository.go -destination=mocks/outbox_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romakorinenko/task-manager/internal/constant"
+)
+
+const (
+	OutboxTableName          = "outbox"
+	OutboxProcessedTableName = "outbox_processed"
+)
+
+// OutboxEvent - доменное событие, сохранённое в одной транзакции с изменением, о котором оно сообщает.
+// AggregateID - идентификатор задачи: события одной задачи публикуются строго по порядку.
+type OutboxEvent struct {
+	ID          int       `db:"id" json:"id"`
+	AggregateID int       `db:"aggregate_id" json:"aggregateId"`
+	Event       string    `db:"event" json:"event"`
+	Payload     string    `db:"payload" json:"payload"`
+	Status      string    `db:"status" json:"status"`
+	Attempts    int       `db:"attempts" json:"attempts"`
+	LastError   string    `db:"last_error" json:"lastError"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+var OutboxEventStruct = sqlbuilder.NewStruct(new(OutboxEvent))
+
+type IOutboxRepo interface {
+	Add(ctx context.Context, event *OutboxEvent) error
+	GetPending(ctx context.Context, limit int) ([]OutboxEvent, error)
+	GetAfter(ctx context.Context, afterID int, limit int) ([]OutboxEvent, error)
+	Update(ctx context.Context, event *OutboxEvent) error
+	IsProcessed(ctx context.Context, handler string, eventID int) (bool, error)
+	MarkProcessed(ctx context.Context, handler string, eventID int) error
+}
+
+type OutboxRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewOutboxRepo(dbPool *pgxpool.Pool) *OutboxRepo {
+	return &OutboxRepo{dbPool: dbPool}
+}
+
+// Add сохраняет событие. Вызывается внутри транзакции ITransactor вместе с изменением данных.
+func (o *OutboxRepo) Add(ctx context.Context, event *OutboxEvent) error {
+	db := conn(ctx, o.dbPool)
+
+	var ID int
+	if err := db.QueryRow(ctx, "SELECT nextval('outbox_sequence')").Scan(&ID); err != nil {
+		return err
+	}
+
+	event.ID = ID
+	event.Status = constant.PendingOutboxStatus
+	event.CreatedAt = time.Now()
+	sql, args := OutboxEventStruct.InsertInto(OutboxTableName, event).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := db.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetPending возвращает до limit неопубликованных событий в порядке их создания.
+func (o *OutboxRepo) GetPending(ctx context.Context, limit int) ([]OutboxEvent, error) {
+	sb := OutboxEventStruct.SelectFrom(OutboxTableName)
+	sb.Where(sb.Equal("status", constant.PendingOutboxStatus)).
+		OrderBy("id").
+		Limit(limit)
+
+	return o.queryEvents(ctx, sb)
+}
+
+// GetAfter возвращает до limit событий с идентификатором больше afterID в порядке их создания.
+func (o *OutboxRepo) GetAfter(ctx context.Context, afterID int, limit int) ([]OutboxEvent, error) {
+	sb := OutboxEventStruct.SelectFrom(OutboxTableName)
+	sb.Where(sb.GreaterThan("id", afterID)).
+		OrderBy("id").
+		Limit(limit)
+
+	return o.queryEvents(ctx, sb)
+}
+
+func (o *OutboxRepo) Update(ctx context.Context, event *OutboxEvent) error {
+	ub := sqlbuilder.Update(OutboxTableName)
+	sql, args := ub.Where(ub.Equal("id", event.ID)).
+		Set(
+			ub.Assign("status", event.Status),
+			ub.Assign("attempts", event.Attempts),
+			ub.Assign("last_error", event.LastError),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := o.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// IsProcessed сообщает, обработал ли уже обработчик handler событие eventID.
+func (o *OutboxRepo) IsProcessed(ctx context.Context, handler string, eventID int) (bool, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("COUNT(*)").
+		From(OutboxProcessedTableName).
+		Where(sb.Equal("handler", handler), sb.Equal("event_id", eventID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var count int
+	if err := o.dbPool.QueryRow(ctx, sql, args...).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (o *OutboxRepo) MarkProcessed(ctx context.Context, handler string, eventID int) error {
+	_, err := o.dbPool.Exec(ctx,
+		`INSERT INTO outbox_processed (handler, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		handler, eventID,
+	)
+	return err
+}
+
+func (o *OutboxRepo) queryEvents(ctx context.Context, sb *sqlbuilder.SelectBuilder) ([]OutboxEvent, error) {
+	sql, args := sb.BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := o.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]OutboxEvent, 0)
+	for rows.Next() {
+		var event OutboxEvent
+		if rowScanErr := rows.Scan(OutboxEventStruct.Addr(&event)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, event)
+	}
+
+	return res, rows.Err()
+}
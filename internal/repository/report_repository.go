@@ -0,0 +1,256 @@
+package repository
+
+//go:generate mockgen -source=report_repository.go -destination=mocks/report_repository_mocks.go
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romakorinenko/task-manager/internal/constant"
+)
+
+// WeekCount - число задач за неделю. Week - начало недели (понедельник, 00:00).
+type WeekCount struct {
+	Week  time.Time
+	Count int
+}
+
+// CycleTime - среднее время от открытия задачи до её выполнения по задачам, выполненным за период.
+type CycleTime struct {
+	Tasks        int     `json:"tasks" example:"12"`
+	AverageHours float64 `json:"averageHours" example:"30.5"`
+}
+
+// StatusCounts - число задач в каждом статусе.
+type StatusCounts struct {
+	Open       int `json:"open" example:"3"`
+	InProgress int `json:"inProgress" example:"2"`
+	Done       int `json:"done" example:"10"`
+}
+
+// AssigneeStatusCounts - задачи исполнителя по статусам. UserLogin пуст у задач без исполнителя.
+type AssigneeStatusCounts struct {
+	UserLogin string `json:"userLogin" example:"user"`
+	StatusCounts
+}
+
+// PriorityStatusCounts - задачи с приоритетом по статусам.
+type PriorityStatusCounts struct {
+	Priority int `json:"priority" example:"2"`
+	StatusCounts
+}
+
+// IReportRepo считает агрегаты по задачам в базе. userID ограничивает подсчёт задачами пользователя,
+// 0 - задачи всех пользователей.
+type IReportRepo interface {
+	CountCreatedByWeek(ctx context.Context, userID int, since time.Time) ([]WeekCount, error)
+	CountCompletedByWeek(ctx context.Context, userID int, since time.Time) ([]WeekCount, error)
+	GetCycleTime(ctx context.Context, userID int, since time.Time) (*CycleTime, error)
+	CountOpenByAge(ctx context.Context, userID int, bounds []time.Time) ([]int, error)
+	CountByAssignee(ctx context.Context, userID int) ([]AssigneeStatusCounts, error)
+	CountByPriority(ctx context.Context, userID int) ([]PriorityStatusCounts, error)
+}
+
+type ReportRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewReportRepo(dbPool *pgxpool.Pool) *ReportRepo {
+	return &ReportRepo{dbPool: dbPool}
+}
+
+// CountCreatedByWeek возвращает число созданных задач по неделям начиная с since. Недели без задач пропускаются.
+func (r *ReportRepo) CountCreatedByWeek(ctx context.Context, userID int, since time.Time) ([]WeekCount, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("date_trunc('week', tasks.created_at) AS week", "COUNT(*)").
+		From(TasksTableName).
+		Where(sb.GreaterEqualThan("tasks.created_at", since))
+	whereTaskUser(sb, userID)
+	sql, args := sb.GroupBy("week").
+		OrderBy("week").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return r.queryWeekCounts(ctx, sql, args)
+}
+
+// CountCompletedByWeek возвращает по неделям начиная с since, сколько раз задачи переводились в DONE.
+// Недели без таких переходов пропускаются.
+func (r *ReportRepo) CountCompletedByWeek(ctx context.Context, userID int, since time.Time) ([]WeekCount, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("date_trunc('week', task_status_history.changed_at) AS week", "COUNT(*)").
+		From(TaskStatusHistoryTableName).
+		Join(TasksTableName, "tasks.id = task_status_history.task_id").
+		Where(
+			sb.Equal("task_status_history.new_status", constant.DoneTaskStatus),
+			sb.GreaterEqualThan("task_status_history.changed_at", since),
+		)
+	whereTaskUser(sb, userID)
+	sql, args := sb.GroupBy("week").
+		OrderBy("week").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return r.queryWeekCounts(ctx, sql, args)
+}
+
+// GetCycleTime считает время выполнения по истории статусов задач, которые сейчас в DONE и последний раз
+// переведены в DONE не раньше since: от первого перехода в OPEN (или создания задачи) до последнего перехода в DONE.
+func (r *ReportRepo) GetCycleTime(ctx context.Context, userID int, since time.Time) (*CycleTime, error) {
+	cycle := sqlbuilder.NewSelectBuilder()
+	cycle.Select(
+		"MAX(task_status_history.changed_at) FILTER (WHERE task_status_history.new_status = "+
+			cycle.Var(constant.DoneTaskStatus)+") AS done_at",
+		"COALESCE(MIN(task_status_history.changed_at) FILTER (WHERE task_status_history.new_status = "+
+			cycle.Var(constant.OpenTaskStatus)+"), tasks.created_at) AS open_at",
+	).
+		From(TasksTableName).
+		Join(TaskStatusHistoryTableName, "task_status_history.task_id = tasks.id").
+		Where(cycle.Equal("tasks.status", constant.DoneTaskStatus))
+	whereTaskUser(cycle, userID)
+	cycle.GroupBy("tasks.id")
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select(
+		"COUNT(*)",
+		"COALESCE(AVG(EXTRACT(EPOCH FROM cycle.done_at - cycle.open_at)), 0)::float8 / 3600",
+	).
+		From(sb.BuilderAs(cycle, "cycle")).
+		Where(sb.GreaterEqualThan("cycle.done_at", since)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var cycleTime CycleTime
+	if err := r.dbPool.QueryRow(ctx, sql, args...).Scan(&cycleTime.Tasks, &cycleTime.AverageHours); err != nil {
+		return nil, err
+	}
+
+	return &cycleTime, nil
+}
+
+// CountOpenByAge делит невыполненные задачи на корзины по времени создания. bounds - границы корзин по убыванию:
+// первая корзина - задачи, созданные позже bounds[0], последняя - созданные не позже bounds[len(bounds)-1].
+// Возвращает len(bounds)+1 значений.
+func (r *ReportRepo) CountOpenByAge(ctx context.Context, userID int, bounds []time.Time) ([]int, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	columns := make([]string, 0, len(bounds)+1)
+	for i, bound := range bounds {
+		condition := "tasks.created_at > " + sb.Var(bound)
+		if i > 0 {
+			condition += " AND tasks.created_at <= " + sb.Var(bounds[i-1])
+		}
+		columns = append(columns, fmt.Sprintf("COUNT(*) FILTER (WHERE %s)", condition))
+	}
+	columns = append(columns, "COUNT(*) FILTER (WHERE tasks.created_at <= "+sb.Var(bounds[len(bounds)-1])+")")
+
+	sb.Select(columns...).
+		From(TasksTableName).
+		Where(sb.NotEqual("tasks.status", constant.DoneTaskStatus))
+	whereTaskUser(sb, userID)
+	sql, args := sb.BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	counts := make([]int, len(columns))
+	dest := make([]any, len(counts))
+	for i := range counts {
+		dest[i] = &counts[i]
+	}
+	if err := r.dbPool.QueryRow(ctx, sql, args...).Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// CountByAssignee возвращает число задач каждого исполнителя по статусам, упорядоченное по логину.
+func (r *ReportRepo) CountByAssignee(ctx context.Context, userID int) ([]AssigneeStatusCounts, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(append([]string{"COALESCE(users.login, '')"}, statusCountColumns(sb)...)...).
+		From(TasksTableName).
+		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id")
+	whereTaskUser(sb, userID)
+	sql, args := sb.GroupBy("users.login").
+		OrderBy("users.login").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := r.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]AssigneeStatusCounts, 0)
+	for rows.Next() {
+		var counts AssigneeStatusCounts
+		rowScanErr := rows.Scan(&counts.UserLogin, &counts.Open, &counts.InProgress, &counts.Done)
+		if rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, counts)
+	}
+
+	return res, rows.Err()
+}
+
+// CountByPriority возвращает число задач каждого приоритета по статусам, упорядоченное по приоритету.
+func (r *ReportRepo) CountByPriority(ctx context.Context, userID int) ([]PriorityStatusCounts, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(append([]string{"tasks.priority"}, statusCountColumns(sb)...)...).
+		From(TasksTableName)
+	whereTaskUser(sb, userID)
+	sql, args := sb.GroupBy("tasks.priority").
+		OrderBy("tasks.priority").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := r.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]PriorityStatusCounts, 0)
+	for rows.Next() {
+		var counts PriorityStatusCounts
+		rowScanErr := rows.Scan(&counts.Priority, &counts.Open, &counts.InProgress, &counts.Done)
+		if rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, counts)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *ReportRepo) queryWeekCounts(ctx context.Context, sql string, args []interface{}) ([]WeekCount, error) {
+	rows, err := r.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]WeekCount, 0)
+	for rows.Next() {
+		var weekCount WeekCount
+		if rowScanErr := rows.Scan(&weekCount.Week, &weekCount.Count); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, weekCount)
+	}
+
+	return res, rows.Err()
+}
+
+// statusCountColumns - столбцы с числом задач в статусах OPEN, IN_PROGRESS и DONE в порядке полей StatusCounts.
+func statusCountColumns(sb *sqlbuilder.SelectBuilder) []string {
+	columns := make([]string, 0, len(constant.TaskStatuses))
+	for _, status := range constant.TaskStatuses {
+		columns = append(columns, "COUNT(*) FILTER (WHERE tasks.status = "+sb.Var(status)+")")
+	}
+
+	return columns
+}
+
+func whereTaskUser(sb *sqlbuilder.SelectBuilder, userID int) {
+	if userID != 0 {
+		sb.Where(sb.Equal("tasks.user_id", userID))
+	}
+}
@@ -0,0 +1,237 @@
+package repository
+
+//go:generate mockgen -source=saved_filter_repository.go -destination=mocks/saved_filter_repository_mocks.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romakorinenko/task-manager/internal/constant"
+)
+
+const (
+	SavedFiltersTableName        = "saved_filters"
+	SavedFilterSettingsTableName = "saved_filter_settings"
+)
+
+// ErrFilterNameTaken возвращается Create и Update, если у владельца уже есть фильтр с таким именем.
+var ErrFilterNameTaken = errors.New("saved filter name is already taken")
+
+// SavedFilter - именованный фильтр задач. Visibility - кому доступен фильтр: PRIVATE, SHARED или GLOBAL.
+type SavedFilter struct {
+	ID         int        `db:"id" json:"id"`
+	UserID     int        `db:"user_id" json:"userId"`
+	Name       string     `db:"name" json:"name"`
+	Filter     TaskFilter `db:"filter" json:"filter"`
+	Visibility string     `db:"visibility" json:"visibility"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updatedAt"`
+
+	// Pinned и Notify - настройки фильтра у текущего пользователя, хранятся в SavedFilterSettings.
+	Pinned bool `db:"-" json:"pinned"`
+	Notify bool `db:"-" json:"notify"`
+}
+
+// SavedFilterSettings - настройки фильтра у пользователя: Pinned - показывать на странице задач,
+// Notify - уведомлять о задачах, подходящих под фильтр.
+type SavedFilterSettings struct {
+	FilterID int  `db:"filter_id"`
+	UserID   int  `db:"user_id"`
+	Pinned   bool `db:"pinned"`
+	Notify   bool `db:"notify"`
+}
+
+var (
+	SavedFilterStruct         = sqlbuilder.NewStruct(new(SavedFilter))
+	SavedFilterSettingsStruct = sqlbuilder.NewStruct(new(SavedFilterSettings))
+)
+
+type ISavedFilterRepo interface {
+	Create(ctx context.Context, filter *SavedFilter) error
+	Update(ctx context.Context, filter *SavedFilter) (bool, error)
+	GetByID(ctx context.Context, filterID int) (*SavedFilter, error)
+	GetVisible(ctx context.Context, userID int) ([]SavedFilter, error)
+	DeleteByID(ctx context.Context, filterID int) (bool, error)
+	GetSettings(ctx context.Context, userID int) ([]SavedFilterSettings, error)
+	SaveSettings(ctx context.Context, settings *SavedFilterSettings) error
+	GetNotifySettings(ctx context.Context) ([]SavedFilterSettings, error)
+}
+
+type SavedFilterRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewSavedFilterRepo(dbPool *pgxpool.Pool) *SavedFilterRepo {
+	return &SavedFilterRepo{dbPool: dbPool}
+}
+
+func (s *SavedFilterRepo) Create(ctx context.Context, filter *SavedFilter) error {
+	ID, err := s.generateNextID(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	filter.ID = ID
+	filter.CreatedAt = now
+	filter.UpdatedAt = now
+	sql, args := SavedFilterStruct.InsertInto(SavedFiltersTableName, filter).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err = s.dbPool.Exec(ctx, sql, args...)
+	if isUniqueViolation(err) {
+		return ErrFilterNameTaken
+	}
+
+	return err
+}
+
+// Update сохраняет имя, условия и видимость фильтра. Возвращает false, если фильтра нет.
+func (s *SavedFilterRepo) Update(ctx context.Context, filter *SavedFilter) (bool, error) {
+	filter.UpdatedAt = time.Now()
+	ub := sqlbuilder.Update(SavedFiltersTableName)
+	sql, args := ub.Where(ub.Equal("id", filter.ID)).
+		Set(
+			ub.Assign("name", filter.Name),
+			ub.Assign("filter", filter.Filter),
+			ub.Assign("visibility", filter.Visibility),
+			ub.Assign("updated_at", filter.UpdatedAt),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := s.dbPool.Exec(ctx, sql, args...)
+	if isUniqueViolation(err) {
+		return false, ErrFilterNameTaken
+	} else if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *SavedFilterRepo) GetByID(ctx context.Context, filterID int) (*SavedFilter, error) {
+	sb := SavedFilterStruct.SelectFrom(SavedFiltersTableName)
+	sql, args := sb.Where(sb.Equal("id", filterID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var filter SavedFilter
+	if err := s.dbPool.QueryRow(ctx, sql, args...).Scan(SavedFilterStruct.Addr(&filter)...); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+// GetVisible возвращает фильтры пользователя и фильтры, доступные всем, упорядоченные по имени.
+func (s *SavedFilterRepo) GetVisible(ctx context.Context, userID int) ([]SavedFilter, error) {
+	sb := SavedFilterStruct.SelectFrom(SavedFiltersTableName)
+	sql, args := sb.Where(sb.Or(
+		sb.Equal("user_id", userID),
+		sb.In("visibility", constant.SharedFilterVisibility, constant.GlobalFilterVisibility),
+	)).
+		OrderBy("name", "id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := s.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]SavedFilter, 0)
+	for rows.Next() {
+		var filter SavedFilter
+		if rowScanErr := rows.Scan(SavedFilterStruct.Addr(&filter)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, filter)
+	}
+
+	return res, rows.Err()
+}
+
+// DeleteByID удаляет фильтр вместе с настройками пользователей. Возвращает false, если фильтра нет.
+func (s *SavedFilterRepo) DeleteByID(ctx context.Context, filterID int) (bool, error) {
+	db := SavedFilterStruct.DeleteFrom(SavedFiltersTableName)
+	sql, args := db.Where(db.Equal("id", filterID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := s.dbPool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *SavedFilterRepo) GetSettings(ctx context.Context, userID int) ([]SavedFilterSettings, error) {
+	sb := SavedFilterSettingsStruct.SelectFrom(SavedFilterSettingsTableName)
+	sql, args := sb.Where(sb.Equal("user_id", userID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return s.querySettings(ctx, sql, args)
+}
+
+// SaveSettings создаёт или заменяет настройки фильтра у пользователя.
+func (s *SavedFilterRepo) SaveSettings(ctx context.Context, settings *SavedFilterSettings) error {
+	sql, args := SavedFilterSettingsStruct.InsertInto(SavedFilterSettingsTableName, settings).
+		SQL("ON CONFLICT (filter_id, user_id) DO UPDATE SET pinned = EXCLUDED.pinned, notify = EXCLUDED.notify").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := s.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetNotifySettings возвращает настройки всех пользователей, подписанных на уведомления по фильтрам.
+func (s *SavedFilterRepo) GetNotifySettings(ctx context.Context) ([]SavedFilterSettings, error) {
+	sb := SavedFilterSettingsStruct.SelectFrom(SavedFilterSettingsTableName)
+	sql, args := sb.Where("notify").
+		OrderBy("filter_id", "user_id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return s.querySettings(ctx, sql, args)
+}
+
+func (s *SavedFilterRepo) querySettings(ctx context.Context,
+	sql string,
+	args []interface{},
+) ([]SavedFilterSettings, error) {
+	rows, err := s.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]SavedFilterSettings, 0)
+	for rows.Next() {
+		var settings SavedFilterSettings
+		if rowScanErr := rows.Scan(SavedFilterSettingsStruct.Addr(&settings)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, settings)
+	}
+
+	return res, rows.Err()
+}
+
+func (s *SavedFilterRepo) generateNextID(ctx context.Context) (int, error) {
+	rows, err := s.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "saved_filters_sequence"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next id in %s", "saved_filters_sequence")
+}
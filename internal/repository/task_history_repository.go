@@ -0,0 +1,75 @@
+package repository
+
+//go:generate mockgen -source=task_history_repository.go -destination=mocks/task_history_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TaskHistoryTableName = "task_history"
+
+type TaskHistory struct {
+	ID        int       `db:"id" json:"id"`
+	TaskID    int       `db:"task_id" json:"taskId"`
+	UserID    int       `db:"user_id" json:"userId"`
+	Action    string    `db:"action" json:"action"`
+	OldValue  string    `db:"old_value" json:"oldValue"`
+	NewValue  string    `db:"new_value" json:"newValue"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+var TaskHistoryStruct = sqlbuilder.NewStruct(new(TaskHistory))
+
+type ITaskHistoryRepo interface {
+	Create(ctx context.Context, history *TaskHistory) error
+	GetByTaskID(ctx context.Context, taskID int) ([]TaskHistory, error)
+}
+
+type TaskHistoryRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTaskHistoryRepo(dbPool *pgxpool.Pool) *TaskHistoryRepo {
+	return &TaskHistoryRepo{dbPool: dbPool}
+}
+
+func (t *TaskHistoryRepo) Create(ctx context.Context, history *TaskHistory) error {
+	history.CreatedAt = time.Now()
+
+	ib := sqlbuilder.NewInsertBuilder()
+	sql, args := ib.InsertInto(TaskHistoryTableName).
+		Cols("task_id", "user_id", "action", "old_value", "new_value", "created_at").
+		Values(history.TaskID, history.UserID, history.Action, history.OldValue, history.NewValue, history.CreatedAt).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := t.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (t *TaskHistoryRepo) GetByTaskID(ctx context.Context, taskID int) ([]TaskHistory, error) {
+	sb := TaskHistoryStruct.SelectFrom(TaskHistoryTableName)
+	sql, args := sb.Where(sb.Equal("task_id", taskID)).
+		OrderBy("created_at", "id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TaskHistory, 0)
+	for rows.Next() {
+		var history TaskHistory
+		if rowScanErr := rows.Scan(TaskHistoryStruct.Addr(&history)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, history)
+	}
+
+	return res, rows.Err()
+}
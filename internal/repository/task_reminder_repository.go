@@ -0,0 +1,39 @@
+package repository
+
+//go:generate mockgen -source=task_reminder_repository.go -destination=mocks/task_reminder_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TaskDueRemindersTableName = "task_due_reminders"
+
+type ITaskReminderRepo interface {
+	Create(ctx context.Context, taskID int, dueAt time.Time) (bool, error)
+}
+
+type TaskReminderRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTaskReminderRepo(dbPool *pgxpool.Pool) *TaskReminderRepo {
+	return &TaskReminderRepo{dbPool: dbPool}
+}
+
+// Create отмечает, что о сроке dueAt задачи taskID напомнили, и сообщает, не было ли напоминания раньше.
+// После переноса срока напоминание о новом сроке создаётся заново.
+func (t *TaskReminderRepo) Create(ctx context.Context, taskID int, dueAt time.Time) (bool, error) {
+	tag, err := conn(ctx, t.dbPool).Exec(ctx,
+		`INSERT INTO task_due_reminders (task_id, due_at, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (task_id, due_at) DO NOTHING`,
+		taskID, dueAt, time.Now(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
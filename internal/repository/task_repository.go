@@ -7,15 +7,30 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romakorinenko/task-manager/internal/constant"
 )
 
 const TasksTableName = "tasks"
 
+// taskLabelsColumn выбирает метки задачи массивом, чтобы не делать отдельный запрос на каждую задачу.
+const taskLabelsColumn = "ARRAY(SELECT task_labels.label FROM task_labels " +
+	"WHERE task_labels.task_id = tasks.id ORDER BY task_labels.label) AS labels"
+
+// taskWithLoginColumns - столбцы выборки TaskWithLogin в порядке полей структуры.
+var taskWithLoginColumns = []string{
+	"tasks.id", "tasks.title", "tasks.description", "tasks.priority", "tasks.status",
+	"tasks.created_at", "tasks.updated_at", "users.login", "tasks.version", taskLabelsColumn, "tasks.due_at",
+}
+
+// ErrVersionConflict возвращается Update, если задача изменилась после чтения.
+var ErrVersionConflict = errors.New("task version conflict")
+
 type Task struct {
 	ID          int       `db:"id" json:"id"`
 	Title       string    `db:"title" json:"title"`
@@ -25,17 +40,59 @@ type Task struct {
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
 	UserID      int       `db:"user_id" json:"userId,omitempty"`
+	// Version увеличивается при каждом изменении задачи и используется для оптимистичной блокировки.
+	Version int `db:"version" json:"version"`
+	// DueAt - срок выполнения в UTC, nil - срок не задан.
+	DueAt *time.Time `db:"due_at" json:"dueAt,omitempty"`
+
+	DescriptionHTML string `db:"-" json:"descriptionHtml,omitempty"`
+	DescriptionText string `db:"-" json:"descriptionText,omitempty"`
 }
 
 type TaskWithLogin struct {
-	ID          int       `db:"id"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Priority    int       `db:"priority"`
-	Status      string    `db:"status"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
-	UserLogin   string    `db:"login"`
+	ID          int        `db:"id"`
+	Title       string     `db:"title"`
+	Description string     `db:"description"`
+	Priority    int        `db:"priority"`
+	Status      string     `db:"status"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	UserLogin   string     `db:"login"`
+	Version     int        `db:"version"`
+	Labels      []string   `db:"labels"`
+	DueAt       *time.Time `db:"due_at"`
+}
+
+// TaskFilter - условия отбора задач. Пустые поля не участвуют в отборе.
+type TaskFilter struct {
+	Status    string `json:"status,omitempty" example:"OPEN"`
+	Priority  int    `json:"priority,omitempty" example:"2"`
+	UserLogin string `json:"userLogin,omitempty" example:"user"`
+	// Labels - метки, которые должны быть у задачи все одновременно.
+	Labels []string `json:"labels,omitempty" example:"backend"`
+	// Text ищется без учёта регистра в названии и описании задачи.
+	Text string `json:"text,omitempty" example:"релиз"`
+	// Sort - столбец из TaskSortColumns, с префиксом "-" - по убыванию. По умолчанию задачи упорядочены по id.
+	Sort string `json:"sort,omitempty" example:"-updatedAt"`
+	// UserID ограничивает отбор задачами пользователя; задаётся сервером, а не клиентом.
+	UserID int `json:"-"`
+}
+
+// IsEmpty сообщает, что фильтр не задаёт ни одного условия. Порядок сортировки условием не считается.
+func (f *TaskFilter) IsEmpty() bool {
+	return f.Status == "" && f.Priority == 0 && f.UserLogin == "" && len(f.Labels) == 0 && f.Text == "" &&
+		f.UserID == 0
+}
+
+// TaskSortColumns - допустимые значения TaskFilter.Sort и соответствующие им столбцы.
+var TaskSortColumns = map[string]string{
+	"id":        "tasks.id",
+	"title":     "tasks.title",
+	"priority":  "tasks.priority",
+	"status":    "tasks.status",
+	"createdAt": "tasks.created_at",
+	"updatedAt": "tasks.updated_at",
+	"dueAt":     "tasks.due_at",
 }
 
 var (
@@ -51,6 +108,11 @@ type ITaskRepo interface {
 	GetByUserLogin(ctx context.Context, userLogin string) ([]Task, error)
 	GetByStatus(ctx context.Context, status string) ([]Task, error)
 	GetByPriority(ctx context.Context, priority int) ([]Task, error)
+	GetByFilter(ctx context.Context, filter *TaskFilter) ([]Task, error)
+	MatchesFilter(ctx context.Context, taskID int, filter *TaskFilter) (bool, error)
+	IterateWithLogin(ctx context.Context, filter *TaskFilter, fn func(task *TaskWithLogin) error) error
+	GetWithDueDate(ctx context.Context, userID int) ([]TaskWithLogin, error)
+	GetDueBetween(ctx context.Context, from, to time.Time) ([]Task, error)
 	GetTasksWithLogin(ctx context.Context) ([]TaskWithLogin, error)
 	GetTasksWithLoginByUserID(ctx context.Context, userID int) ([]TaskWithLogin, error)
 	GetTaskWithLoginByID(ctx context.Context, taskID int) (*TaskWithLogin, error)
@@ -71,36 +133,48 @@ func (t *TaskRepo) Create(ctx context.Context, task *Task) (int, error) {
 	}
 
 	task.ID = ID
+	task.Version = 1
 	sql, args := TaskStruct.InsertInto(TasksTableName, task).
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
 
-	row := t.dbPool.QueryRow(ctx, sql, args...)
+	row := conn(ctx, t.dbPool).QueryRow(ctx, sql, args...)
 	rowScanErr := row.Scan()
 	if rowScanErr != nil && !errors.Is(rowScanErr, pgx.ErrNoRows) {
-		return 0, err
+		return 0, rowScanErr
 	}
 
 	return task.ID, nil
 }
 
+// Update сохраняет задачу, если её версия в базе совпадает с task.Version, и увеличивает версию.
+// Если задачу успели изменить или удалить, возвращает ErrVersionConflict.
 func (t *TaskRepo) Update(ctx context.Context, task *Task) error {
-	task.UpdatedAt = time.Now()
+	updatedAt := time.Now()
 
 	ub := sqlbuilder.Update(TasksTableName)
-	sql, args := ub.Where(ub.Equal("id", task.ID)).
+	sql, args := ub.Where(ub.Equal("id", task.ID), ub.Equal("version", task.Version)).
 		Set(
 			ub.Assign("title", task.Title),
 			ub.Assign("description", task.Description),
 			ub.Assign("priority", task.Priority),
 			ub.Assign("status", task.Status),
-			ub.Assign("updated_at", task.UpdatedAt),
+			ub.Assign("user_id", task.UserID),
+			ub.Assign("due_at", task.DueAt),
+			ub.Assign("updated_at", updatedAt),
+			ub.Incr("version"),
 		).
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
 
-	_, err := t.dbPool.Exec(ctx, sql, args...)
+	tag, err := conn(ctx, t.dbPool).Exec(ctx, sql, args...)
 	if err != nil {
 		return err
 	}
+	if tag.RowsAffected() == 0 {
+		return ErrVersionConflict
+	}
+
+	task.UpdatedAt = updatedAt
+	task.Version++
 
 	return nil
 }
@@ -110,7 +184,7 @@ func (t *TaskRepo) DeleteByID(ctx context.Context, taskID int) error {
 	sql, args := db.Where(db.Equal("id", taskID)).
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
 
-	_, err := t.dbPool.Exec(ctx, sql, args...)
+	_, err := conn(ctx, t.dbPool).Exec(ctx, sql, args...)
 	return err
 }
 
@@ -122,7 +196,7 @@ func (t *TaskRepo) GetByID(ctx context.Context, taskID int) (*Task, error) {
 
 	var task Task
 	rowScanErr := row.Scan(TaskStruct.Addr(&task)...)
-	if rowScanErr != nil && errors.Is(rowScanErr, pgx.ErrNoRows) {
+	if rowScanErr != nil {
 		return nil, rowScanErr
 	}
 
@@ -156,15 +230,7 @@ func (t *TaskRepo) GetByUserLogin(ctx context.Context, userLogin string) ([]Task
 
 func (t *TaskRepo) GetTasksWithLogin(ctx context.Context) ([]TaskWithLogin, error) {
 	sb := sqlbuilder.NewSelectBuilder()
-	sql, _ := sb.Select("tasks.id",
-		"tasks.title",
-		"tasks.description",
-		"tasks.priority",
-		"tasks.status",
-		"tasks.created_at",
-		"tasks.updated_at",
-		"users.login",
-	).
+	sql, _ := sb.Select(taskWithLoginColumns...).
 		From("tasks").
 		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id").
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
@@ -189,16 +255,7 @@ func (t *TaskRepo) GetTasksWithLogin(ctx context.Context) ([]TaskWithLogin, erro
 
 func (t *TaskRepo) GetTasksWithLoginByUserID(ctx context.Context, userID int) ([]TaskWithLogin, error) {
 	sb := sqlbuilder.NewSelectBuilder()
-	sql, args := sb.Select(
-		"tasks.id",
-		"tasks.title",
-		"tasks.description",
-		"tasks.priority",
-		"tasks.status",
-		"tasks.created_at",
-		"tasks.updated_at",
-		"users.login",
-	).
+	sql, args := sb.Select(taskWithLoginColumns...).
 		From(TasksTableName).
 		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id").
 		Where(sb.Equal("users.id", userID)).
@@ -225,10 +282,7 @@ func (t *TaskRepo) GetTasksWithLoginByUserID(ctx context.Context, userID int) ([
 
 func (t *TaskRepo) GetTaskWithLoginByID(ctx context.Context, taskID int) (*TaskWithLogin, error) {
 	sb := sqlbuilder.NewSelectBuilder()
-	sql, args := sb.Select(
-		"tasks.id", "tasks.title", "tasks.description", "tasks.priority", "tasks.status",
-		"tasks.created_at", "tasks.updated_at", "users.login",
-	).
+	sql, args := sb.Select(taskWithLoginColumns...).
 		From(TasksTableName).
 		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id").
 		Where(sb.Equal("tasks.id", taskID)).
@@ -238,7 +292,7 @@ func (t *TaskRepo) GetTaskWithLoginByID(ctx context.Context, taskID int) (*TaskW
 
 	var task TaskWithLogin
 	rowScanErr := row.Scan(TaskWithLoginStruct.Addr(&task)...)
-	if rowScanErr != nil && errors.Is(rowScanErr, pgx.ErrNoRows) {
+	if rowScanErr != nil {
 		return nil, rowScanErr
 	}
 
@@ -261,8 +315,8 @@ func (t *TaskRepo) GetByStatus(ctx context.Context, status string) ([]Task, erro
 	for rows.Next() {
 		var task Task
 		if rowScanErr := rows.Scan(TaskStruct.Addr(&task)...); rowScanErr != nil {
-			slog.Info(err.Error())
-			return nil, err
+			slog.ErrorContext(ctx, "cannot scan task", slog.Any("error", rowScanErr))
+			return nil, rowScanErr
 		}
 
 		res = append(res, task)
@@ -287,8 +341,8 @@ func (t *TaskRepo) GetByPriority(ctx context.Context, priority int) ([]Task, err
 	for rows.Next() {
 		var task Task
 		if rowScanErr := rows.Scan(TaskStruct.Addr(&task)...); rowScanErr != nil {
-			slog.Info(err.Error())
-			return nil, err
+			slog.ErrorContext(ctx, "cannot scan task", slog.Any("error", rowScanErr))
+			return nil, rowScanErr
 		}
 
 		res = append(res, task)
@@ -297,6 +351,195 @@ func (t *TaskRepo) GetByPriority(ctx context.Context, priority int) ([]Task, err
 	return res, nil
 }
 
+func (t *TaskRepo) GetByFilter(ctx context.Context, filter *TaskFilter) ([]Task, error) {
+	sb := TaskStruct.SelectFrom(TasksTableName)
+	sql, args := applyTaskFilter(sb, filter).
+		OrderBy(taskFilterOrder(filter)...).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Task, 0)
+	for rows.Next() {
+		var task Task
+		if rowScanErr := rows.Scan(TaskStruct.Addr(&task)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, task)
+	}
+
+	return res, rows.Err()
+}
+
+// MatchesFilter сообщает, подходит ли задача под фильтр. Удалённая задача не подходит ни под какой фильтр.
+func (t *TaskRepo) MatchesFilter(ctx context.Context, taskID int, filter *TaskFilter) (bool, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("tasks.id").
+		From(TasksTableName).
+		Where(sb.Equal("tasks.id", taskID))
+	sql, args := applyTaskFilter(sb, filter).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var id int
+	err := t.dbPool.QueryRow(ctx, sql, args...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// IterateWithLogin передаёт в fn задачи по фильтру с логинами исполнителей по одной, не загружая их все в память.
+// Ошибка fn прерывает чтение и возвращается.
+func (t *TaskRepo) IterateWithLogin(ctx context.Context,
+	filter *TaskFilter,
+	fn func(task *TaskWithLogin) error,
+) error {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(taskWithLoginColumns...).
+		From(TasksTableName).
+		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id")
+	sql, args := applyTaskFilter(sb, filter).
+		OrderBy(taskFilterOrder(filter)...).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task TaskWithLogin
+		if rowScanErr := rows.Scan(TaskWithLoginStruct.Addr(&task)...); rowScanErr != nil {
+			return rowScanErr
+		}
+		if fnErr := fn(&task); fnErr != nil {
+			return fnErr
+		}
+	}
+
+	return rows.Err()
+}
+
+// GetWithDueDate возвращает задачи со сроком выполнения, назначенные пользователю userID, и задачи, за которыми
+// он следит. userID 0 - задачи всех пользователей.
+func (t *TaskRepo) GetWithDueDate(ctx context.Context, userID int) ([]TaskWithLogin, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(taskWithLoginColumns...).
+		From(TasksTableName).
+		JoinWithOption(sqlbuilder.LeftJoin, "users", "tasks.user_id = users.id").
+		Where(sb.IsNotNull("tasks.due_at"))
+	if userID != 0 {
+		sb.Where(sb.Or(
+			sb.Equal("tasks.user_id", userID),
+			"tasks.id IN (SELECT task_watchers.task_id FROM task_watchers WHERE task_watchers.user_id = "+
+				sb.Var(userID)+")",
+		))
+	}
+	sql, args := sb.OrderBy("tasks.due_at", "tasks.id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TaskWithLogin, 0)
+	for rows.Next() {
+		var task TaskWithLogin
+		if rowScanErr := rows.Scan(TaskWithLoginStruct.Addr(&task)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, task)
+	}
+
+	return res, rows.Err()
+}
+
+// GetDueBetween возвращает невыполненные задачи с исполнителем, срок которых наступает после from и не позже to.
+func (t *TaskRepo) GetDueBetween(ctx context.Context, from, to time.Time) ([]Task, error) {
+	sb := TaskStruct.SelectFrom(TasksTableName)
+	sql, args := sb.Where(
+		sb.GreaterThan("due_at", from),
+		sb.LessEqualThan("due_at", to),
+		sb.NotEqual("status", constant.DoneTaskStatus),
+		sb.IsNotNull("user_id"),
+	).
+		OrderBy("due_at", "id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Task, 0)
+	for rows.Next() {
+		var task Task
+		if rowScanErr := rows.Scan(TaskStruct.Addr(&task)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, task)
+	}
+
+	return res, rows.Err()
+}
+
+func applyTaskFilter(sb *sqlbuilder.SelectBuilder, filter *TaskFilter) *sqlbuilder.SelectBuilder {
+	if filter.Status != "" {
+		sb.Where(sb.Equal("tasks.status", filter.Status))
+	}
+	if filter.Priority != 0 {
+		sb.Where(sb.Equal("tasks.priority", filter.Priority))
+	}
+	if filter.UserLogin != "" {
+		sb.Where("tasks.user_id IN (SELECT users.id FROM users WHERE users.login = " + sb.Var(filter.UserLogin) + ")")
+	}
+	for _, label := range filter.Labels {
+		sb.Where("tasks.id IN (SELECT task_labels.task_id FROM task_labels WHERE task_labels.label = " +
+			sb.Var(label) + ")")
+	}
+	if filter.Text != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Text) + "%"
+		sb.Where(sb.Or(sb.ILike("tasks.title", pattern), sb.ILike("tasks.description", pattern)))
+	}
+	if filter.UserID != 0 {
+		sb.Where(sb.Equal("tasks.user_id", filter.UserID))
+	}
+
+	return sb
+}
+
+// likeEscaper экранирует символы шаблона LIKE, чтобы текст фильтра искался буквально.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// taskFilterOrder возвращает порядок выборки по фильтру. Неизвестный столбец сортировки игнорируется,
+// задачи без срока при сортировке по сроку идут последними.
+func taskFilterOrder(filter *TaskFilter) []string {
+	column, descending := strings.CutPrefix(filter.Sort, "-")
+	orderColumn, ok := TaskSortColumns[column]
+	if !ok {
+		return []string{"tasks.id"}
+	}
+
+	direction := " ASC"
+	if descending {
+		direction = " DESC"
+	}
+	if orderColumn == "tasks.id" {
+		return []string{orderColumn + direction}
+	}
+
+	return []string{orderColumn + direction + " NULLS LAST", "tasks.id"}
+}
+
 func (t *TaskRepo) generateNextTaskID(ctx context.Context) (int, error) {
 	rows, err := t.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "tasks_sequence"))
 	if err != nil {
@@ -0,0 +1,90 @@
+package repository
+
+//go:generate mockgen -source=task_snapshot_repository.go -destination=mocks/task_snapshot_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TaskStatusSnapshotsTableName = "task_status_snapshots"
+
+// DailyStatusCount - число задач в статусе Status на конец дня Day (по последнему снимку за день).
+type DailyStatusCount struct {
+	Day    time.Time
+	Status string
+	Tasks  int
+}
+
+type ITaskSnapshotRepo interface {
+	SaveDaily(ctx context.Context, day time.Time) error
+	GetDaily(ctx context.Context, userID int, from, to time.Time) ([]DailyStatusCount, error)
+}
+
+type TaskSnapshotRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTaskSnapshotRepo(dbPool *pgxpool.Pool) *TaskSnapshotRepo {
+	return &TaskSnapshotRepo{dbPool: dbPool}
+}
+
+// SaveDaily заменяет снимок за день day текущим числом задач каждого исполнителя в каждом статусе.
+// Задачи без исполнителя учитываются с user_id 0. Удаление и запись нужно выполнять в одной транзакции.
+func (t *TaskSnapshotRepo) SaveDaily(ctx context.Context, day time.Time) error {
+	db := sqlbuilder.NewDeleteBuilder()
+	sql, args := db.DeleteFrom(TaskStatusSnapshotsTableName).
+		Where(db.Equal("day", day)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+	if _, err := conn(ctx, t.dbPool).Exec(ctx, sql, args...); err != nil {
+		return err
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(sb.Var(day)+"::date", "COALESCE(tasks.user_id, 0)", "tasks.status", "COUNT(*)").
+		From(TasksTableName).
+		GroupBy("tasks.user_id", "tasks.status")
+	ib := sqlbuilder.NewInsertBuilder()
+	sql, args = ib.InsertInto(TaskStatusSnapshotsTableName).
+		Cols("day", "user_id", "status", "tasks").
+		SQL(ib.Var(sb)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := conn(ctx, t.dbPool).Exec(ctx, sql, args...)
+	return err
+}
+
+// GetDaily возвращает число задач по статусам за каждый день с from по to включительно, за который есть снимок,
+// упорядоченное по дню. userID ограничивает подсчёт задачами пользователя, 0 - задачи всех пользователей.
+func (t *TaskSnapshotRepo) GetDaily(ctx context.Context, userID int, from, to time.Time) ([]DailyStatusCount, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("day", "status", "SUM(tasks)").
+		From(TaskStatusSnapshotsTableName).
+		Where(sb.Between("day", from, to))
+	if userID != 0 {
+		sb.Where(sb.Equal("user_id", userID))
+	}
+	sql, args := sb.GroupBy("day", "status").
+		OrderBy("day", "status").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]DailyStatusCount, 0)
+	for rows.Next() {
+		var count DailyStatusCount
+		if rowScanErr := rows.Scan(&count.Day, &count.Status, &count.Tasks); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, count)
+	}
+
+	return res, rows.Err()
+}
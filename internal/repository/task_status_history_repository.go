@@ -0,0 +1,52 @@
+package repository
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/task_status_history_repository_mocks.go
+
+import (
+	"context"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TaskStatusHistoryTableName = "task_status_history"
+
+// TaskStatusChange - смена статуса задачи. EventID - событие outbox, из которого получена запись; OldStatus пуст
+// у созданной задачи.
+type TaskStatusChange struct {
+	EventID   int       `db:"event_id" json:"eventId"`
+	TaskID    int       `db:"task_id" json:"taskId"`
+	OldStatus string    `db:"old_status" json:"oldStatus"`
+	NewStatus string    `db:"new_status" json:"newStatus"`
+	ChangedAt time.Time `db:"changed_at" json:"changedAt"`
+}
+
+var TaskStatusChangeStruct = sqlbuilder.NewStruct(new(TaskStatusChange))
+
+type ITaskStatusHistoryRepo interface {
+	Add(ctx context.Context, change *TaskStatusChange) error
+}
+
+type TaskStatusHistoryRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTaskStatusHistoryRepo(dbPool *pgxpool.Pool) *TaskStatusHistoryRepo {
+	return &TaskStatusHistoryRepo{dbPool: dbPool}
+}
+
+// Add сохраняет смену статуса. Повторная запись того же события и запись для уже удалённой задачи
+// ничего не делают.
+func (t *TaskStatusHistoryRepo) Add(ctx context.Context, change *TaskStatusChange) error {
+	sql, args := TaskStatusChangeStruct.InsertInto(TaskStatusHistoryTableName, change).
+		SQL("ON CONFLICT (event_id) DO NOTHING").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := t.dbPool.Exec(ctx, sql, args...)
+	if isForeignKeyViolation(err) {
+		return nil
+	}
+
+	return err
+}
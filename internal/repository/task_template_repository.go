@@ -0,0 +1,172 @@
+package repository
+
+//go:generate mockgen -source=task_template_repository.go -destination=mocks/task_template_repository_mocks.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const TaskTemplatesTableName = "task_templates"
+
+// ErrTemplateNameTaken возвращается Create и Update, если шаблон с таким именем уже есть.
+var ErrTemplateNameTaken = errors.New("task template name is already taken")
+
+// TaskTemplate - заготовка задачи. Title, Description и пункты Checklist могут содержать подстановки {{name}},
+// которые заполняются при создании задачи. Visibility - кому доступен шаблон: ALL или ADMIN.
+type TaskTemplate struct {
+	ID          int       `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Title       string    `db:"title" json:"title"`
+	Description string    `db:"description" json:"description"`
+	Priority    int       `db:"priority" json:"priority"`
+	Labels      []string  `db:"labels" json:"labels"`
+	Checklist   []string  `db:"checklist" json:"checklist"`
+	Visibility  string    `db:"visibility" json:"visibility"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+var TaskTemplateStruct = sqlbuilder.NewStruct(new(TaskTemplate))
+
+type ITaskTemplateRepo interface {
+	Create(ctx context.Context, template *TaskTemplate) error
+	Update(ctx context.Context, template *TaskTemplate) (bool, error)
+	GetByID(ctx context.Context, templateID int) (*TaskTemplate, error)
+	GetByVisibility(ctx context.Context, visibilities []string) ([]TaskTemplate, error)
+	DeleteByID(ctx context.Context, templateID int) (bool, error)
+}
+
+type TaskTemplateRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTaskTemplateRepo(dbPool *pgxpool.Pool) *TaskTemplateRepo {
+	return &TaskTemplateRepo{dbPool: dbPool}
+}
+
+func (t *TaskTemplateRepo) Create(ctx context.Context, template *TaskTemplate) error {
+	ID, err := t.generateNextID(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	template.ID = ID
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	sql, args := TaskTemplateStruct.InsertInto(TaskTemplatesTableName, template).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err = t.dbPool.Exec(ctx, sql, args...)
+	return templateNameErr(err)
+}
+
+// Update сохраняет поля шаблона. Возвращает false, если шаблона нет.
+func (t *TaskTemplateRepo) Update(ctx context.Context, template *TaskTemplate) (bool, error) {
+	template.UpdatedAt = time.Now()
+	ub := sqlbuilder.Update(TaskTemplatesTableName)
+	sql, args := ub.Where(ub.Equal("id", template.ID)).
+		Set(
+			ub.Assign("name", template.Name),
+			ub.Assign("title", template.Title),
+			ub.Assign("description", template.Description),
+			ub.Assign("priority", template.Priority),
+			ub.Assign("labels", template.Labels),
+			ub.Assign("checklist", template.Checklist),
+			ub.Assign("visibility", template.Visibility),
+			ub.Assign("updated_at", template.UpdatedAt),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := t.dbPool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, templateNameErr(err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (t *TaskTemplateRepo) GetByID(ctx context.Context, templateID int) (*TaskTemplate, error) {
+	sb := TaskTemplateStruct.SelectFrom(TaskTemplatesTableName)
+	sql, args := sb.Where(sb.Equal("id", templateID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var template TaskTemplate
+	if err := t.dbPool.QueryRow(ctx, sql, args...).Scan(TaskTemplateStruct.Addr(&template)...); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// GetByVisibility возвращает шаблоны с одной из видимостей visibilities, упорядоченные по имени.
+func (t *TaskTemplateRepo) GetByVisibility(ctx context.Context, visibilities []string) ([]TaskTemplate, error) {
+	sb := TaskTemplateStruct.SelectFrom(TaskTemplatesTableName)
+	sql, args := sb.Where(sb.In("visibility", sqlbuilder.Flatten(visibilities)...)).
+		OrderBy("name").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := t.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]TaskTemplate, 0)
+	for rows.Next() {
+		var template TaskTemplate
+		if rowScanErr := rows.Scan(TaskTemplateStruct.Addr(&template)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, template)
+	}
+
+	return res, rows.Err()
+}
+
+// DeleteByID удаляет шаблон. Задачи, созданные по шаблону, остаются. Возвращает false, если шаблона нет.
+func (t *TaskTemplateRepo) DeleteByID(ctx context.Context, templateID int) (bool, error) {
+	db := TaskTemplateStruct.DeleteFrom(TaskTemplatesTableName)
+	sql, args := db.Where(db.Equal("id", templateID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := t.dbPool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (t *TaskTemplateRepo) generateNextID(ctx context.Context) (int, error) {
+	rows, err := t.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", "task_templates_sequence"))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next id in %s", "task_templates_sequence")
+}
+
+// templateNameErr заменяет нарушение уникальности имени шаблона на ErrTemplateNameTaken.
+func templateNameErr(err error) error {
+	if isUniqueViolation(err) {
+		return ErrTemplateNameTaken
+	}
+
+	return err
+}
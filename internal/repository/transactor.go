@@ -0,0 +1,69 @@
+package repository
+
+//go:generate mockgen -source=transactor.go -destination=mocks/transactor_mocks.go
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// ITransactor выполняет несколько операций репозиториев в одной транзакции.
+type ITransactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Transactor struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTransactor(dbPool *pgxpool.Pool) *Transactor {
+	return &Transactor{dbPool: dbPool}
+}
+
+// WithinTx открывает транзакцию и передаёт её в fn через контекст. Транзакция фиксируется, если fn
+// завершилась без ошибки, иначе откатывается. Репозитории, поддерживающие транзакции, берут её из контекста.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return pgx.BeginFunc(ctx, t.dbPool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// conn возвращает транзакцию из контекста, если она открыта через WithinTx, иначе пул соединений.
+func conn(ctx context.Context, dbPool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return dbPool
+}
+
+const (
+	// uniqueViolationCode - код ошибки PostgreSQL при нарушении ограничения UNIQUE.
+	uniqueViolationCode = "23505"
+	// foreignKeyViolationCode - код ошибки PostgreSQL при нарушении внешнего ключа.
+	foreignKeyViolationCode = "23503"
+)
+
+// isUniqueViolation сообщает, что запрос нарушил ограничение UNIQUE.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// isForeignKeyViolation сообщает, что запрос сослался на отсутствующую строку, например на удалённую задачу.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/huandu/go-sqlbuilder"
@@ -22,6 +23,10 @@ type User struct {
 	Role      string    `db:"role" json:"role"`
 	Password  string    `db:"password" json:"password,omitempty"`
 	Active    bool      `db:"active" json:"active,omitempty"`
+	// Email - адрес для уведомлений, пустой - письма не отправляются.
+	Email string `db:"email" json:"email,omitempty"`
+	// EmailDigest - отправлять уведомления раз в день одной сводкой вместо отдельных писем.
+	EmailDigest bool `db:"email_digest" json:"emailDigest,omitempty"`
 }
 
 var UserStruct = sqlbuilder.NewStruct(new(User))
@@ -30,7 +35,9 @@ type IUserRepo interface {
 	Create(ctx context.Context, user *User) *User
 	BlockByID(ctx context.Context, userID string) bool
 	GetByLogin(ctx context.Context, userLogin string) (*User, error)
-	GetAll(ctx context.Context) []User
+	GetByID(ctx context.Context, userID int) (*User, error)
+	UpdateEmailSettings(ctx context.Context, userID int, email string, digest bool) error
+	GetAll(ctx context.Context) ([]User, error)
 }
 
 type UserRepo struct {
@@ -44,6 +51,7 @@ func NewUserRepo(dbPool *pgxpool.Pool) *UserRepo {
 func (u *UserRepo) Create(ctx context.Context, user *User) *User {
 	ID, err := u.generateNextUserID(ctx)
 	if err != nil {
+		slog.ErrorContext(ctx, "cannot generate user id", slog.Any("error", err))
 		return nil
 	}
 	user.ID = ID
@@ -56,6 +64,7 @@ func (u *UserRepo) Create(ctx context.Context, user *User) *User {
 	row := u.dbPool.QueryRow(ctx, sql, args...)
 	rowScanErr := row.Scan()
 	if rowScanErr != nil && !errors.Is(rowScanErr, pgx.ErrNoRows) {
+		slog.ErrorContext(ctx, "cannot create user", slog.String("login", user.Login), slog.Any("error", rowScanErr))
 		return nil
 	}
 
@@ -68,9 +77,12 @@ func (u *UserRepo) BlockByID(ctx context.Context, userID string) bool {
 		Set(ub.Assign("active", false)).
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
 
-	_, err := u.dbPool.Exec(ctx, sql, args...)
+	if _, err := u.dbPool.Exec(ctx, sql, args...); err != nil {
+		slog.ErrorContext(ctx, "cannot block user", slog.String("userId", userID), slog.Any("error", err))
+		return false
+	}
 
-	return err == nil
+	return true
 }
 
 func (u *UserRepo) GetByLogin(ctx context.Context, userLogin string) (*User, error) {
@@ -87,28 +99,54 @@ func (u *UserRepo) GetByLogin(ctx context.Context, userLogin string) (*User, err
 	return &user, nil
 }
 
-func (u *UserRepo) GetAll(ctx context.Context) []User {
+func (u *UserRepo) GetByID(ctx context.Context, userID int) (*User, error) {
+	sb := UserStruct.SelectFrom(UsersTableName)
+	sql, args := sb.Where(sb.Equal("id", userID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+	row := u.dbPool.QueryRow(ctx, sql, args...)
+
+	var user User
+	if err := row.Scan(UserStruct.Addr(&user)...); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (u *UserRepo) UpdateEmailSettings(ctx context.Context, userID int, email string, digest bool) error {
+	ub := sqlbuilder.Update(UsersTableName)
+	sql, args := ub.Where(ub.Equal("id", userID)).
+		Set(
+			ub.Assign("email", email),
+			ub.Assign("email_digest", digest),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := u.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (u *UserRepo) GetAll(ctx context.Context) ([]User, error) {
 	sql, _ := UserStruct.SelectFrom(UsersTableName).
 		OrderBy("id").
 		BuildWithFlavor(sqlbuilder.PostgreSQL)
 
 	rows, err := u.dbPool.Query(ctx, sql)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	defer rows.Close()
 
 	res := make([]User, 0)
 	for rows.Next() {
 		var user User
-		rowScanErr := rows.Scan(UserStruct.Addr(&user)...)
-		if rowScanErr != nil {
-			return nil
+		if rowScanErr := rows.Scan(UserStruct.Addr(&user)...); rowScanErr != nil {
+			return nil, rowScanErr
 		}
 		res = append(res, user)
 	}
 
-	return res
+	return res, rows.Err()
 }
 
 func (u *UserRepo) generateNextUserID(ctx context.Context) (int, error) {
@@ -0,0 +1,74 @@
+package repository
+
+//go:generate mockgen -source=watcher_repository.go -destination=mocks/watcher_repository_mocks.go
+
+import (
+	"context"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const WatchersTableName = "task_watchers"
+
+type IWatcherRepo interface {
+	Add(ctx context.Context, taskID, userID int) error
+	Delete(ctx context.Context, taskID, userID int) error
+	GetUserIDs(ctx context.Context, taskID int) ([]int, error)
+}
+
+type WatcherRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewWatcherRepo(dbPool *pgxpool.Pool) *WatcherRepo {
+	return &WatcherRepo{dbPool: dbPool}
+}
+
+// Add подписывает пользователя на задачу. Повторная подписка ничего не меняет.
+func (w *WatcherRepo) Add(ctx context.Context, taskID, userID int) error {
+	ib := sqlbuilder.InsertInto(WatchersTableName)
+	sql, args := ib.Cols("task_id", "user_id").
+		Values(taskID, userID).
+		SQL("ON CONFLICT DO NOTHING").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := w.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (w *WatcherRepo) Delete(ctx context.Context, taskID, userID int) error {
+	db := sqlbuilder.DeleteFrom(WatchersTableName)
+	sql, args := db.Where(db.Equal("task_id", taskID), db.Equal("user_id", userID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := w.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+// GetUserIDs возвращает пользователей, которые следят за задачей.
+func (w *WatcherRepo) GetUserIDs(ctx context.Context, taskID int) ([]int, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sql, args := sb.Select("user_id").
+		From(WatchersTableName).
+		Where(sb.Equal("task_id", taskID)).
+		OrderBy("user_id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	rows, err := w.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]int, 0)
+	for rows.Next() {
+		var userID int
+		if rowScanErr := rows.Scan(&userID); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
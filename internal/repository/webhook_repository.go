@@ -0,0 +1,261 @@
+package repository
+
+//go:generate mockgen -source=webhook_repository.go -destination=mocks/webhook_repository_mocks.go
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/romakorinenko/task-manager/internal/constant"
+)
+
+const (
+	WebhooksTableName          = "webhooks"
+	WebhookDeliveriesTableName = "webhook_deliveries"
+)
+
+type Webhook struct {
+	ID     int      `db:"id" json:"id"`
+	URL    string   `db:"url" json:"url"`
+	Events []string `db:"events" json:"events"`
+	// Secret - ключ подписи тел запросов, отдаётся только при создании подписки.
+	Secret    string    `db:"secret" json:"secret,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// WebhookDelivery - одна доставка события подписчику. Неудачная доставка остаётся в статусе PENDING до следующей
+// попытки, а после исчерпания попыток переходит в DEAD.
+type WebhookDelivery struct {
+	ID             int       `db:"id" json:"id"`
+	WebhookID      int       `db:"webhook_id" json:"webhookId"`
+	Event          string    `db:"event" json:"event"`
+	Payload        string    `db:"payload" json:"payload"`
+	Status         string    `db:"status" json:"status"`
+	Attempts       int       `db:"attempts" json:"attempts"`
+	ResponseStatus int       `db:"response_status" json:"responseStatus"`
+	LastError      string    `db:"last_error" json:"lastError"`
+	NextAttemptAt  time.Time `db:"next_attempt_at" json:"nextAttemptAt"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+	// EventID - событие outbox, по которому создана доставка, 0 - доставка создана не из outbox.
+	EventID int `db:"event_id" json:"-"`
+}
+
+var (
+	WebhookStruct         = sqlbuilder.NewStruct(new(Webhook))
+	WebhookDeliveryStruct = sqlbuilder.NewStruct(new(WebhookDelivery))
+)
+
+type IWebhookRepo interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetAll(ctx context.Context) ([]Webhook, error)
+	GetByID(ctx context.Context, webhookID int) (*Webhook, error)
+	GetByEvent(ctx context.Context, event string) ([]Webhook, error)
+	DeleteByID(ctx context.Context, webhookID int) (bool, error)
+	CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, deliveryID int) (*WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID, limit int) ([]WebhookDelivery, error)
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error
+}
+
+type WebhookRepo struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewWebhookRepo(dbPool *pgxpool.Pool) *WebhookRepo {
+	return &WebhookRepo{dbPool: dbPool}
+}
+
+func (w *WebhookRepo) Create(ctx context.Context, webhook *Webhook) error {
+	ID, err := w.generateNextID(ctx, "webhooks_sequence")
+	if err != nil {
+		return err
+	}
+
+	webhook.ID = ID
+	webhook.CreatedAt = time.Now()
+	sql, args := WebhookStruct.InsertInto(WebhooksTableName, webhook).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err = w.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (w *WebhookRepo) GetAll(ctx context.Context) ([]Webhook, error) {
+	sql, args := WebhookStruct.SelectFrom(WebhooksTableName).
+		OrderBy("id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return w.queryWebhooks(ctx, sql, args)
+}
+
+func (w *WebhookRepo) GetByID(ctx context.Context, webhookID int) (*Webhook, error) {
+	sb := WebhookStruct.SelectFrom(WebhooksTableName)
+	sql, args := sb.Where(sb.Equal("id", webhookID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var webhook Webhook
+	if err := w.dbPool.QueryRow(ctx, sql, args...).Scan(WebhookStruct.Addr(&webhook)...); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetByEvent возвращает подписки на событие event.
+func (w *WebhookRepo) GetByEvent(ctx context.Context, event string) ([]Webhook, error) {
+	sb := WebhookStruct.SelectFrom(WebhooksTableName)
+	sql, args := sb.Where(fmt.Sprintf("%s = ANY(events)", sb.Var(event))).
+		OrderBy("id").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return w.queryWebhooks(ctx, sql, args)
+}
+
+// DeleteByID удаляет подписку вместе с журналом доставок. Возвращает false, если подписки нет.
+func (w *WebhookRepo) DeleteByID(ctx context.Context, webhookID int) (bool, error) {
+	db := WebhookStruct.DeleteFrom(WebhooksTableName)
+	sql, args := db.Where(db.Equal("id", webhookID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	tag, err := w.dbPool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (w *WebhookRepo) CreateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	ID, err := w.generateNextID(ctx, "webhook_deliveries_sequence")
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	delivery.ID = ID
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	sql, args := WebhookDeliveryStruct.InsertInto(WebhookDeliveriesTableName, delivery).
+		SQL("ON CONFLICT (event_id, webhook_id) WHERE event_id <> 0 DO NOTHING").
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err = w.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (w *WebhookRepo) GetDeliveryByID(ctx context.Context, deliveryID int) (*WebhookDelivery, error) {
+	sb := WebhookDeliveryStruct.SelectFrom(WebhookDeliveriesTableName)
+	sql, args := sb.Where(sb.Equal("id", deliveryID)).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	var delivery WebhookDelivery
+	if err := w.dbPool.QueryRow(ctx, sql, args...).Scan(WebhookDeliveryStruct.Addr(&delivery)...); err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries возвращает последние limit доставок подписки, новые первыми.
+func (w *WebhookRepo) GetDeliveries(ctx context.Context, webhookID, limit int) ([]WebhookDelivery, error) {
+	sb := WebhookDeliveryStruct.SelectFrom(WebhookDeliveriesTableName)
+	sql, args := sb.Where(sb.Equal("webhook_id", webhookID)).
+		OrderBy("id").Desc().
+		Limit(limit).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return w.queryDeliveries(ctx, sql, args)
+}
+
+// GetDueDeliveries возвращает до limit ожидающих доставок, время следующей попытки которых наступило.
+func (w *WebhookRepo) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error) {
+	sb := WebhookDeliveryStruct.SelectFrom(WebhookDeliveriesTableName)
+	sql, args := sb.Where(
+		sb.Equal("status", constant.PendingDeliveryStatus),
+		sb.LessEqualThan("next_attempt_at", now),
+	).
+		OrderBy("next_attempt_at").
+		Limit(limit).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	return w.queryDeliveries(ctx, sql, args)
+}
+
+func (w *WebhookRepo) UpdateDelivery(ctx context.Context, delivery *WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	ub := sqlbuilder.Update(WebhookDeliveriesTableName)
+	sql, args := ub.Where(ub.Equal("id", delivery.ID)).
+		Set(
+			ub.Assign("status", delivery.Status),
+			ub.Assign("attempts", delivery.Attempts),
+			ub.Assign("response_status", delivery.ResponseStatus),
+			ub.Assign("last_error", delivery.LastError),
+			ub.Assign("next_attempt_at", delivery.NextAttemptAt),
+			ub.Assign("updated_at", delivery.UpdatedAt),
+		).
+		BuildWithFlavor(sqlbuilder.PostgreSQL)
+
+	_, err := w.dbPool.Exec(ctx, sql, args...)
+	return err
+}
+
+func (w *WebhookRepo) queryWebhooks(ctx context.Context, sql string, args []interface{}) ([]Webhook, error) {
+	rows, err := w.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]Webhook, 0)
+	for rows.Next() {
+		var webhook Webhook
+		if rowScanErr := rows.Scan(WebhookStruct.Addr(&webhook)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, webhook)
+	}
+
+	return res, rows.Err()
+}
+
+func (w *WebhookRepo) queryDeliveries(ctx context.Context, sql string, args []interface{}) ([]WebhookDelivery, error) {
+	rows, err := w.dbPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery WebhookDelivery
+		if rowScanErr := rows.Scan(WebhookDeliveryStruct.Addr(&delivery)...); rowScanErr != nil {
+			return nil, rowScanErr
+		}
+		res = append(res, delivery)
+	}
+
+	return res, rows.Err()
+}
+
+func (w *WebhookRepo) generateNextID(ctx context.Context, sequence string) (int, error) {
+	rows, err := w.dbPool.Query(ctx, fmt.Sprintf("SELECT nextval('%s')", sequence))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var id int
+		rowScanErr := rows.Scan(&id)
+		if rowScanErr != nil {
+			return 0, rowScanErr
+		}
+		return id, nil
+	}
+	return 0, fmt.Errorf("something was wrong. there is no next id in %s", sequence)
+}
@@ -1,18 +1,28 @@
 package server
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"html/template"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
 
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/romakorinenko/task-manager/internal/config"
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/controller"
+	"github.com/romakorinenko/task-manager/internal/logging"
+	"github.com/romakorinenko/task-manager/internal/markdown"
+	"github.com/romakorinenko/task-manager/internal/metrics"
 	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/tracing"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -20,28 +30,100 @@ import (
 //go:embed templates/*
 var templates embed.FS
 
+const requestIDHeader = "X-Request-ID"
+
+// requestIDPattern - какой X-Request-ID клиента принимается. Другие значения заменяются новым идентификатором,
+// чтобы в логи не попадали произвольные строки.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// probeRoutes - пробы оркестратора. Их успешные запросы идут каждые несколько секунд и пишутся в лог
+// с уровнем debug, чтобы не забивать его.
+var probeRoutes = map[string]bool{"/healthz": true, "/readyz": true}
+
 var Router *gin.Engine
 
 func RegisterServerAndHandlers(
 	userController controller.IUserController,
 	taskController controller.ITaskController,
-	port int,
+	checklistController controller.IChecklistController,
+	attachmentController controller.IAttachmentController,
+	notificationController controller.INotificationController,
+	webhookController controller.IWebhookController,
+	taskStreamController controller.ITaskStreamController,
+	commentController controller.ICommentController,
+	collabController controller.ICollabController,
+	taskBulkController controller.ITaskBulkController,
+	taskImportController controller.ITaskImportController,
+	taskExportController controller.ITaskExportController,
+	calendarController controller.ICalendarController,
+	backupController controller.IBackupController,
+	taskTemplateController controller.ITaskTemplateController,
+	savedFilterController controller.ISavedFilterController,
+	reportController controller.IReportController,
+	chartController controller.IChartController,
+	healthController controller.IHealthController,
 ) {
-	Router = gin.Default()
-	tmpl := template.Must(template.ParseFS(templates, "templates/*.html"))
+	Router = gin.New()
+	Router.Use(RequestLoggingMiddleware, gin.CustomRecovery(recoverPanic), tracing.Middleware, metrics.Middleware)
+	tmpl := template.Must(template.New("").Funcs(markdown.TemplateFuncs()).ParseFS(templates, "templates/*.html"))
 	Router.SetHTMLTemplate(tmpl)
 	store := sessions.NewCookieStore([]byte("secret"))
 	Router.Use(sessions.Sessions("sessions", store))
 
 	RegisterUserHandlers(userController)
-	RegisterTaskHandlers(taskController)
+	RegisterTaskHandlers(taskController, taskStreamController)
+	RegisterChecklistHandlers(checklistController)
+	RegisterAttachmentHandlers(attachmentController)
+	RegisterNotificationHandlers(notificationController)
+	RegisterWebhookHandlers(webhookController)
+	RegisterCommentHandlers(commentController)
+	RegisterCollabHandlers(collabController)
+	RegisterTaskBulkHandlers(taskBulkController)
+	RegisterTaskImportHandlers(taskImportController)
+	RegisterTaskExportHandlers(taskExportController)
+	RegisterCalendarHandlers(calendarController)
+	RegisterBackupHandlers(backupController)
+	RegisterTaskTemplateHandlers(taskTemplateController)
+	RegisterSavedFilterHandlers(savedFilterController)
+	RegisterReportHandlers(reportController)
+	RegisterChartHandlers(chartController)
+	RegisterHealthHandlers(healthController)
 	RegisterSwaggerAndMetricsHandlers()
+}
 
+// Serve обслуживает запросы Router, пока не отменён ctx. После отмены сервер перестаёт принимать соединения,
+// вызывает onShutdown, чтобы закрыть долгие соединения (потоки событий, websocket), и ждёт завершения начатых
+// запросов не дольше cfg.ShutdownTimeout.
+func Serve(ctx context.Context, cfg *config.Server, onShutdown ...func()) error {
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           Router,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+	for _, fn := range onShutdown {
+		httpServer.RegisterOnShutdown(fn)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
 	slog.Info("Swagger available on http://localhost:8080/swagger/index.html")
-	err := Router.Run(fmt.Sprintf(":%d", port))
-	if err != nil {
-		slog.Error("server closed", slog.Any("error", err))
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
 	}
+
+	slog.Info("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	return httpServer.Shutdown(shutdownCtx)
 }
 
 func RegisterUserHandlers(userController controller.IUserController) {
@@ -57,13 +139,19 @@ func RegisterUserHandlers(userController controller.IUserController) {
 	}
 }
 
-func RegisterTaskHandlers(taskController controller.ITaskController) {
+func RegisterTaskHandlers(
+	taskController controller.ITaskController,
+	taskStreamController controller.ITaskStreamController,
+) {
 	tasksRouterGroup := Router.Group("/tasks")
 	{
 		tasksRouterGroup.GET("/create", taskController.CreateTemplate)
 		tasksRouterGroup.POST("", UserSessionMiddleware, taskController.Create)
+		tasksRouterGroup.POST("/preview", UserSessionMiddleware, taskController.Preview)
+		tasksRouterGroup.GET("/events", UserSessionMiddleware, taskStreamController.Stream)
 		tasksRouterGroup.POST("/:id", UserSessionMiddleware, taskController.Update)
 		tasksRouterGroup.POST("/:id/delete", UserSessionMiddleware, taskController.Delete)
+		tasksRouterGroup.POST("/:id/due", UserSessionMiddleware, taskController.SetDueDate)
 		tasksRouterGroup.GET("/:id", UserSessionMiddleware, taskController.GetByID)
 		tasksRouterGroup.GET("/:id/edit", UserSessionMiddleware, taskController.Edit)
 		tasksRouterGroup.GET("/user/:login", UserSessionMiddleware, taskController.GetByUserLogin)
@@ -73,6 +161,144 @@ func RegisterTaskHandlers(taskController controller.ITaskController) {
 	}
 }
 
+func RegisterChecklistHandlers(checklistController controller.IChecklistController) {
+	checklistRouterGroup := Router.Group("/tasks/:id/checklist")
+	{
+		checklistRouterGroup.GET("", UserSessionMiddleware, checklistController.GetByTaskID)
+		checklistRouterGroup.POST("", UserSessionMiddleware, checklistController.Create)
+		checklistRouterGroup.POST("/:itemId/check", UserSessionMiddleware, checklistController.Check)
+		checklistRouterGroup.POST("/:itemId/uncheck", UserSessionMiddleware, checklistController.Uncheck)
+		checklistRouterGroup.POST("/:itemId/move", UserSessionMiddleware, checklistController.Move)
+		checklistRouterGroup.POST("/:itemId/delete", UserSessionMiddleware, checklistController.Delete)
+	}
+}
+
+func RegisterAttachmentHandlers(attachmentController controller.IAttachmentController) {
+	attachmentsRouterGroup := Router.Group("/tasks/:id/attachments")
+	{
+		attachmentsRouterGroup.GET("", UserSessionMiddleware, attachmentController.GetByTaskID)
+		attachmentsRouterGroup.POST("", UserSessionMiddleware, attachmentController.Upload)
+		attachmentsRouterGroup.GET("/:attachmentId", UserSessionMiddleware, attachmentController.Download)
+		attachmentsRouterGroup.POST("/:attachmentId/delete", UserSessionMiddleware, attachmentController.Delete)
+	}
+}
+
+func RegisterNotificationHandlers(notificationController controller.INotificationController) {
+	notificationsRouterGroup := Router.Group("/notifications")
+	{
+		notificationsRouterGroup.GET("", UserSessionMiddleware, notificationController.GetInbox)
+		notificationsRouterGroup.GET("/unread-count", UserSessionMiddleware, notificationController.GetUnreadCount)
+		notificationsRouterGroup.POST("/read-all", UserSessionMiddleware, notificationController.MarkAllRead)
+		notificationsRouterGroup.POST("/preferences", UserSessionMiddleware, notificationController.UpdatePreferences)
+		notificationsRouterGroup.POST("/email", UserSessionMiddleware, notificationController.UpdateEmailSettings)
+		notificationsRouterGroup.POST("/:notificationId/read", UserSessionMiddleware, notificationController.MarkRead)
+	}
+}
+
+func RegisterWebhookHandlers(webhookController controller.IWebhookController) {
+	webhooksRouterGroup := Router.Group("/webhooks")
+	{
+		webhooksRouterGroup.POST("", AdminSessionMiddleware, webhookController.Create)
+		webhooksRouterGroup.GET("", AdminSessionMiddleware, webhookController.GetAll)
+		webhooksRouterGroup.DELETE("/:id", AdminSessionMiddleware, webhookController.Delete)
+		webhooksRouterGroup.GET("/:id/deliveries", AdminSessionMiddleware, webhookController.GetDeliveries)
+		webhooksRouterGroup.POST("/:id/test", AdminSessionMiddleware, webhookController.SendTest)
+		webhooksRouterGroup.POST("/:id/deliveries/:deliveryId/redeliver", AdminSessionMiddleware,
+			webhookController.Redeliver)
+	}
+}
+
+func RegisterCommentHandlers(commentController controller.ICommentController) {
+	commentsRouterGroup := Router.Group("/tasks/:id/comments")
+	{
+		commentsRouterGroup.GET("", UserSessionMiddleware, commentController.GetByTaskID)
+		commentsRouterGroup.POST("", UserSessionMiddleware, commentController.Create)
+	}
+}
+
+func RegisterCollabHandlers(collabController controller.ICollabController) {
+	Router.GET("/tasks/:id/ws", UserSessionMiddleware, collabController.Connect)
+}
+
+func RegisterTaskBulkHandlers(taskBulkController controller.ITaskBulkController) {
+	Router.POST("/tasks/bulk", UserSessionMiddleware, taskBulkController.Apply)
+}
+
+func RegisterTaskImportHandlers(taskImportController controller.ITaskImportController) {
+	Router.POST("/tasks/import", AdminSessionMiddleware, taskImportController.Import)
+}
+
+func RegisterTaskExportHandlers(taskExportController controller.ITaskExportController) {
+	Router.GET("/tasks/export", UserSessionMiddleware, taskExportController.Export)
+}
+
+func RegisterCalendarHandlers(calendarController controller.ICalendarController) {
+	calendarRouterGroup := Router.Group("/calendar")
+	{
+		calendarRouterGroup.GET("", UserSessionMiddleware, calendarController.GetFeed)
+		calendarRouterGroup.POST("/token", UserSessionMiddleware, calendarController.RotateToken)
+		// календарные приложения не входят в систему, доступ к календарю даёт токен в адресе.
+		calendarRouterGroup.GET("/:token", calendarController.Feed)
+	}
+	Router.POST("/tasks/:id/watch", UserSessionMiddleware, calendarController.Watch)
+	Router.POST("/tasks/:id/unwatch", UserSessionMiddleware, calendarController.Unwatch)
+}
+
+func RegisterBackupHandlers(backupController controller.IBackupController) {
+	Router.GET("/backup", AdminSessionMiddleware, backupController.Download)
+}
+
+func RegisterTaskTemplateHandlers(taskTemplateController controller.ITaskTemplateController) {
+	templatesRouterGroup := Router.Group("/templates")
+	{
+		templatesRouterGroup.GET("", UserSessionMiddleware, taskTemplateController.GetAll)
+		templatesRouterGroup.GET("/choose", UserSessionMiddleware, taskTemplateController.Choose)
+		templatesRouterGroup.GET("/:id/use", UserSessionMiddleware, taskTemplateController.Use)
+		templatesRouterGroup.POST("/:id/tasks", UserSessionMiddleware, taskTemplateController.CreateTask)
+		templatesRouterGroup.POST("", AdminSessionMiddleware, taskTemplateController.Create)
+		templatesRouterGroup.PUT("/:id", AdminSessionMiddleware, taskTemplateController.Update)
+		templatesRouterGroup.DELETE("/:id", AdminSessionMiddleware, taskTemplateController.Delete)
+	}
+}
+
+func RegisterSavedFilterHandlers(savedFilterController controller.ISavedFilterController) {
+	filtersRouterGroup := Router.Group("/filters")
+	{
+		filtersRouterGroup.GET("", UserSessionMiddleware, savedFilterController.GetAll)
+		filtersRouterGroup.POST("", UserSessionMiddleware, savedFilterController.Create)
+		filtersRouterGroup.PUT("/:id", UserSessionMiddleware, savedFilterController.Update)
+		filtersRouterGroup.DELETE("/:id", UserSessionMiddleware, savedFilterController.Delete)
+		filtersRouterGroup.POST("/:id/pin", UserSessionMiddleware, savedFilterController.Pin)
+		filtersRouterGroup.POST("/:id/unpin", UserSessionMiddleware, savedFilterController.Unpin)
+		filtersRouterGroup.POST("/:id/subscribe", UserSessionMiddleware, savedFilterController.Subscribe)
+		filtersRouterGroup.POST("/:id/unsubscribe", UserSessionMiddleware, savedFilterController.Unsubscribe)
+	}
+}
+
+func RegisterReportHandlers(reportController controller.IReportController) {
+	reportsRouterGroup := Router.Group("/reports")
+	{
+		reportsRouterGroup.GET("", UserSessionMiddleware, reportController.Page)
+		reportsRouterGroup.GET("/throughput", UserSessionMiddleware, reportController.GetThroughput)
+		reportsRouterGroup.GET("/cycle-time", UserSessionMiddleware, reportController.GetCycleTime)
+		reportsRouterGroup.GET("/aging", UserSessionMiddleware, reportController.GetAging)
+		reportsRouterGroup.GET("/breakdown", UserSessionMiddleware, reportController.GetBreakdown)
+	}
+}
+
+func RegisterChartHandlers(chartController controller.IChartController) {
+	Router.GET("/reports/charts", UserSessionMiddleware, chartController.Page)
+	Router.GET("/reports/burndown", UserSessionMiddleware, chartController.GetBurndown)
+	Router.GET("/reports/cumulative-flow", UserSessionMiddleware, chartController.GetCumulativeFlow)
+}
+
+// RegisterHealthHandlers регистрирует пробы оркестратора без аутентификации и подробный отчёт для администраторов.
+func RegisterHealthHandlers(healthController controller.IHealthController) {
+	Router.GET("/healthz", healthController.Healthz)
+	Router.GET("/readyz", healthController.Readyz)
+	Router.GET("/health", AdminSessionMiddleware, healthController.GetReport)
+}
+
 func RegisterSwaggerAndMetricsHandlers() {
 	Router.GET("/swagger/*any", AdminSessionMiddleware, ginSwagger.WrapHandler(swaggerFiles.Handler))
 	Router.GET("/metrics", AdminSessionMiddleware, gin.WrapH(promhttp.Handler()))
@@ -83,6 +309,7 @@ func UserSessionMiddleware(c *gin.Context) {
 	user := session.Get(constant.UserSessionKey)
 	if user == nil {
 		c.Redirect(http.StatusFound, "/")
+		c.Abort()
 	}
 }
 
@@ -91,16 +318,80 @@ func AdminSessionMiddleware(c *gin.Context) {
 	user := session.Get(constant.UserSessionKey)
 	if user == nil {
 		c.Redirect(http.StatusFound, "/")
+		c.Abort()
+		return
 	}
 
 	sessionUser, ok := user.(*repository.User)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
 	if sessionUser.Role != constant.AdminRole {
-		c.JSON(http.StatusForbidden, gin.H{"error": "you should be admin for the action"})
-		return
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "you should be admin for the action"})
+	}
+}
+
+// RequestLoggingMiddleware назначает запросу идентификатор и пишет в лог строку о каждом запросе. Идентификатор
+// берётся из заголовка X-Request-ID клиента или создаётся, возвращается в том же заголовке ответа и кладётся
+// в контекст запроса, поэтому попадает во все записи лога, сделанные с этим контекстом.
+func RequestLoggingMiddleware(c *gin.Context) {
+	start := time.Now()
+	requestID := c.GetHeader(requestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = uuid.NewString()
+	}
+	c.Header(requestIDHeader, requestID)
+	c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= http.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= http.StatusBadRequest:
+		level = slog.LevelWarn
+	case probeRoutes[c.FullPath()]:
+		level = slog.LevelDebug
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", c.Request.Method),
+		slog.String("route", c.FullPath()),
+		slog.String("path", c.Request.URL.Path),
+		slog.Int("status", status),
+		slog.Float64("latencyMs", float64(time.Since(start).Microseconds())/1000),
+	}
+	if login := sessionUserLogin(c); login != "" {
+		attrs = append(attrs, slog.String("userLogin", login))
 	}
+	if len(c.Errors) > 0 {
+		attrs = append(attrs, slog.String("error", c.Errors.String()))
+	}
+	slog.LogAttrs(c.Request.Context(), level, "http request", attrs...)
+}
+
+// sessionUserLogin возвращает логин пользователя сессии или пустую строку, если сессии нет.
+func sessionUserLogin(c *gin.Context) string {
+	if _, exists := c.Get(sessions.DefaultKey); !exists {
+		return ""
+	}
+
+	user, ok := sessions.Default(c).Get(constant.UserSessionKey).(*repository.User)
+	if !ok {
+		return ""
+	}
+
+	return user.Login
+}
+
+func recoverPanic(c *gin.Context, err any) {
+	slog.ErrorContext(c.Request.Context(), "panic recovered",
+		slog.Any("error", err),
+		slog.String("stack", string(debug.Stack())),
+	)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 }
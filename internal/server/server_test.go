@@ -0,0 +1,192 @@
+//go:build unit && !integration
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/logging"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func setUpLogRouter(t *testing.T) (*gin.Engine, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger, err := logging.NewLogger(&buf, &config.Logging{Level: "info", Format: logging.JSONFormat})
+	require.NoError(t, err)
+	defaultLogger := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() {
+		slog.SetDefault(defaultLogger)
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestLoggingMiddleware, gin.CustomRecovery(recoverPanic))
+	router.Use(sessions.Sessions("sessions", sessions.NewCookieStore([]byte("secret"))))
+
+	return router, &buf
+}
+
+func TestRequestLoggingMiddleware(t *testing.T) {
+	router, buf := setUpLogRouter(t)
+	router.GET("/tasks/:id", func(c *gin.Context) {
+		sessions.Default(c).Set(constant.UserSessionKey, &repository.User{ID: 2, Login: "user"})
+		c.Status(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/5", nil)
+	req.Header.Set("X-Request-ID", "client-id-1")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, "client-id-1", w.Header().Get("X-Request-ID"))
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "http request", record["msg"])
+	require.Equal(t, "WARN", record["level"])
+	require.Equal(t, "client-id-1", record["requestId"])
+	require.Equal(t, "/tasks/:id", record["route"])
+	require.Equal(t, "/tasks/5", record["path"])
+	require.InDelta(t, http.StatusNotFound, record["status"], 0)
+	require.Equal(t, "user", record["userLogin"])
+	require.Contains(t, record, "latencyMs")
+}
+
+func TestRequestLoggingMiddleware_PanicLoggedWithRequestID(t *testing.T) {
+	router, buf := setUpLogRouter(t)
+	router.GET("/panic", func(*gin.Context) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "bad id\nwith newline")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	requestID := w.Header().Get("X-Request-ID")
+	require.Len(t, requestID, 36)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(line, &record))
+		require.Equal(t, requestID, record["requestId"])
+		require.Equal(t, "ERROR", record["level"])
+	}
+}
+
+func TestRequestLoggingMiddleware_ProbesLoggedAtDebug(t *testing.T) {
+	router, buf := setUpLogRouter(t)
+	router.GET("/readyz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Empty(t, buf.String())
+
+	router.GET("/readyz-fail", func(c *gin.Context) {
+		c.Status(http.StatusServiceUnavailable)
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz-fail", nil))
+	require.Contains(t, buf.String(), `"level":"ERROR"`)
+}
+
+func TestSessionMiddlewares_HandlerNotRunWithoutAccess(t *testing.T) {
+	router, _ := setUpLogRouter(t)
+	handled := false
+	handler := func(c *gin.Context) {
+		handled = true
+		c.Status(http.StatusOK)
+	}
+	router.GET("/user", UserSessionMiddleware, handler)
+	router.GET("/admin", AdminSessionMiddleware, handler)
+	router.GET("/admin-as-user", func(c *gin.Context) {
+		sessions.Default(c).Set(constant.UserSessionKey, &repository.User{ID: 2, Role: constant.UserRole})
+	}, AdminSessionMiddleware, handler)
+
+	for path, statusCode := range map[string]int{
+		"/user":          http.StatusFound,
+		"/admin":         http.StatusFound,
+		"/admin-as-user": http.StatusForbidden,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		require.Equal(t, statusCode, w.Code, path)
+		require.False(t, handled, path)
+	}
+}
+
+func TestServe_DrainsRequestsOnShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	started := make(chan struct{})
+	gin.SetMode(gin.TestMode)
+	Router = gin.New()
+	Router.GET("/ping", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	Router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	onShutdownCalled := make(chan struct{})
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, &config.Server{Port: port, ShutdownTimeout: 5 * time.Second}, func() {
+			close(onShutdownCalled)
+		})
+	}()
+
+	get := func(path string) int {
+		req, reqErr := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			fmt.Sprintf("http://127.0.0.1:%d%s", port, path), nil)
+		require.NoError(t, reqErr)
+		resp, getErr := http.DefaultClient.Do(req)
+		if getErr != nil {
+			return 0
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode
+	}
+	require.Eventually(t, func() bool {
+		return get("/ping") == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	slowStatus := make(chan int, 1)
+	go func() {
+		slowStatus <- get("/slow")
+	}()
+	<-started
+	cancel()
+
+	require.NoError(t, <-served)
+	require.Equal(t, http.StatusOK, <-slowStatus)
+	<-onShutdownCalled
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/storage"
+)
+
+const (
+	maxAttachmentFileNameLen = 255
+	contentSniffLen          = 512
+)
+
+type IAttachmentService interface {
+	MaxSize() int64
+	GetByTaskID(ctx context.Context, user *repository.User, taskID int) ([]repository.Attachment, error)
+	Upload(ctx context.Context,
+		user *repository.User,
+		taskID int,
+		fileName string,
+		size int64,
+		reader io.Reader,
+	) (*repository.Attachment, error)
+	Download(ctx context.Context,
+		user *repository.User,
+		taskID, attachmentID int,
+	) (*repository.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, user *repository.User, taskID, attachmentID int) error
+	GetFiles(ctx context.Context, taskID int) ([]repository.Attachment, error)
+	DeleteFiles(ctx context.Context, attachments []repository.Attachment)
+}
+
+type AttachmentService struct {
+	attachmentRepository repository.IAttachmentRepo
+	taskRepository       repository.ITaskRepo
+	storage              storage.IStorage
+	cfg                  *config.Attachments
+}
+
+func NewAttachmentService(
+	attachmentRepository repository.IAttachmentRepo,
+	taskRepository repository.ITaskRepo,
+	storage storage.IStorage,
+	cfg *config.Attachments,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepository: attachmentRepository,
+		taskRepository:       taskRepository,
+		storage:              storage,
+		cfg:                  cfg,
+	}
+}
+
+func (a *AttachmentService) MaxSize() int64 {
+	return a.cfg.MaxSize
+}
+
+// GetByTaskID возвращает вложения задачи, если пользователь может её открыть: свою, а ADMIN - любую.
+func (a *AttachmentService) GetByTaskID(ctx context.Context,
+	user *repository.User,
+	taskID int,
+) ([]repository.Attachment, error) {
+	if _, err := getAccessibleTask(ctx, a.taskRepository, user, taskID); err != nil {
+		return nil, err
+	}
+
+	return a.attachmentRepository.GetByTaskID(ctx, taskID)
+}
+
+func (a *AttachmentService) Upload(ctx context.Context,
+	user *repository.User,
+	taskID int,
+	fileName string,
+	size int64,
+	reader io.Reader,
+) (*repository.Attachment, error) {
+	fileName = strings.TrimSpace(filepath.Base(filepath.Clean("/" + fileName)))
+	if fileName == "" || fileName == "/" || len(fileName) > maxAttachmentFileNameLen || size <= 0 {
+		return nil, errs.BadReqErr{}
+	}
+	if size > a.cfg.MaxSize {
+		return nil, errs.TooLargeErr{}
+	}
+
+	if _, err := getAccessibleTask(ctx, a.taskRepository, user, taskID); err != nil {
+		return nil, err
+	}
+
+	bufferedReader := bufio.NewReaderSize(reader, contentSniffLen)
+	head, err := bufferedReader.Peek(contentSniffLen)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !slices.Contains(a.cfg.AllowedMimeTypes, contentType) {
+		return nil, errs.UnsupportedMediaTypeErr{}
+	}
+
+	attachmentID, err := a.attachmentRepository.NextID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &repository.Attachment{
+		ID:          attachmentID,
+		TaskID:      taskID,
+		UserID:      user.ID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("tasks/%d/%d", taskID, attachmentID),
+	}
+
+	err = a.storage.Put(ctx, attachment.StorageKey, io.LimitReader(bufferedReader, size), size, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if createErr := a.attachmentRepository.Create(ctx, attachment); createErr != nil {
+		a.DeleteFiles(ctx, []repository.Attachment{*attachment})
+		return nil, createErr
+	}
+
+	return attachment, nil
+}
+
+func (a *AttachmentService) Download(ctx context.Context,
+	user *repository.User,
+	taskID, attachmentID int,
+) (*repository.Attachment, io.ReadCloser, error) {
+	attachment, err := a.getTaskAttachment(ctx, user, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := a.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (a *AttachmentService) Delete(ctx context.Context, user *repository.User, taskID, attachmentID int) error {
+	attachment, err := a.getTaskAttachment(ctx, user, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if deleteErr := a.attachmentRepository.DeleteByID(ctx, attachmentID); deleteErr != nil {
+		return deleteErr
+	}
+
+	a.DeleteFiles(ctx, []repository.Attachment{*attachment})
+
+	return nil
+}
+
+// GetFiles возвращает вложения задачи без проверки доступа, чтобы после удаления задачи удалить их файлы
+// через DeleteFiles. Доступ к задаче проверяет вызывающий.
+func (a *AttachmentService) GetFiles(ctx context.Context, taskID int) ([]repository.Attachment, error) {
+	return a.attachmentRepository.GetByTaskID(ctx, taskID)
+}
+
+// DeleteFiles удаляет содержимое вложений из хранилища. Ошибки только логируются:
+// метаданные к этому моменту уже удалены, и повторить удаление пользователь не сможет.
+func (a *AttachmentService) DeleteFiles(ctx context.Context, attachments []repository.Attachment) {
+	for _, attachment := range attachments {
+		if err := a.storage.Delete(ctx, attachment.StorageKey); err != nil {
+			slog.ErrorContext(ctx, "cannot delete attachment file",
+				slog.Int("attachmentId", attachment.ID),
+				slog.String("key", attachment.StorageKey),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
+
+func (a *AttachmentService) getTaskAttachment(ctx context.Context,
+	user *repository.User,
+	taskID, attachmentID int,
+) (*repository.Attachment, error) {
+	if _, err := getAccessibleTask(ctx, a.taskRepository, user, taskID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := a.attachmentRepository.GetByID(ctx, attachmentID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if attachment.TaskID != taskID {
+		return nil, errs.NotFoundErr{}
+	}
+
+	return attachment, nil
+}
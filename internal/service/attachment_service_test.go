@@ -0,0 +1,165 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	mockStorage "github.com/romakorinenko/task-manager/internal/storage/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var attachmentsConfig = &config.Attachments{
+	MaxSize:          1024,
+	AllowedMimeTypes: []string{"text/plain", "image/png"},
+}
+
+func TestAttachmentService_Upload_AttachmentCreated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, attachmentsConfig)
+
+	content := "panic: runtime error"
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+	attachmentRepo.EXPECT().NextID(gomock.Any()).Return(10, nil)
+	attachmentStorage.EXPECT().Put(gomock.Any(), "tasks/1/10", gomock.Any(), int64(len(content)), "text/plain").
+		DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ int64, _ string) error {
+			stored, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, content, string(stored))
+			return nil
+		})
+	attachmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	attachment, err := attachmentService.Upload(ctx, user, 1, "../../app.log", int64(len(content)),
+		strings.NewReader(content))
+	require.NoError(t, err)
+	require.Equal(t, "app.log", attachment.FileName)
+	require.Equal(t, "text/plain", attachment.ContentType)
+}
+
+func TestAttachmentService_Upload_TooLarge(t *testing.T) {
+	ctx := context.Background()
+	attachmentService := NewAttachmentService(nil, nil, nil, attachmentsConfig)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	_, err := attachmentService.Upload(ctx, user, 1, "app.log", 2048, strings.NewReader(""))
+	require.Equal(t, errs.TooLargeErr{}, err)
+}
+
+func TestAttachmentService_Upload_UnsupportedMediaType(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentService := NewAttachmentService(nil, taskRepo, nil, attachmentsConfig)
+
+	content := []byte("%PDF-1.7 fake document")
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	_, err := attachmentService.Upload(ctx, user, 1, "doc.pdf", int64(len(content)), bytes.NewReader(content))
+	require.Equal(t, errs.UnsupportedMediaTypeErr{}, err)
+}
+
+func TestAttachmentService_Upload_ForeignTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentService := NewAttachmentService(nil, taskRepo, nil, attachmentsConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	_, err := attachmentService.Upload(ctx, user, 1, "app.log", 4, strings.NewReader("text"))
+	require.Equal(t, errs.ForbiddenErr{}, err)
+}
+
+func TestAttachmentService_Upload_MetadataNotSaved(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, attachmentsConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil)
+	attachmentRepo.EXPECT().NextID(gomock.Any()).Return(10, nil)
+	attachmentStorage.EXPECT().Put(gomock.Any(), "tasks/1/10", gomock.Any(), int64(4), "text/plain").Return(nil)
+	attachmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New(""))
+	attachmentStorage.EXPECT().Delete(gomock.Any(), "tasks/1/10").Return(nil)
+
+	admin := &repository.User{ID: 1, Role: constant.AdminRole}
+	_, err := attachmentService.Upload(ctx, admin, 1, "app.log", 4, strings.NewReader("text"))
+	require.Error(t, err)
+}
+
+func TestAttachmentService_Download_AttachmentOfAnotherTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, nil, attachmentsConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+	attachmentRepo.EXPECT().GetByID(gomock.Any(), 10).Return(&repository.Attachment{ID: 10, TaskID: 5}, nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	_, _, err := attachmentService.Download(ctx, user, 1, 10)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+func TestAttachmentService_Delete_FileRemoved(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, attachmentsConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+	attachmentRepo.EXPECT().GetByID(gomock.Any(), 10).
+		Return(&repository.Attachment{ID: 10, TaskID: 1, StorageKey: "tasks/1/10"}, nil)
+	attachmentRepo.EXPECT().DeleteByID(gomock.Any(), 10).Return(nil)
+	attachmentStorage.EXPECT().Delete(gomock.Any(), "tasks/1/10").Return(nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	err := attachmentService.Delete(ctx, user, 1, 10)
+	require.NoError(t, err)
+}
+
+func TestAttachmentService_GetByTaskID_ForeignTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, nil, attachmentsConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil).Times(2)
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).Return([]repository.Attachment{{ID: 10, TaskID: 1}}, nil)
+
+	t.Run("пользователь не видит вложения чужой задачи", func(t *testing.T) {
+		_, err := attachmentService.GetByTaskID(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 1)
+		require.Equal(t, errs.ForbiddenErr{}, err)
+	})
+
+	t.Run("администратор видит вложения любой задачи", func(t *testing.T) {
+		attachments, err := attachmentService.GetByTaskID(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, 1)
+		require.NoError(t, err)
+		require.Len(t, attachments, 1)
+	})
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/backup"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/storage"
+)
+
+const restoreBatchSize = 500
+
+// migrationUserIDs - учётные записи admin и user, которые создаёт миграция create_users_with_some_roles.
+// Они не мешают восстановлению и заменяются пользователями из копии.
+var migrationUserIDs = []int{1, 2}
+
+var ErrDatabaseNotEmpty = errors.New("database is not empty")
+
+// backupAttachment - поля строки task_attachments, нужные, чтобы сохранить файл вложения.
+type backupAttachment struct { //nolint:tagliatelle // ключи - имена столбцов таблицы
+	Key         string `json:"storage_key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type IBackupService interface {
+	Backup(ctx context.Context, w io.Writer) error
+	Validate(manifest *backup.Manifest, schemaVersion int64) error
+	Restore(ctx context.Context, archive *backup.Reader) error
+}
+
+type BackupService struct {
+	backupRepository repository.IBackupRepo
+	transactor       repository.ITransactor
+	storage          storage.IStorage
+}
+
+func NewBackupService(
+	backupRepository repository.IBackupRepo,
+	transactor repository.ITransactor,
+	storage storage.IStorage,
+) *BackupService {
+	return &BackupService{
+		backupRepository: backupRepository,
+		transactor:       transactor,
+		storage:          storage,
+	}
+}
+
+// Backup пишет в w архив со всеми данными приложения и содержимым вложений. Таблицы читаются из одного снимка
+// базы, строки пишутся в архив по мере чтения. Вложение, файла которого нет в хранилище, пропускается.
+func (b *BackupService) Backup(ctx context.Context, w io.Writer) error {
+	archive := backup.NewWriter(w)
+	manifest := &backup.Manifest{FormatVersion: backup.FormatVersion, CreatedAt: time.Now().UTC()}
+
+	var attachments []backup.File
+	err := b.backupRepository.WithinSnapshot(ctx, func(ctx context.Context) error {
+		version, err := b.backupRepository.GetSchemaVersion(ctx)
+		if err != nil {
+			return err
+		}
+		manifest.SchemaVersion = version
+
+		for _, table := range repository.BackupTables {
+			entity, entityErr := b.backupTable(ctx, archive, table, &attachments)
+			if entityErr != nil {
+				return fmt.Errorf("cannot back up %s: %w", table, entityErr)
+			}
+			manifest.Entities = append(manifest.Entities, *entity)
+		}
+
+		for _, name := range repository.BackupSequences {
+			value, sequenceErr := b.backupRepository.GetSequence(ctx, name)
+			if sequenceErr != nil {
+				return fmt.Errorf("cannot back up %s: %w", name, sequenceErr)
+			}
+			manifest.Sequences = append(manifest.Sequences, backup.Sequence{
+				Name:      name,
+				LastValue: value.LastValue,
+				IsCalled:  value.IsCalled,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	manifest.Files = make([]backup.File, 0, len(attachments))
+	for _, file := range attachments {
+		saved, fileErr := b.backupFile(ctx, archive, file)
+		if fileErr != nil {
+			return fmt.Errorf("cannot back up file %s: %w", file.Key, fileErr)
+		}
+		if saved {
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+
+	return archive.Close(manifest)
+}
+
+// Validate проверяет, что копию можно восстановить этой версией приложения: формат архива совпадает, схема
+// базы не новее schemaVersion, а все сущности копии известны.
+func (b *BackupService) Validate(manifest *backup.Manifest, schemaVersion int64) error {
+	if manifest.FormatVersion != backup.FormatVersion {
+		return fmt.Errorf("unsupported backup format version %d, expected %d",
+			manifest.FormatVersion, backup.FormatVersion)
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return fmt.Errorf("backup schema version %d is newer than application schema version %d",
+			manifest.SchemaVersion, schemaVersion)
+	}
+	for _, entity := range manifest.Entities {
+		if !slices.Contains(repository.BackupTables, entity.Name) {
+			return fmt.Errorf("unknown backup entity %q", entity.Name)
+		}
+	}
+	for _, sequence := range manifest.Sequences {
+		if !slices.Contains(repository.BackupSequences, sequence.Name) {
+			return fmt.Errorf("unknown backup sequence %q", sequence.Name)
+		}
+	}
+
+	return nil
+}
+
+// Restore загружает копию в пустую базу, схема которой уже обновлена миграциями. База считается пустой, если в
+// ней нет данных, кроме учётных записей, созданных миграциями. Таблицы загружаются в порядке зависимостей в одной
+// транзакции, после чего восстанавливаются последовательности. Файлы вложений кладутся в хранилище до загрузки.
+func (b *BackupService) Restore(ctx context.Context, archive *backup.Reader) error {
+	for _, table := range repository.BackupTables {
+		var ignoredIDs []int
+		if table == repository.UsersTableName {
+			ignoredIDs = migrationUserIDs
+		}
+
+		empty, err := b.backupRepository.IsEmpty(ctx, table, ignoredIDs)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return fmt.Errorf("%w: table %s has data", ErrDatabaseNotEmpty, table)
+		}
+	}
+
+	manifest := archive.Manifest()
+	for _, file := range manifest.Files {
+		if err := b.restoreFile(ctx, archive, file); err != nil {
+			return fmt.Errorf("cannot restore file %s: %w", file.Key, err)
+		}
+	}
+
+	return b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := b.backupRepository.DeleteAll(ctx, repository.UsersTableName); err != nil {
+			return err
+		}
+
+		for _, table := range repository.BackupTables {
+			index := slices.IndexFunc(manifest.Entities, func(entity backup.Entity) bool {
+				return entity.Name == table
+			})
+			// таблицы, появившиеся после создания копии, остаются пустыми.
+			if index < 0 {
+				continue
+			}
+			if err := b.restoreTable(ctx, archive, &manifest.Entities[index]); err != nil {
+				return fmt.Errorf("cannot restore %s: %w", table, err)
+			}
+		}
+
+		for _, sequence := range manifest.Sequences {
+			value := &repository.SequenceValue{LastValue: sequence.LastValue, IsCalled: sequence.IsCalled}
+			if err := b.backupRepository.SetSequence(ctx, sequence.Name, value); err != nil {
+				return fmt.Errorf("cannot restore %s: %w", sequence.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// backupTable пишет строки таблицы в архив. Для вложений запоминает файлы, которые нужно добавить в архив.
+func (b *BackupService) backupTable(ctx context.Context,
+	archive *backup.Writer,
+	table string,
+	attachments *[]backup.File,
+) (*backup.Entity, error) {
+	columns, err := b.backupRepository.GetColumns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	entityWriter, err := archive.CreateEntity(table)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := &backup.Entity{Name: table, Columns: columns}
+	err = b.backupRepository.IterateRows(ctx, table, func(row json.RawMessage) error {
+		entity.Rows++
+		if table == repository.AttachmentsTableName {
+			var file backupAttachment
+			if decodeErr := json.Unmarshal(row, &file); decodeErr != nil {
+				return decodeErr
+			}
+			*attachments = append(*attachments, backup.File(file))
+		}
+
+		_, writeErr := entityWriter.Write(append(row, '\n'))
+		return writeErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity, nil
+}
+
+func (b *BackupService) backupFile(ctx context.Context, archive *backup.Writer, file backup.File) (bool, error) {
+	content, err := b.storage.Get(ctx, file.Key)
+	if err != nil {
+		slog.WarnContext(ctx, "attachment file is not backed up", slog.String("key", file.Key), slog.Any("error", err))
+		return false, nil
+	}
+	defer content.Close()
+
+	fileWriter, err := archive.CreateFile(file.Key)
+	if err != nil {
+		return false, err
+	}
+	if _, err = io.Copy(fileWriter, content); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *BackupService) restoreTable(ctx context.Context, archive *backup.Reader, entity *backup.Entity) error {
+	entityFile, err := archive.OpenEntity(entity.Name)
+	if err != nil {
+		return err
+	}
+	defer entityFile.Close()
+
+	reader := bufio.NewReader(entityFile)
+	batch := make([]json.RawMessage, 0, restoreBatchSize)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if line = bytes.TrimSuffix(line, []byte("\n")); len(line) > 0 {
+			batch = append(batch, line)
+		}
+		if len(batch) == restoreBatchSize || (errors.Is(readErr, io.EOF) && len(batch) > 0) {
+			if err = b.backupRepository.InsertRows(ctx, entity.Name, entity.Columns, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		} else if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+func (b *BackupService) restoreFile(ctx context.Context, archive *backup.Reader, file backup.File) error {
+	content, err := archive.OpenFile(file.Key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return b.storage.Put(ctx, file.Key, content, file.Size, file.ContentType)
+}
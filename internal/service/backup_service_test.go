@@ -0,0 +1,166 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/backup"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	mockStorage "github.com/romakorinenko/task-manager/internal/storage/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBackupService_Backup(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	backupRepo := mockRepository.NewMockIBackupRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	backupService := NewBackupService(backupRepo, nil, attachmentStorage)
+
+	backupRepo.EXPECT().WithinSnapshot(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+	backupRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(int64(20261019200000), nil)
+	backupRepo.EXPECT().GetColumns(gomock.Any(), gomock.Any()).Return([]string{"id"}, nil).
+		Times(len(repository.BackupTables))
+	backupRepo.EXPECT().IterateRows(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, table string, fn func(row json.RawMessage) error) error {
+			switch table {
+			case repository.UsersTableName:
+				return fn(json.RawMessage(`{"id":1,"login":"admin"}`))
+			case repository.AttachmentsTableName:
+				return fn(json.RawMessage(`{"id":3,"storage_key":"tasks/5/3","content_type":"text/plain","size":4}`))
+			default:
+				return nil
+			}
+		}).Times(len(repository.BackupTables))
+	backupRepo.EXPECT().GetSequence(gomock.Any(), gomock.Any()).
+		Return(&repository.SequenceValue{LastValue: 7, IsCalled: true}, nil).
+		Times(len(repository.BackupSequences))
+	attachmentStorage.EXPECT().Get(gomock.Any(), "tasks/5/3").Return(io.NopCloser(strings.NewReader("logs")), nil)
+
+	var archive bytes.Buffer
+	require.NoError(t, backupService.Backup(ctx, &archive))
+
+	reader, err := backup.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+	manifest := reader.Manifest()
+	require.Equal(t, backup.FormatVersion, manifest.FormatVersion)
+	require.Equal(t, int64(20261019200000), manifest.SchemaVersion)
+	require.Len(t, manifest.Entities, len(repository.BackupTables))
+	require.Equal(t, backup.Entity{Name: repository.UsersTableName, Columns: []string{"id"}, Rows: 1},
+		manifest.Entities[0])
+	require.Equal(t, []backup.File{{Key: "tasks/5/3", ContentType: "text/plain", Size: 4}}, manifest.Files)
+	require.Len(t, manifest.Sequences, len(repository.BackupSequences))
+
+	users, err := reader.OpenEntity(repository.UsersTableName)
+	require.NoError(t, err)
+	usersContent, err := io.ReadAll(users)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1,"login":"admin"}`+"\n", string(usersContent))
+}
+
+func TestBackupService_Validate(t *testing.T) {
+	backupService := NewBackupService(nil, nil, nil)
+
+	manifest := &backup.Manifest{
+		FormatVersion: backup.FormatVersion,
+		SchemaVersion: 20261019190000,
+		Entities:      []backup.Entity{{Name: repository.UsersTableName}},
+	}
+	require.NoError(t, backupService.Validate(manifest, 20261019200000))
+	require.ErrorContains(t, backupService.Validate(manifest, 20261019180000), "is newer than")
+
+	manifest.FormatVersion = backup.FormatVersion + 1
+	require.ErrorContains(t, backupService.Validate(manifest, 20261019200000), "unsupported backup format")
+
+	manifest.FormatVersion = backup.FormatVersion
+	manifest.Entities = append(manifest.Entities, backup.Entity{Name: "pg_authid"})
+	require.ErrorContains(t, backupService.Validate(manifest, 20261019200000), `unknown backup entity "pg_authid"`)
+}
+
+func TestBackupService_Restore(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	backupRepo := mockRepository.NewMockIBackupRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	backupService := NewBackupService(backupRepo, newTransactorMock(ctrl), attachmentStorage)
+
+	archive := newTestBackup(t, map[string]string{
+		repository.UsersTableName: `{"id":1,"login":"admin"}` + "\n" + `{"id":100,"login":"dev"}` + "\n",
+		repository.TasksTableName: `{"id":5,"title":"Release"}` + "\n",
+	})
+
+	backupRepo.EXPECT().IsEmpty(gomock.Any(), repository.UsersTableName, migrationUserIDs).Return(true, nil)
+	backupRepo.EXPECT().IsEmpty(gomock.Any(), gomock.Not(repository.UsersTableName), nil).Return(true, nil).
+		Times(len(repository.BackupTables) - 1)
+	attachmentStorage.EXPECT().Put(gomock.Any(), "tasks/5/3", gomock.Any(), int64(4), "text/plain").Return(nil)
+	gomock.InOrder(
+		backupRepo.EXPECT().DeleteAll(gomock.Any(), repository.UsersTableName).Return(nil),
+		backupRepo.EXPECT().InsertRows(gomock.Any(), repository.UsersTableName, []string{"id", "login"},
+			[]json.RawMessage{json.RawMessage(`{"id":1,"login":"admin"}`), json.RawMessage(`{"id":100,"login":"dev"}`)},
+		).Return(nil),
+		backupRepo.EXPECT().InsertRows(gomock.Any(), repository.TasksTableName, []string{"id", "title"},
+			[]json.RawMessage{json.RawMessage(`{"id":5,"title":"Release"}`)},
+		).Return(nil),
+		backupRepo.EXPECT().SetSequence(gomock.Any(), "users_sequence",
+			&repository.SequenceValue{LastValue: 100, IsCalled: true}).Return(nil),
+	)
+
+	require.NoError(t, backupService.Restore(ctx, archive))
+}
+
+func TestBackupService_Restore_DatabaseNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	backupRepo := mockRepository.NewMockIBackupRepo(ctrl)
+	backupService := NewBackupService(backupRepo, nil, nil)
+
+	backupRepo.EXPECT().IsEmpty(gomock.Any(), repository.UsersTableName, migrationUserIDs).Return(true, nil)
+	backupRepo.EXPECT().IsEmpty(gomock.Any(), repository.TasksTableName, nil).Return(false, nil)
+
+	err := backupService.Restore(ctx, newTestBackup(t, nil))
+	require.ErrorIs(t, err, ErrDatabaseNotEmpty)
+}
+
+// newTestBackup собирает архив с сущностями users и tasks, одним файлом вложения и последовательностью.
+func newTestBackup(t *testing.T, entities map[string]string) *backup.Reader {
+	t.Helper()
+
+	var archive bytes.Buffer
+	writer := backup.NewWriter(&archive)
+	for _, table := range []string{repository.UsersTableName, repository.TasksTableName} {
+		entityWriter, err := writer.CreateEntity(table)
+		require.NoError(t, err)
+		_, err = io.WriteString(entityWriter, entities[table])
+		require.NoError(t, err)
+	}
+	fileWriter, err := writer.CreateFile("tasks/5/3")
+	require.NoError(t, err)
+	_, err = io.WriteString(fileWriter, "logs")
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Close(&backup.Manifest{
+		FormatVersion: backup.FormatVersion,
+		Entities: []backup.Entity{
+			{Name: repository.TasksTableName, Columns: []string{"id", "title"}, Rows: 1},
+			{Name: repository.UsersTableName, Columns: []string{"id", "login"}, Rows: 2},
+		},
+		Sequences: []backup.Sequence{{Name: "users_sequence", LastValue: 100, IsCalled: true}},
+		Files:     []backup.File{{Key: "tasks/5/3", ContentType: "text/plain", Size: 4}},
+	}))
+
+	reader, err := backup.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	require.NoError(t, err)
+
+	return reader
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/ical"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// Виды записей календаря: события видят все календарные приложения, задачи (VTODO) - только часть из них.
+const (
+	EventCalendarKind = "event"
+	TodoCalendarKind  = "todo"
+)
+
+// todoStatuses сопоставляет статусы задач статусам VTODO.
+var todoStatuses = map[string]string{
+	constant.OpenTaskStatus:       "NEEDS-ACTION",
+	constant.InProgressTaskStatus: "IN-PROCESS",
+	constant.DoneTaskStatus:       "COMPLETED",
+}
+
+// CalendarFeedInfo - адрес календаря пользователя для подписки в календарном приложении.
+type CalendarFeedInfo struct {
+	URL       string    `json:"url" example:"http://localhost:8080/calendar/3f2a.ics"`
+	AllTasks  bool      `json:"allTasks"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ICalendarService interface {
+	GetFeed(ctx context.Context, user *repository.User) (*CalendarFeedInfo, error)
+	RotateToken(ctx context.Context, user *repository.User, allTasks bool) (*CalendarFeedInfo, error)
+	WriteFeed(ctx context.Context, token, kind string, w io.Writer) error
+	Watch(ctx context.Context, user *repository.User, taskID int) error
+	Unwatch(ctx context.Context, user *repository.User, taskID int) error
+}
+
+type CalendarService struct {
+	taskRepository     repository.ITaskRepo
+	userRepository     repository.IUserRepo
+	watcherRepository  repository.IWatcherRepo
+	calendarRepository repository.ICalendarRepo
+	cfg                *config.Calendar
+}
+
+func NewCalendarService(
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	watcherRepository repository.IWatcherRepo,
+	calendarRepository repository.ICalendarRepo,
+	cfg *config.Calendar,
+) *CalendarService {
+	return &CalendarService{
+		taskRepository:     taskRepository,
+		userRepository:     userRepository,
+		watcherRepository:  watcherRepository,
+		calendarRepository: calendarRepository,
+		cfg:                cfg,
+	}
+}
+
+// GetFeed возвращает календарь пользователя или errs.NotFoundErr, если токен ещё не выпущен.
+func (s *CalendarService) GetFeed(ctx context.Context, user *repository.User) (*CalendarFeedInfo, error) {
+	feed, err := s.calendarRepository.GetByUserID(ctx, user.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.feedInfo(feed), nil
+}
+
+// RotateToken выпускает новый токен календаря; прежний адрес сразу перестаёт работать.
+// allTasks - календарь со сроками задач всех пользователей, доступен только ADMIN.
+func (s *CalendarService) RotateToken(ctx context.Context,
+	user *repository.User,
+	allTasks bool,
+) (*CalendarFeedInfo, error) {
+	if allTasks && user.Role != constant.AdminRole {
+		return nil, errs.ForbiddenErr{}
+	}
+
+	token, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &repository.CalendarFeed{UserID: user.ID, Token: token, AllTasks: allTasks, CreatedAt: time.Now()}
+	if err = s.calendarRepository.Save(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	return s.feedInfo(feed), nil
+}
+
+// WriteFeed пишет в w календарь по токену с задачами, у которых задан срок: назначенными владельцу календаря
+// и теми, за которыми он следит. Календарь со всеми задачами доступен только ADMIN.
+// Календарь собирается при каждом запросе и всегда отражает текущее состояние задач.
+// Неизвестный токен или заблокированный владелец - errs.NotFoundErr.
+func (s *CalendarService) WriteFeed(ctx context.Context, token, kind string, w io.Writer) error {
+	if kind != EventCalendarKind && kind != TodoCalendarKind {
+		return errs.BadReqErr{}
+	}
+
+	feed, err := s.calendarRepository.GetByToken(ctx, token)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errs.NotFoundErr{}
+	} else if err != nil {
+		return err
+	}
+
+	user, err := s.userRepository.GetByID(ctx, feed.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errs.NotFoundErr{}
+	} else if err != nil {
+		return err
+	}
+	if !user.Active {
+		return errs.NotFoundErr{}
+	}
+
+	userID, name := user.ID, "Задачи "+user.Login
+	if feed.AllTasks && user.Role == constant.AdminRole {
+		userID, name = 0, "Все задачи"
+	}
+	tasks, err := s.taskRepository.GetWithDueDate(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	writer := ical.NewWriter(w, name, s.cfg.RefreshInterval)
+	for i := range tasks {
+		s.writeTask(writer, kind, &tasks[i])
+	}
+
+	return writer.Close()
+}
+
+// Watch добавляет задачу в календарь пользователя, даже если она назначена не ему. Следить можно только за
+// задачей, которую пользователь может открыть: своей, а ADMIN - за любой.
+func (s *CalendarService) Watch(ctx context.Context, user *repository.User, taskID int) error {
+	if _, err := getAccessibleTask(ctx, s.taskRepository, user, taskID); err != nil {
+		return err
+	}
+
+	return s.watcherRepository.Add(ctx, taskID, user.ID)
+}
+
+func (s *CalendarService) Unwatch(ctx context.Context, user *repository.User, taskID int) error {
+	return s.watcherRepository.Delete(ctx, taskID, user.ID)
+}
+
+func (s *CalendarService) writeTask(writer *ical.Writer, kind string, task *repository.TaskWithLogin) {
+	component := "VEVENT"
+	if kind == TodoCalendarKind {
+		component = "VTODO"
+	}
+
+	writer.Begin(component)
+	writer.Property("UID", fmt.Sprintf("task-%d@task-manager", task.ID))
+	writer.Time("DTSTAMP", task.UpdatedAt)
+	writer.Time("LAST-MODIFIED", task.UpdatedAt)
+	writer.Property("SEQUENCE", strconv.Itoa(task.Version))
+	if kind == TodoCalendarKind {
+		writer.Time("DUE", *task.DueAt)
+		writer.Property("STATUS", todoStatuses[task.Status])
+	} else {
+		writer.Time("DTSTART", *task.DueAt)
+	}
+	writer.Text("SUMMARY", fmt.Sprintf("#%d %s", task.ID, task.Title))
+	writer.Text("DESCRIPTION", fmt.Sprintf("%s\n\nИсполнитель: %s, статус: %s",
+		task.Description, task.UserLogin, task.Status))
+	// приоритеты 1-4 задачи переводятся в шкалу iCalendar, где 1 - наивысший, 9 - низший.
+	writer.Property("PRIORITY", strconv.Itoa(task.Priority*2-1))
+	writer.Property("URL", fmt.Sprintf("%s/tasks/%d", s.cfg.BaseURL, task.ID))
+	if len(task.Labels) > 0 {
+		writer.TextList("CATEGORIES", task.Labels)
+	}
+	writer.End(component)
+}
+
+func (s *CalendarService) feedInfo(feed *repository.CalendarFeed) *CalendarFeedInfo {
+	return &CalendarFeedInfo{
+		URL:       s.cfg.BaseURL + "/calendar/" + feed.Token + ".ics",
+		AllTasks:  feed.AllTasks,
+		CreatedAt: feed.CreatedAt,
+	}
+}
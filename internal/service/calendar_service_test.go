@@ -0,0 +1,167 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var calendarConfig = &config.Calendar{BaseURL: "http://tasks.example", RefreshInterval: 15 * time.Minute}
+
+func TestCalendarService_WriteFeed_Events(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarService := NewCalendarService(taskRepo, userRepo, nil, calendarRepo, calendarConfig)
+
+	dueAt := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "secret").
+		Return(&repository.CalendarFeed{UserID: 2, Token: "secret"}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.User{ID: 2, Login: "user", Role: constant.UserRole, Active: true}, nil)
+	taskRepo.EXPECT().GetWithDueDate(gomock.Any(), 2).Return([]repository.TaskWithLogin{{
+		ID:          5,
+		Title:       "Release",
+		Description: "Publish 1.2",
+		Priority:    2,
+		Status:      constant.InProgressTaskStatus,
+		UpdatedAt:   dueAt.Add(-time.Hour),
+		UserLogin:   "user",
+		Version:     3,
+		Labels:      []string{"backend"},
+		DueAt:       &dueAt,
+	}}, nil)
+
+	var calendar strings.Builder
+	err := calendarService.WriteFeed(ctx, "secret", EventCalendarKind, &calendar)
+	require.NoError(t, err)
+	require.Contains(t, calendar.String(), "X-WR-CALNAME:Задачи user\r\n")
+	require.Contains(t, calendar.String(), "BEGIN:VEVENT\r\n"+
+		"UID:task-5@task-manager\r\n"+
+		"DTSTAMP:20261020T140000Z\r\n"+
+		"LAST-MODIFIED:20261020T140000Z\r\n"+
+		"SEQUENCE:3\r\n"+
+		"DTSTART:20261020T150000Z\r\n"+
+		"SUMMARY:#5 Release\r\n")
+	require.Contains(t, calendar.String(), "PRIORITY:3\r\n"+
+		"URL:http://tasks.example/tasks/5\r\n"+
+		"CATEGORIES:backend\r\n"+
+		"END:VEVENT\r\n")
+}
+
+func TestCalendarService_WriteFeed_AdminAllTasks(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarService := NewCalendarService(taskRepo, userRepo, nil, calendarRepo, calendarConfig)
+
+	dueAt := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "secret").
+		Return(&repository.CalendarFeed{UserID: 1, Token: "secret", AllTasks: true}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 1).
+		Return(&repository.User{ID: 1, Login: "admin", Role: constant.AdminRole, Active: true}, nil)
+	taskRepo.EXPECT().GetWithDueDate(gomock.Any(), 0).Return([]repository.TaskWithLogin{
+		{ID: 5, Title: "Release", Priority: 1, Status: constant.DoneTaskStatus, DueAt: &dueAt},
+	}, nil)
+
+	var calendar strings.Builder
+	err := calendarService.WriteFeed(ctx, "secret", TodoCalendarKind, &calendar)
+	require.NoError(t, err)
+	require.Contains(t, calendar.String(), "X-WR-CALNAME:Все задачи\r\n")
+	require.Contains(t, calendar.String(), "DUE:20261020T150000Z\r\nSTATUS:COMPLETED\r\n")
+	require.NotContains(t, calendar.String(), "VEVENT")
+}
+
+func TestCalendarService_WriteFeed_NotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarService := NewCalendarService(nil, userRepo, nil, calendarRepo, calendarConfig)
+
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "unknown").Return(nil, pgx.ErrNoRows)
+	err := calendarService.WriteFeed(ctx, "unknown", EventCalendarKind, &strings.Builder{})
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+
+	calendarRepo.EXPECT().GetByToken(gomock.Any(), "blocked").Return(&repository.CalendarFeed{UserID: 2}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.User{ID: 2, Active: false}, nil)
+	err = calendarService.WriteFeed(ctx, "blocked", EventCalendarKind, &strings.Builder{})
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+}
+
+func TestCalendarService_WriteFeed_UnknownKind(t *testing.T) {
+	calendarService := NewCalendarService(nil, nil, nil, nil, calendarConfig)
+
+	err := calendarService.WriteFeed(context.Background(), "secret", "journal", &strings.Builder{})
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
+
+func TestCalendarService_RotateToken(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	calendarRepo := mockRepository.NewMockICalendarRepo(ctrl)
+	calendarService := NewCalendarService(nil, nil, nil, calendarRepo, calendarConfig)
+
+	var savedToken string
+	calendarRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, feed *repository.CalendarFeed) error {
+			require.Equal(t, 2, feed.UserID)
+			require.Len(t, feed.Token, 64)
+			savedToken = feed.Token
+			return nil
+		})
+
+	feed, err := calendarService.RotateToken(ctx, &repository.User{ID: 2, Role: constant.UserRole}, false)
+	require.NoError(t, err)
+	require.Equal(t, "http://tasks.example/calendar/"+savedToken+".ics", feed.URL)
+}
+
+func TestCalendarService_RotateToken_AllTasksForbidden(t *testing.T) {
+	calendarService := NewCalendarService(nil, nil, nil, nil, calendarConfig)
+
+	_, err := calendarService.RotateToken(context.Background(), &repository.User{ID: 2, Role: constant.UserRole}, true)
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+}
+
+func TestCalendarService_Watch(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	calendarService := NewCalendarService(taskRepo, nil, watcherRepo, nil, calendarConfig)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.Task{ID: 5, UserID: 2}, nil).Times(2)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 6).Return(nil, pgx.ErrNoRows)
+	watcherRepo.EXPECT().Add(gomock.Any(), 5, 1).Return(nil)
+
+	t.Run("администратор следит за чужой задачей", func(t *testing.T) {
+		err := calendarService.Watch(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, 5)
+		require.NoError(t, err)
+	})
+
+	t.Run("пользователь не может следить за чужой задачей", func(t *testing.T) {
+		err := calendarService.Watch(ctx, &repository.User{ID: 3, Role: constant.UserRole}, 5)
+		require.ErrorIs(t, err, errs.ForbiddenErr{})
+	})
+
+	t.Run("задача не найдена", func(t *testing.T) {
+		err := calendarService.Watch(ctx, &repository.User{ID: 3, Role: constant.UserRole}, 6)
+		require.ErrorIs(t, err, errs.NotFoundErr{})
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// DefaultChartDays - период графиков в днях, если он не задан.
+const DefaultChartDays = 30
+
+// BurndownPoint - число невыполненных задач (OPEN и IN_PROGRESS) на конец дня. Ideal - сколько задач
+// оставалось бы при равномерном выполнении от первого дня периода до последнего.
+type BurndownPoint struct {
+	Day       time.Time `json:"day" example:"2026-10-12T00:00:00Z"`
+	Remaining int       `json:"remaining" example:"7"`
+	Ideal     float64   `json:"ideal" example:"6.5"`
+}
+
+// CumulativeFlowPoint - число задач в каждом статусе на конец дня.
+type CumulativeFlowPoint struct {
+	Day time.Time `json:"day" example:"2026-10-12T00:00:00Z"`
+	repository.StatusCounts
+}
+
+// IChartService строит графики по ежедневным снимкам числа задач в статусах. Видимость как в списке задач:
+// ADMIN видит все задачи, остальные - свои. Нулевые from и to - последние DefaultChartDays дней.
+// Дни без снимка пропускаются.
+type IChartService interface {
+	TakeSnapshot(ctx context.Context) error
+	GetBurndown(ctx context.Context, user *repository.User, from, to time.Time) ([]BurndownPoint, error)
+	GetCumulativeFlow(ctx context.Context, user *repository.User, from, to time.Time) ([]CumulativeFlowPoint, error)
+}
+
+type ChartService struct {
+	snapshotRepository repository.ITaskSnapshotRepo
+	transactor         repository.ITransactor
+	cfg                *config.Charts
+	now                func() time.Time
+}
+
+func NewChartService(
+	snapshotRepository repository.ITaskSnapshotRepo,
+	transactor repository.ITransactor,
+	cfg *config.Charts,
+) *ChartService {
+	return &ChartService{
+		snapshotRepository: snapshotRepository,
+		transactor:         transactor,
+		cfg:                cfg,
+		now:                time.Now,
+	}
+}
+
+// TakeSnapshot перезаписывает снимок за сегодня текущим числом задач в статусах.
+func (c *ChartService) TakeSnapshot(ctx context.Context) error {
+	today := chartDay(c.now())
+
+	return c.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return c.snapshotRepository.SaveDaily(ctx, today)
+	})
+}
+
+// RunSnapshots делает снимок сразу и затем каждые SnapshotInterval, пока не отменён ctx. Последний снимок дня
+// остаётся снимком на конец этого дня.
+func (c *ChartService) RunSnapshots(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.SnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.TakeSnapshot(ctx); err != nil {
+			slog.Error("cannot take task status snapshot", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetBurndown возвращает число невыполненных задач по дням периода и идеальную линию их выполнения к концу периода.
+func (c *ChartService) GetBurndown(ctx context.Context,
+	user *repository.User,
+	from, to time.Time,
+) ([]BurndownPoint, error) {
+	from, to, err := c.chartPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	flow, err := c.GetCumulativeFlow(ctx, user, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(flow) == 0 {
+		return []BurndownPoint{}, nil
+	}
+
+	total := to.Sub(flow[0].Day)
+	initial := float64(flow[0].Open + flow[0].InProgress)
+
+	burndown := make([]BurndownPoint, 0, len(flow))
+	for _, point := range flow {
+		ideal := 0.0
+		if total > 0 {
+			ideal = initial * float64(to.Sub(point.Day)) / float64(total)
+		}
+		burndown = append(burndown, BurndownPoint{
+			Day:       point.Day,
+			Remaining: point.Open + point.InProgress,
+			Ideal:     ideal,
+		})
+	}
+
+	return burndown, nil
+}
+
+// GetCumulativeFlow возвращает число задач в каждом статусе по дням периода.
+func (c *ChartService) GetCumulativeFlow(ctx context.Context,
+	user *repository.User,
+	from, to time.Time,
+) ([]CumulativeFlowPoint, error) {
+	from, to, err := c.chartPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := c.snapshotRepository.GetDaily(ctx, reportUserID(user), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	flow := make([]CumulativeFlowPoint, 0)
+	for _, count := range counts {
+		if len(flow) == 0 || !flow[len(flow)-1].Day.Equal(count.Day) {
+			flow = append(flow, CumulativeFlowPoint{Day: count.Day})
+		}
+		point := &flow[len(flow)-1]
+		switch count.Status {
+		case constant.OpenTaskStatus:
+			point.Open = count.Tasks
+		case constant.InProgressTaskStatus:
+			point.InProgress = count.Tasks
+		case constant.DoneTaskStatus:
+			point.Done = count.Tasks
+		}
+	}
+
+	return flow, nil
+}
+
+// chartPeriod подставляет период по умолчанию и проверяет, что from не позже to и период не длиннее MaxDays.
+func (c *ChartService) chartPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = chartDay(c.now())
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(DefaultChartDays - 1))
+	}
+	if from.After(to) || to.Sub(from) >= time.Duration(c.cfg.MaxDays)*24*time.Hour {
+		return time.Time{}, time.Time{}, errs.BadReqErr{}
+	}
+
+	return from, to, nil
+}
+
+// chartDay - день снимка: местная дата в полночь UTC, как её хранит столбец DATE.
+func chartDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
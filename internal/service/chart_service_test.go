@@ -0,0 +1,99 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestChartService(ctrl *gomock.Controller) (*ChartService, *mockRepository.MockITaskSnapshotRepo) {
+	snapshotRepo := mockRepository.NewMockITaskSnapshotRepo(ctrl)
+	transactor := mockRepository.NewMockITransactor(ctrl)
+	transactor.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	chartService := NewChartService(snapshotRepo, transactor, &config.Charts{SnapshotInterval: time.Hour, MaxDays: 90})
+	chartService.now = func() time.Time {
+		return time.Date(2026, time.October, 19, 23, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	}
+
+	return chartService, snapshotRepo
+}
+
+func TestChartService_TakeSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chartService, snapshotRepo := newTestChartService(ctrl)
+
+	snapshotRepo.EXPECT().SaveDaily(gomock.Any(), time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+	require.NoError(t, chartService.TakeSnapshot(context.Background()))
+}
+
+func TestChartService_GetCumulativeFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chartService, snapshotRepo := newTestChartService(ctrl)
+
+	first := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 2)
+	snapshotRepo.EXPECT().GetDaily(gomock.Any(), 2, time.Date(2026, time.September, 20, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)).Return([]repository.DailyStatusCount{
+		{Day: first, Status: constant.DoneTaskStatus, Tasks: 1},
+		{Day: first, Status: constant.OpenTaskStatus, Tasks: 4},
+		{Day: second, Status: constant.InProgressTaskStatus, Tasks: 2},
+	}, nil)
+
+	flow, err := chartService.GetCumulativeFlow(context.Background(),
+		&repository.User{ID: 2, Role: constant.UserRole}, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, []CumulativeFlowPoint{
+		{Day: first, StatusCounts: repository.StatusCounts{Open: 4, Done: 1}},
+		{Day: second, StatusCounts: repository.StatusCounts{InProgress: 2}},
+	}, flow)
+}
+
+func TestChartService_GetBurndown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chartService, snapshotRepo := newTestChartService(ctrl)
+
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, time.October, 6, 0, 0, 0, 0, time.UTC)
+	snapshotRepo.EXPECT().GetDaily(gomock.Any(), 0, from, to).Return([]repository.DailyStatusCount{
+		{Day: from.AddDate(0, 0, 1), Status: constant.OpenTaskStatus, Tasks: 6},
+		{Day: from.AddDate(0, 0, 1), Status: constant.InProgressTaskStatus, Tasks: 2},
+		{Day: from.AddDate(0, 0, 3), Status: constant.OpenTaskStatus, Tasks: 3},
+		{Day: from.AddDate(0, 0, 3), Status: constant.DoneTaskStatus, Tasks: 5},
+	}, nil)
+
+	burndown, err := chartService.GetBurndown(context.Background(),
+		&repository.User{ID: 1, Role: constant.AdminRole}, from, to)
+	require.NoError(t, err)
+	require.Equal(t, []BurndownPoint{
+		{Day: from.AddDate(0, 0, 1), Remaining: 8, Ideal: 8},
+		{Day: from.AddDate(0, 0, 3), Remaining: 3, Ideal: 4},
+	}, burndown)
+}
+
+func TestChartService_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chartService, _ := newTestChartService(ctrl)
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	from := time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC)
+
+	_, err := chartService.GetBurndown(context.Background(), user, from, from.AddDate(0, 0, -1))
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	_, err = chartService.GetCumulativeFlow(context.Background(), user, from, from.AddDate(0, 0, 90))
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
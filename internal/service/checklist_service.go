@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const maxChecklistItemTitleLen = 255
+
+type IChecklistService interface {
+	GetByTaskID(ctx context.Context, user *repository.User, taskID int) ([]repository.ChecklistItem, error)
+	GetProgress(ctx context.Context, taskIDs []int) (map[int]repository.ChecklistProgress, error)
+	Create(ctx context.Context, user *repository.User, taskID int, title string) (int, error)
+	SetDone(ctx context.Context, user *repository.User, taskID, itemID int, done bool) error
+	Move(ctx context.Context, user *repository.User, taskID, itemID, position int) error
+	Delete(ctx context.Context, user *repository.User, taskID, itemID int) error
+}
+
+type ChecklistService struct {
+	checklistRepository   repository.IChecklistRepo
+	taskRepository        repository.ITaskRepo
+	taskHistoryRepository repository.ITaskHistoryRepo
+}
+
+func NewChecklistService(
+	checklistRepository repository.IChecklistRepo,
+	taskRepository repository.ITaskRepo,
+	taskHistoryRepository repository.ITaskHistoryRepo,
+) *ChecklistService {
+	return &ChecklistService{
+		checklistRepository:   checklistRepository,
+		taskRepository:        taskRepository,
+		taskHistoryRepository: taskHistoryRepository,
+	}
+}
+
+// GetByTaskID возвращает чеклист задачи, доступной пользователю: ADMIN - любой, остальным - только своей.
+func (c *ChecklistService) GetByTaskID(ctx context.Context,
+	user *repository.User,
+	taskID int,
+) ([]repository.ChecklistItem, error) {
+	if _, err := getAccessibleTask(ctx, c.taskRepository, user, taskID); err != nil {
+		return nil, err
+	}
+
+	return c.checklistRepository.GetByTaskID(ctx, taskID)
+}
+
+func (c *ChecklistService) GetProgress(
+	ctx context.Context,
+	taskIDs []int,
+) (map[int]repository.ChecklistProgress, error) {
+	return c.checklistRepository.GetProgressByTaskIDs(ctx, taskIDs)
+}
+
+func (c *ChecklistService) Create(ctx context.Context, user *repository.User, taskID int, title string) (int, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || len(title) > maxChecklistItemTitleLen {
+		return 0, errs.BadReqErr{}
+	}
+
+	if _, err := getAccessibleTask(ctx, c.taskRepository, user, taskID); err != nil {
+		return 0, err
+	}
+
+	return c.checklistRepository.Create(ctx, &repository.ChecklistItem{
+		TaskID: taskID,
+		Title:  title,
+	})
+}
+
+func (c *ChecklistService) SetDone(
+	ctx context.Context,
+	user *repository.User,
+	taskID, itemID int,
+	done bool,
+) error {
+	item, err := c.getTaskItem(ctx, user, taskID, itemID)
+	if err != nil {
+		return err
+	}
+
+	if item.Done == done {
+		return nil
+	}
+
+	if setDoneErr := c.checklistRepository.SetDone(ctx, itemID, done); setDoneErr != nil {
+		return setDoneErr
+	}
+
+	action := constant.ChecklistItemUncheckedAction
+	if done {
+		action = constant.ChecklistItemCheckedAction
+	}
+
+	return c.taskHistoryRepository.Create(ctx, &repository.TaskHistory{
+		TaskID:   taskID,
+		UserID:   user.ID,
+		Action:   action,
+		NewValue: item.Title,
+	})
+}
+
+func (c *ChecklistService) Move(ctx context.Context, user *repository.User, taskID, itemID, position int) error {
+	if position < 1 {
+		return errs.BadReqErr{}
+	}
+
+	if _, err := c.getTaskItem(ctx, user, taskID, itemID); err != nil {
+		return err
+	}
+
+	return c.checklistRepository.Move(ctx, taskID, itemID, position)
+}
+
+func (c *ChecklistService) Delete(ctx context.Context, user *repository.User, taskID, itemID int) error {
+	if _, err := c.getTaskItem(ctx, user, taskID, itemID); err != nil {
+		return err
+	}
+
+	return c.checklistRepository.DeleteByID(ctx, itemID)
+}
+
+func (c *ChecklistService) getTaskItem(ctx context.Context,
+	user *repository.User,
+	taskID, itemID int,
+) (*repository.ChecklistItem, error) {
+	if _, err := getAccessibleTask(ctx, c.taskRepository, user, taskID); err != nil {
+		return nil, err
+	}
+
+	item, err := c.checklistRepository.GetByID(ctx, itemID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if item.TaskID != taskID {
+		return nil, errs.NotFoundErr{}
+	}
+
+	return item, nil
+}
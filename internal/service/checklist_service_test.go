@@ -0,0 +1,175 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var checklistUser = &repository.User{ID: 7, Role: constant.UserRole}
+
+// newOwnTaskRepo возвращает репозиторий, в котором задача 1 принадлежит checklistUser.
+func newOwnTaskRepo(ctrl *gomock.Controller) *mockRepository.MockITaskRepo {
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 7}, nil).AnyTimes()
+
+	return taskRepo
+}
+
+func TestChecklistService_Create_ItemCreated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, taskRepo, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 7}, nil)
+	checklistRepo.EXPECT().Create(gomock.Any(), &repository.ChecklistItem{TaskID: 1, Title: "step"}).Return(5, nil)
+
+	itemID, err := checklistService.Create(ctx, checklistUser, 1, "  step ")
+	require.NoError(t, err)
+	require.Equal(t, 5, itemID)
+}
+
+func TestChecklistService_Create_EmptyTitle(t *testing.T) {
+	ctx := context.Background()
+	checklistService := NewChecklistService(nil, nil, nil)
+
+	itemID, err := checklistService.Create(ctx, checklistUser, 1, "   ")
+	require.Equal(t, errs.BadReqErr{}, err)
+	require.Equal(t, 0, itemID)
+}
+
+func TestChecklistService_Create_TaskNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	checklistService := NewChecklistService(nil, taskRepo, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	itemID, err := checklistService.Create(ctx, checklistUser, 1, "step")
+	require.Equal(t, errs.NotFoundErr{}, err)
+	require.Equal(t, 0, itemID)
+}
+
+func TestChecklistService_SetDone_HistoryRecorded(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	taskRepo := newOwnTaskRepo(ctrl)
+	historyRepo := mockRepository.NewMockITaskHistoryRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, taskRepo, historyRepo)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.ChecklistItem{ID: 2, TaskID: 1, Title: "step"}, nil)
+	checklistRepo.EXPECT().SetDone(gomock.Any(), 2, true).Return(nil)
+	historyRepo.EXPECT().Create(gomock.Any(), &repository.TaskHistory{
+		TaskID:   1,
+		UserID:   7,
+		Action:   constant.ChecklistItemCheckedAction,
+		NewValue: "step",
+	}).Return(nil)
+
+	err := checklistService.SetDone(ctx, checklistUser, 1, 2, true)
+	require.NoError(t, err)
+}
+
+func TestChecklistService_SetDone_AlreadyInState(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, newOwnTaskRepo(ctrl), nil)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.ChecklistItem{ID: 2, TaskID: 1, Done: true}, nil)
+
+	err := checklistService.SetDone(ctx, checklistUser, 1, 2, true)
+	require.NoError(t, err)
+}
+
+func TestChecklistService_SetDone_ItemOfAnotherTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, newOwnTaskRepo(ctrl), nil)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.ChecklistItem{ID: 2, TaskID: 3}, nil)
+
+	err := checklistService.SetDone(ctx, checklistUser, 1, 2, true)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+func TestChecklistService_Move_InvalidPosition(t *testing.T) {
+	ctx := context.Background()
+	checklistService := NewChecklistService(nil, nil, nil)
+
+	err := checklistService.Move(ctx, checklistUser, 1, 2, 0)
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestChecklistService_Move_ItemMoved(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, newOwnTaskRepo(ctrl), nil)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.ChecklistItem{ID: 2, TaskID: 1}, nil)
+	checklistRepo.EXPECT().Move(gomock.Any(), 1, 2, 3).Return(nil)
+
+	err := checklistService.Move(ctx, checklistUser, 1, 2, 3)
+	require.NoError(t, err)
+}
+
+func TestChecklistService_Delete_ItemNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, newOwnTaskRepo(ctrl), nil)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).Return(nil, pgx.ErrNoRows)
+
+	err := checklistService.Delete(ctx, checklistUser, 1, 2)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+func TestChecklistService_Delete_InternalErr(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	checklistService := NewChecklistService(checklistRepo, newOwnTaskRepo(ctrl), nil)
+
+	checklistRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.ChecklistItem{ID: 2, TaskID: 1}, nil)
+	checklistRepo.EXPECT().DeleteByID(gomock.Any(), 2).Return(errors.New(""))
+
+	err := checklistService.Delete(ctx, checklistUser, 1, 2)
+	require.Error(t, err)
+}
+
+func TestChecklistService_OtherUsersTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	checklistService := NewChecklistService(nil, taskRepo, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil).Times(4)
+
+	_, err := checklistService.GetByTaskID(ctx, checklistUser, 1)
+	require.Equal(t, errs.ForbiddenErr{}, err)
+	_, err = checklistService.Create(ctx, checklistUser, 1, "step")
+	require.Equal(t, errs.ForbiddenErr{}, err)
+	err = checklistService.SetDone(ctx, checklistUser, 1, 2, true)
+	require.Equal(t, errs.ForbiddenErr{}, err)
+	err = checklistService.Delete(ctx, checklistUser, 1, 2)
+	require.Equal(t, errs.ForbiddenErr{}, err)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// maxFieldValueLength - сколько символов значения поля пересылается другим участникам.
+const maxFieldValueLength = 10000
+
+// collabFields - поля формы редактирования задачи, об изменении которых сообщается участникам.
+var collabFields = []string{"Title", "Description", "Priority", "Status"}
+
+type ICollabService interface {
+	CheckAccess(ctx context.Context, user *repository.User, taskID int) error
+	Join(taskID int, user *repository.User) *collab.Client
+	Leave(taskID int, client *collab.Client)
+	ChangeField(taskID int, client *collab.Client, field, value string) error
+}
+
+// CollabService ведёт каналы совместной работы над задачами: кто открыл задачу, какие поля сейчас меняются,
+// какие изменения сохранены и какие комментарии добавлены.
+type CollabService struct {
+	hub            *collab.Hub
+	taskRepository repository.ITaskRepo
+	userRepository repository.IUserRepo
+}
+
+func NewCollabService(
+	hub *collab.Hub,
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+) *CollabService {
+	return &CollabService{
+		hub:            hub,
+		taskRepository: taskRepository,
+		userRepository: userRepository,
+	}
+}
+
+// CheckAccess проверяет, может ли пользователь подключиться к каналу задачи.
+func (co *CollabService) CheckAccess(ctx context.Context, user *repository.User, taskID int) error {
+	_, err := getAccessibleTask(ctx, co.taskRepository, user, taskID)
+	return err
+}
+
+// Join подключает пользователя к каналу задачи. Доступ проверяется заранее через CheckAccess.
+func (co *CollabService) Join(taskID int, user *repository.User) *collab.Client {
+	return co.hub.Join(taskID, user.Login)
+}
+
+func (co *CollabService) Leave(taskID int, client *collab.Client) {
+	co.hub.Leave(taskID, client)
+}
+
+// ChangeField сообщает остальным участникам, что клиент меняет поле формы. Значение ещё не сохранено.
+func (co *CollabService) ChangeField(taskID int, client *collab.Client, field, value string) error {
+	if !slices.Contains(collabFields, field) || utf8.RuneCountInString(value) > maxFieldValueLength {
+		return errs.BadReqErr{}
+	}
+
+	co.hub.Broadcast(taskID, collab.Message{
+		Type:  collab.FieldMessage,
+		User:  client.Login,
+		Field: field,
+		Value: value,
+	}, client)
+
+	return nil
+}
+
+func (co *CollabService) Name() string {
+	return "task-collab"
+}
+
+// Handle сообщает участникам канала о сохранении или удалении задачи.
+func (co *CollabService) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	var messageType string
+	switch event.Event {
+	case constant.TaskUpdatedEvent:
+		messageType = collab.SavedMessage
+	case constant.TaskDeletedEvent:
+		messageType = collab.DeletedMessage
+	default:
+		return nil
+	}
+
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+
+	message := collab.Message{Type: messageType, Task: &taskEvent.Task}
+	if taskEvent.ActorID != 0 {
+		actor, err := co.userRepository.GetByID(ctx, taskEvent.ActorID)
+		if err != nil {
+			return err
+		}
+		message.User = actor.Login
+	}
+	co.hub.Broadcast(taskEvent.Task.ID, message, nil)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCollabService_CheckAccess_OtherUsersTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	collabService := NewCollabService(collab.NewHub(10), taskRepo, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+
+	err := collabService.CheckAccess(ctx, &repository.User{ID: 1, Role: constant.UserRole}, 1)
+	require.Equal(t, errs.ForbiddenErr{}, err)
+}
+
+func TestCollabService_ChangeField_OthersNotified(t *testing.T) {
+	hub := collab.NewHub(10)
+	collabService := NewCollabService(hub, nil, nil)
+	editor := collabService.Join(1, &repository.User{ID: 1, Login: "user"})
+	viewer := collabService.Join(1, &repository.User{ID: 2, Login: "admin"})
+	<-editor.Messages()
+	<-editor.Messages()
+	<-viewer.Messages()
+
+	err := collabService.ChangeField(1, editor, "Title", "Release")
+	require.NoError(t, err)
+	require.Equal(t, collab.Message{Type: collab.FieldMessage, User: "user", Field: "Title", Value: "Release"},
+		<-viewer.Messages())
+	require.Empty(t, editor.Messages())
+}
+
+func TestCollabService_ChangeField_UnknownField(t *testing.T) {
+	hub := collab.NewHub(10)
+	collabService := NewCollabService(hub, nil, nil)
+	editor := collabService.Join(1, &repository.User{ID: 1, Login: "user"})
+
+	err := collabService.ChangeField(1, editor, "UserLogin", "admin")
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestCollabService_Handle_SavedBroadcast(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	hub := collab.NewHub(10)
+	collabService := NewCollabService(hub, nil, userRepo)
+	viewer := collabService.Join(5, &repository.User{ID: 1, Login: "user"})
+	<-viewer.Messages()
+
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.User{ID: 2, Login: "admin"}, nil)
+
+	err := collabService.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:    repository.Task{ID: 5, Title: "Release", Status: "DONE"},
+		ActorID: 2,
+	}))
+	require.NoError(t, err)
+
+	message := <-viewer.Messages()
+	require.Equal(t, collab.SavedMessage, message.Type)
+	require.Equal(t, "admin", message.User)
+	require.Equal(t, "DONE", message.Task.Status)
+}
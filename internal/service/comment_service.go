@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// maxCommentLength - максимальная длина комментария в символах.
+const maxCommentLength = 5000
+
+type ICommentService interface {
+	Create(ctx context.Context, user *repository.User, taskID int, body string) (*repository.Comment, error)
+	GetByTaskID(ctx context.Context, taskID int) ([]repository.Comment, error)
+}
+
+type CommentService struct {
+	commentRepository   repository.ICommentRepo
+	taskRepository      repository.ITaskRepo
+	hub                 *collab.Hub
+	notificationService INotificationService
+	mentionService      IMentionService
+}
+
+func NewCommentService(
+	commentRepository repository.ICommentRepo,
+	taskRepository repository.ITaskRepo,
+	hub *collab.Hub,
+	notificationService INotificationService,
+	mentionService IMentionService,
+) *CommentService {
+	return &CommentService{
+		commentRepository:   commentRepository,
+		taskRepository:      taskRepository,
+		hub:                 hub,
+		notificationService: notificationService,
+		mentionService:      mentionService,
+	}
+}
+
+// Create добавляет комментарий к задаче, рассылает его открывшим задачу пользователям и уведомляет упомянутых
+// в нём пользователей и владельца задачи. Упомянутый владелец получает только уведомление об упоминании.
+func (co *CommentService) Create(ctx context.Context,
+	user *repository.User,
+	taskID int,
+	body string,
+) (*repository.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return nil, errs.BadReqErr{}
+	}
+
+	task, err := getAccessibleTask(ctx, co.taskRepository, user, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &repository.Comment{TaskID: taskID, UserID: user.ID, Body: body}
+	if err = co.commentRepository.Create(ctx, comment); err != nil {
+		return nil, err
+	}
+	comment.UserLogin = user.Login
+	if comment.BodyHTML, err = co.mentionService.Render(ctx, body); err != nil {
+		return nil, err
+	}
+
+	co.hub.Broadcast(taskID, collab.Message{Type: collab.CommentMessage, User: user.Login, Comment: comment}, nil)
+	co.notify(ctx, user, task, body)
+
+	return comment, nil
+}
+
+// GetByTaskID возвращает комментарии задачи с текстом, отрендеренным из Markdown.
+func (co *CommentService) GetByTaskID(ctx context.Context, taskID int) ([]repository.Comment, error) {
+	comments, err := co.commentRepository.GetByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range comments {
+		if comments[i].BodyHTML, err = co.mentionService.Render(ctx, comments[i].Body); err != nil {
+			return nil, err
+		}
+	}
+
+	return comments, nil
+}
+
+// notify уведомляет о комментарии упомянутых в нём пользователей и владельца задачи. Комментарий уже сохранён,
+// поэтому ошибки только логируются.
+func (co *CommentService) notify(ctx context.Context, actor *repository.User, task *repository.Task, body string) {
+	mentioned, err := co.mentionService.NotifyCommentMentions(ctx, actor, task.ID, body)
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot notify users mentioned in comment",
+			slog.Int("taskId", task.ID),
+			slog.Any("error", err),
+		)
+	}
+	for _, user := range mentioned {
+		if user.ID == task.UserID {
+			return
+		}
+	}
+
+	err = co.notificationService.Notify(ctx, actor, &repository.Notification{
+		UserID:  task.UserID,
+		TaskID:  task.ID,
+		Event:   constant.CommentAddedEvent,
+		Message: fmt.Sprintf("Новый комментарий к задаче «%s»", task.Title),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot save comment notification",
+			slog.Int("taskId", task.ID),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,141 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/collab"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommentService_Create_CommentBroadcast(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	commentRepo := mockRepository.NewMockICommentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	hub := collab.NewHub(10)
+	commentService := NewCommentService(commentRepo, taskRepo, hub, NewNotificationService(nil, disabledEmailService),
+		NewMentionService(nil, nil, nil, nil))
+	viewer := hub.Join(1, "admin")
+	<-viewer.Messages()
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2}, nil)
+	commentRepo.EXPECT().Create(gomock.Any(), &repository.Comment{TaskID: 1, UserID: 2, Body: "Looks good"}).
+		DoAndReturn(func(_ context.Context, comment *repository.Comment) error {
+			comment.ID = 4
+			return nil
+		})
+
+	comment, err := commentService.Create(ctx, &repository.User{ID: 2, Login: "user", Role: constant.UserRole}, 1,
+		"  Looks good ")
+	require.NoError(t, err)
+	require.Equal(t, 4, comment.ID)
+	require.Equal(t, "user", comment.UserLogin)
+	require.Equal(t, template.HTML("<p>Looks good</p>\n"), comment.BodyHTML)
+
+	message := <-viewer.Messages()
+	require.Equal(t, collab.CommentMessage, message.Type)
+	require.Equal(t, comment, message.Comment)
+}
+
+func newTestCommentNotifyService(ctrl *gomock.Controller) (
+	*CommentService,
+	*mockRepository.MockITaskRepo,
+	*mockRepository.MockIUserRepo,
+	*mockRepository.MockINotificationRepo,
+) {
+	commentRepo := mockRepository.NewMockICommentRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	notificationService := NewNotificationService(notificationRepo, disabledEmailService)
+	mentionService := NewMentionService(nil, taskRepo, userRepo, notificationService)
+	commentService := NewCommentService(commentRepo, taskRepo, collab.NewHub(10), notificationService, mentionService)
+
+	commentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	return commentService, taskRepo, userRepo, notificationRepo
+}
+
+func TestCommentService_Create_OwnerAndMentionedNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	commentService, taskRepo, userRepo, notificationRepo := newTestCommentNotifyService(ctrl)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2, Title: "Title"}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "bob").Return(&repository.User{ID: 3, Login: "bob", Active: true}, nil).
+		Times(2)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), gomock.Any()).
+		Return([]repository.NotificationPreference{}, nil).Times(2)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  3,
+		TaskID:  1,
+		Event:   constant.UserMentionedEvent,
+		Message: "Вас упомянули в комментарии к задаче #1",
+	}).Return(nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  2,
+		TaskID:  1,
+		Event:   constant.CommentAddedEvent,
+		Message: "Новый комментарий к задаче «Title»",
+	}).Return(nil)
+
+	comment, err := commentService.Create(ctx, &repository.User{ID: 1, Login: "admin", Role: constant.AdminRole}, 1,
+		"**Please** check, @bob")
+	require.NoError(t, err)
+	require.Equal(t, template.HTML(`<p><strong>Please</strong> check, `+
+		`<a href="/tasks/user/bob" rel="nofollow">@bob</a></p>`+"\n"), comment.BodyHTML)
+}
+
+func TestCommentService_Create_MentionedOwnerNotifiedOnce(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	commentService, taskRepo, userRepo, notificationRepo := newTestCommentNotifyService(ctrl)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2, Title: "Title"}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "alice").
+		Return(&repository.User{ID: 2, Login: "alice", Active: true}, nil).Times(2)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 2).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  2,
+		TaskID:  1,
+		Event:   constant.UserMentionedEvent,
+		Message: "Вас упомянули в комментарии к задаче #1",
+	}).Return(nil)
+
+	_, err := commentService.Create(ctx, &repository.User{ID: 1, Login: "admin", Role: constant.AdminRole}, 1,
+		"@alice please check")
+	require.NoError(t, err)
+}
+
+func TestCommentService_Create_BodyInvalid(t *testing.T) {
+	ctx := context.Background()
+	commentService := NewCommentService(nil, nil, collab.NewHub(10), nil, nil)
+
+	_, err := commentService.Create(ctx, &repository.User{ID: 2}, 1, "   ")
+	require.Equal(t, errs.BadReqErr{}, err)
+
+	_, err = commentService.Create(ctx, &repository.User{ID: 2}, 1, strings.Repeat("a", maxCommentLength+1))
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestCommentService_Create_OtherUsersTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	commentService := NewCommentService(nil, taskRepo, collab.NewHub(10), nil, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil)
+
+	_, err := commentService.Create(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 1, "Looks good")
+	require.Equal(t, errs.ForbiddenErr{}, err)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// IDueReminderService напоминает исполнителям о задачах, срок которых скоро наступит.
+type IDueReminderService interface {
+	SendReminders(ctx context.Context) error
+	RunReminders(ctx context.Context)
+}
+
+type DueReminderService struct {
+	taskRepository      repository.ITaskRepo
+	reminderRepository  repository.ITaskReminderRepo
+	transactor          repository.ITransactor
+	notificationService INotificationService
+	cfg                 *config.Reminders
+	now                 func() time.Time
+}
+
+func NewDueReminderService(
+	taskRepository repository.ITaskRepo,
+	reminderRepository repository.ITaskReminderRepo,
+	transactor repository.ITransactor,
+	notificationService INotificationService,
+	cfg *config.Reminders,
+) *DueReminderService {
+	return &DueReminderService{
+		taskRepository:      taskRepository,
+		reminderRepository:  reminderRepository,
+		transactor:          transactor,
+		notificationService: notificationService,
+		cfg:                 cfg,
+		now:                 time.Now,
+	}
+}
+
+// SendReminders уведомляет исполнителей невыполненных задач, срок которых наступит в ближайшие DueWithin.
+// О каждом сроке задачи напоминается один раз: отметка о напоминании и уведомление создаются в одной транзакции,
+// поэтому при ошибке напоминание повторится при следующей проверке. После переноса срока напоминание придёт снова.
+func (d *DueReminderService) SendReminders(ctx context.Context) error {
+	now := d.now().UTC()
+	tasks, err := d.taskRepository.GetDueBetween(ctx, now, now.Add(d.cfg.DueWithin))
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		err = d.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			created, createErr := d.reminderRepository.Create(ctx, task.ID, *task.DueAt)
+			if createErr != nil || !created {
+				return createErr
+			}
+
+			return d.notificationService.Notify(ctx, nil, &repository.Notification{
+				UserID: task.UserID,
+				TaskID: task.ID,
+				Event:  constant.TaskDueSoonEvent,
+				Message: fmt.Sprintf("Срок задачи «%s» наступает %s",
+					task.Title, task.DueAt.UTC().Format("02.01.2006 15:04 UTC")),
+			})
+		})
+		if err != nil {
+			// ошибка по одной задаче не должна останавливать напоминания о других.
+			slog.ErrorContext(ctx, "cannot send due date reminder",
+				slog.Int("taskId", task.ID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// RunReminders проверяет сроки задач сразу и затем каждые CheckInterval, пока не отменён ctx.
+func (d *DueReminderService) RunReminders(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.SendReminders(ctx); err != nil {
+			slog.Error("cannot send due date reminders", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,88 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var reminderNow = time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC)
+
+func newTestDueReminderService(ctrl *gomock.Controller,
+	notificationRepo repository.INotificationRepo,
+) (*DueReminderService, *mockRepository.MockITaskRepo, *mockRepository.MockITaskReminderRepo) {
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	reminderRepo := mockRepository.NewMockITaskReminderRepo(ctrl)
+	transactor := mockRepository.NewMockITransactor(ctrl)
+	transactor.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	dueReminderService := NewDueReminderService(taskRepo, reminderRepo, transactor,
+		NewNotificationService(notificationRepo, disabledEmailService),
+		&config.Reminders{DueWithin: 24 * time.Hour, CheckInterval: 15 * time.Minute})
+	dueReminderService.now = func() time.Time {
+		return reminderNow
+	}
+
+	return dueReminderService, taskRepo, reminderRepo
+}
+
+func TestDueReminderService_SendReminders(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	dueReminderService, taskRepo, reminderRepo := newTestDueReminderService(ctrl, notificationRepo)
+
+	dueAt := reminderNow.Add(3 * time.Hour)
+	taskRepo.EXPECT().GetDueBetween(gomock.Any(), reminderNow, reminderNow.Add(24*time.Hour)).
+		Return([]repository.Task{
+			{ID: 5, Title: "Release", UserID: 2, DueAt: &dueAt},
+			{ID: 6, Title: "Changelog", UserID: 2, DueAt: &dueAt},
+		}, nil)
+
+	reminderRepo.EXPECT().Create(gomock.Any(), 5, dueAt).Return(true, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 2).Return(nil, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  2,
+		TaskID:  5,
+		Event:   constant.TaskDueSoonEvent,
+		Message: "Срок задачи «Release» наступает 19.10.2026 12:00 UTC",
+	}).Return(nil)
+	// о сроке второй задачи уже напомнили при прошлой проверке.
+	reminderRepo.EXPECT().Create(gomock.Any(), 6, dueAt).Return(false, nil)
+
+	err := dueReminderService.SendReminders(ctx)
+	require.NoError(t, err)
+}
+
+func TestDueReminderService_SendReminders_TaskErrorSkipped(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	dueReminderService, taskRepo, reminderRepo := newTestDueReminderService(ctrl, notificationRepo)
+
+	dueAt := reminderNow.Add(time.Hour)
+	taskRepo.EXPECT().GetDueBetween(gomock.Any(), gomock.Any(), gomock.Any()).Return([]repository.Task{
+		{ID: 5, Title: "Release", UserID: 2, DueAt: &dueAt},
+		{ID: 6, Title: "Changelog", UserID: 3, DueAt: &dueAt},
+	}, nil)
+	reminderRepo.EXPECT().Create(gomock.Any(), 5, dueAt).Return(false, errors.New(""))
+	reminderRepo.EXPECT().Create(gomock.Any(), 6, dueAt).Return(true, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 3).Return(nil, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := dueReminderService.SendReminders(ctx)
+	require.NoError(t, err)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/email"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+type IEmailService interface {
+	NotifyImmediately(ctx context.Context, notification *repository.Notification) error
+	SendDigests(ctx context.Context) error
+	RunDigests(ctx context.Context)
+	GetSettings(ctx context.Context, user *repository.User) (*repository.User, error)
+	UpdateSettings(ctx context.Context, user *repository.User, address string, digest bool) error
+}
+
+type EmailService struct {
+	userRepository         repository.IUserRepo
+	notificationRepository repository.INotificationRepo
+	queue                  email.IQueue
+	cfg                    *config.Email
+}
+
+func NewEmailService(
+	userRepository repository.IUserRepo,
+	notificationRepository repository.INotificationRepo,
+	queue email.IQueue,
+	cfg *config.Email,
+) *EmailService {
+	return &EmailService{
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		queue:                  queue,
+		cfg:                    cfg,
+	}
+}
+
+// NotifyImmediately ставит письмо об уведомлении в очередь, если получатель указал адрес и не выбрал сводку.
+// SMTP-сервер здесь не ждём: письмо отправит очередь.
+func (e *EmailService) NotifyImmediately(ctx context.Context, notification *repository.Notification) error {
+	if !e.cfg.Enabled {
+		return nil
+	}
+
+	user, err := e.userRepository.GetByID(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.Active || user.Email == "" || user.EmailDigest {
+		return nil
+	}
+
+	msg, err := email.NotificationMessage(user.Email, e.notificationData(notification))
+	if err != nil {
+		return err
+	}
+	if err = e.queue.Enqueue(msg); err != nil {
+		return err
+	}
+
+	return e.notificationRepository.MarkEmailed(ctx, []int{notification.ID})
+}
+
+// SendDigests ставит в очередь сводку неотправленных уведомлений каждому пользователю, выбравшему сводку.
+func (e *EmailService) SendDigests(ctx context.Context) error {
+	if !e.cfg.Enabled {
+		return nil
+	}
+
+	users, err := e.userRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if !user.Active || user.Email == "" || !user.EmailDigest {
+			continue
+		}
+
+		notifications, err := e.notificationRepository.GetNotEmailed(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			continue
+		}
+
+		data := email.DigestData{Login: user.Login, InboxURL: e.cfg.BaseURL + "/notifications"}
+		notificationIDs := make([]int, 0, len(notifications))
+		for i := range notifications {
+			data.Notifications = append(data.Notifications, e.notificationData(&notifications[i]))
+			notificationIDs = append(notificationIDs, notifications[i].ID)
+		}
+
+		subject := fmt.Sprintf("Сводка уведомлений: %d", len(notifications))
+		msg, err := email.DigestMessage(user.Email, subject, data)
+		if err != nil {
+			return err
+		}
+		if err = e.queue.Enqueue(msg); err != nil {
+			return err
+		}
+		if err = e.notificationRepository.MarkEmailed(ctx, notificationIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunDigests рассылает сводки каждый день в digestHour, пока не отменён ctx.
+func (e *EmailService) RunDigests(ctx context.Context) {
+	if !e.cfg.Enabled {
+		return
+	}
+
+	for {
+		timer := time.NewTimer(time.Until(nextDigestTime(time.Now(), e.cfg.DigestHour)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := e.SendDigests(ctx); err != nil {
+			slog.Error("cannot send email digests", slog.Any("error", err))
+		}
+	}
+}
+
+func (e *EmailService) GetSettings(ctx context.Context, user *repository.User) (*repository.User, error) {
+	return e.userRepository.GetByID(ctx, user.ID)
+}
+
+// UpdateSettings сохраняет адрес для уведомлений и режим отправки. Пустой адрес отключает письма.
+func (e *EmailService) UpdateSettings(ctx context.Context, user *repository.User, address string, digest bool) error {
+	if address != "" {
+		parsed, err := mail.ParseAddress(address)
+		if err != nil || parsed.Name != "" {
+			return errs.BadReqErr{}
+		}
+	}
+
+	return e.userRepository.UpdateEmailSettings(ctx, user.ID, address, digest)
+}
+
+func (e *EmailService) notificationData(notification *repository.Notification) email.NotificationData {
+	return email.NotificationData{
+		EventTitle: constant.NotificationEventTitles[notification.Event],
+		Message:    notification.Message,
+		TaskURL:    fmt.Sprintf("%s/tasks/%d", e.cfg.BaseURL, notification.TaskID),
+		CreatedAt:  notification.CreatedAt,
+	}
+}
+
+// nextDigestTime возвращает ближайший момент после now, когда наступает час hour.
+func nextDigestTime(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
@@ -0,0 +1,122 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/email"
+	mockEmail "github.com/romakorinenko/task-manager/internal/email/mocks"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	disabledEmailService = NewEmailService(nil, nil, nil, &config.Email{})
+	emailConfig          = &config.Email{Enabled: true, BaseURL: "http://localhost:8080"}
+)
+
+func TestEmailService_NotifyImmediately_MessageEnqueued(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	queue := mockEmail.NewMockIQueue(ctrl)
+	emailService := NewEmailService(userRepo, notificationRepo, queue, emailConfig)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.User{ID: 2, Active: true, Email: "ivan@example.com"}, nil)
+	queue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(msg email.Message) error {
+		require.Equal(t, "ivan@example.com", msg.To)
+		require.Equal(t, "Вам назначена задача «Release»", msg.Subject)
+		require.Contains(t, msg.Text, "http://localhost:8080/tasks/5")
+		return nil
+	})
+	notificationRepo.EXPECT().MarkEmailed(gomock.Any(), []int{10}).Return(nil)
+
+	err := emailService.NotifyImmediately(ctx, &repository.Notification{
+		ID:        10,
+		UserID:    2,
+		TaskID:    5,
+		Event:     constant.TaskAssignedEvent,
+		Message:   "Вам назначена задача «Release»",
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+}
+
+func TestEmailService_NotifyImmediately_DigestUserSkipped(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	emailService := NewEmailService(userRepo, nil, nil, emailConfig)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).
+		Return(&repository.User{ID: 2, Active: true, Email: "ivan@example.com", EmailDigest: true}, nil)
+
+	err := emailService.NotifyImmediately(ctx, &repository.Notification{ID: 10, UserID: 2})
+	require.NoError(t, err)
+}
+
+func TestEmailService_SendDigests_DigestEnqueued(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	queue := mockEmail.NewMockIQueue(ctrl)
+	emailService := NewEmailService(userRepo, notificationRepo, queue, emailConfig)
+
+	userRepo.EXPECT().GetAll(gomock.Any()).Return([]repository.User{
+		{ID: 1, Login: "admin", Active: true, Email: "admin@example.com"},
+		{ID: 2, Login: "ivan", Active: true, Email: "ivan@example.com", EmailDigest: true},
+		{ID: 3, Login: "petr", Active: true, EmailDigest: true},
+	}, nil)
+	notificationRepo.EXPECT().GetNotEmailed(gomock.Any(), 2).Return([]repository.Notification{
+		{ID: 10, UserID: 2, TaskID: 5, Event: constant.TaskAssignedEvent, Message: "Вам назначена задача «Release»"},
+		{ID: 11, UserID: 2, TaskID: 6, Event: constant.UserMentionedEvent, Message: "Вас упомянули в задаче #6"},
+	}, nil)
+	queue.EXPECT().Enqueue(gomock.Any()).DoAndReturn(func(msg email.Message) error {
+		require.Equal(t, "ivan@example.com", msg.To)
+		require.Equal(t, "Сводка уведомлений: 2", msg.Subject)
+		require.Contains(t, msg.Text, "Вас упомянули в задаче #6")
+		return nil
+	})
+	notificationRepo.EXPECT().MarkEmailed(gomock.Any(), []int{10, 11}).Return(nil)
+
+	err := emailService.SendDigests(ctx)
+	require.NoError(t, err)
+}
+
+func TestEmailService_SendDigests_UsersErr(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	emailService := NewEmailService(userRepo, nil, nil, emailConfig)
+
+	userRepo.EXPECT().GetAll(gomock.Any()).Return(nil, errors.New(""))
+
+	err := emailService.SendDigests(ctx)
+	require.Error(t, err)
+}
+
+func TestEmailService_UpdateSettings_InvalidAddress(t *testing.T) {
+	ctx := context.Background()
+
+	err := disabledEmailService.UpdateSettings(ctx, &repository.User{ID: 2}, "not an email", false)
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestNextDigestTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), nextDigestTime(now, 9))
+	require.Equal(t, time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC), nextDigestTime(now, 18))
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	HealthStatusOK   = "ok"
+	HealthStatusFail = "fail"
+)
+
+// DatabaseCheck - результат проверки, что база отвечает через пул соединений.
+type DatabaseCheck struct {
+	Status    string  `json:"status" example:"ok"`
+	LatencyMs float64 `json:"latencyMs" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// MigrationsCheck - результат сравнения версии схемы базы с последней миграцией, встроенной в приложение.
+type MigrationsCheck struct {
+	Status   string `json:"status" example:"ok"`
+	Current  int64  `json:"current" example:"20"`
+	Expected int64  `json:"expected" example:"20"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Readiness - готовность приложения обслуживать запросы. Status - ok, только если прошли все проверки.
+type Readiness struct {
+	Status     string           `json:"status" example:"ok"`
+	Database   *DatabaseCheck   `json:"database"`
+	Migrations *MigrationsCheck `json:"migrations"`
+}
+
+// HealthReport - подробный отчёт о состоянии приложения для администраторов.
+type HealthReport struct {
+	Readiness
+	StartedAt     time.Time            `json:"startedAt" example:"2026-10-19T08:00:00Z"`
+	UptimeSeconds int64                `json:"uptimeSeconds" example:"3600"`
+	Pool          *repository.PoolStat `json:"pool"`
+	Outbox        map[string]int       `json:"outbox"`
+	OutboxError   string               `json:"outboxError,omitempty"`
+}
+
+// IHealthService проверяет состояние приложения для проб оркестратора и администраторов.
+type IHealthService interface {
+	GetReadiness(ctx context.Context) *Readiness
+	GetReport(ctx context.Context) *HealthReport
+}
+
+type HealthService struct {
+	healthRepository repository.IHealthRepo
+	expectedVersion  int64
+	cfg              *config.Health
+	startedAt        time.Time
+	now              func() time.Time
+}
+
+// NewHealthService создаёт сервис проверок. expectedVersion - версия последней миграции, встроенной в приложение.
+func NewHealthService(
+	healthRepository repository.IHealthRepo,
+	expectedVersion int64,
+	cfg *config.Health,
+) *HealthService {
+	return &HealthService{
+		healthRepository: healthRepository,
+		expectedVersion:  expectedVersion,
+		cfg:              cfg,
+		startedAt:        time.Now(),
+		now:              time.Now,
+	}
+}
+
+// GetReadiness проверяет, что база доступна и миграции применены до ожидаемой версии. Ответ отдаётся без
+// аутентификации, поэтому тексты ошибок в него не попадают, а пишутся в лог.
+func (h *HealthService) GetReadiness(ctx context.Context) *Readiness {
+	readiness := h.checkReadiness(ctx)
+	if readiness.Database.Error != "" {
+		slog.WarnContext(ctx, "database is not ready", slog.String("error", readiness.Database.Error))
+		readiness.Database.Error = ""
+	}
+	if readiness.Migrations.Error != "" {
+		slog.WarnContext(ctx, "migrations are not ready", slog.String("error", readiness.Migrations.Error))
+		readiness.Migrations.Error = ""
+	}
+
+	return readiness
+}
+
+// GetReport возвращает проверки готовности с текстами ошибок, время работы, состояние пула соединений и число
+// событий outbox в каждом статусе.
+func (h *HealthService) GetReport(ctx context.Context) *HealthReport {
+	report := &HealthReport{
+		Readiness:     *h.checkReadiness(ctx),
+		StartedAt:     h.startedAt,
+		UptimeSeconds: int64(h.now().Sub(h.startedAt).Seconds()),
+		Pool:          h.healthRepository.GetPoolStat(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+	defer cancel()
+
+	outbox, err := h.healthRepository.CountOutboxByStatus(ctx)
+	if err != nil {
+		report.OutboxError = err.Error()
+		return report
+	}
+	report.Outbox = map[string]int{
+		constant.PendingOutboxStatus:   outbox[constant.PendingOutboxStatus],
+		constant.PublishedOutboxStatus: outbox[constant.PublishedOutboxStatus],
+		constant.FailedOutboxStatus:    outbox[constant.FailedOutboxStatus],
+	}
+
+	return report
+}
+
+func (h *HealthService) checkReadiness(ctx context.Context) *Readiness {
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+	defer cancel()
+
+	readiness := &Readiness{
+		Status:     HealthStatusOK,
+		Database:   h.checkDatabase(ctx),
+		Migrations: &MigrationsCheck{Status: HealthStatusFail, Expected: h.expectedVersion},
+	}
+	// без базы версию схемы не узнать, ошибка уже есть в проверке базы.
+	if readiness.Database.Status == HealthStatusOK {
+		readiness.Migrations = h.checkMigrations(ctx)
+	}
+	if readiness.Database.Status != HealthStatusOK || readiness.Migrations.Status != HealthStatusOK {
+		readiness.Status = HealthStatusFail
+	}
+
+	return readiness
+}
+
+func (h *HealthService) checkDatabase(ctx context.Context) *DatabaseCheck {
+	start := h.now()
+	err := h.healthRepository.Ping(ctx)
+	check := &DatabaseCheck{
+		Status:    HealthStatusOK,
+		LatencyMs: float64(h.now().Sub(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		check.Status = HealthStatusFail
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+func (h *HealthService) checkMigrations(ctx context.Context) *MigrationsCheck {
+	check := &MigrationsCheck{Status: HealthStatusOK, Expected: h.expectedVersion}
+	version, err := h.healthRepository.GetMigrationVersion(ctx)
+	if err != nil {
+		check.Status = HealthStatusFail
+		check.Error = err.Error()
+		return check
+	}
+
+	check.Current = version
+	if version != h.expectedVersion {
+		check.Status = HealthStatusFail
+		check.Error = fmt.Sprintf("schema version is %d, expected %d", version, h.expectedVersion)
+	}
+
+	return check
+}
@@ -0,0 +1,92 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestHealthService(ctrl *gomock.Controller) (*HealthService, *mockRepository.MockIHealthRepo) {
+	healthRepo := mockRepository.NewMockIHealthRepo(ctrl)
+	healthService := NewHealthService(healthRepo, 20, &config.Health{Timeout: time.Second})
+
+	return healthService, healthRepo
+}
+
+func TestHealthService_GetReadiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	healthService, healthRepo := newTestHealthService(ctrl)
+
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	healthRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(int64(20), nil)
+
+	readiness := healthService.GetReadiness(context.Background())
+	require.Equal(t, HealthStatusOK, readiness.Status)
+	require.Equal(t, HealthStatusOK, readiness.Database.Status)
+	require.Equal(t, &MigrationsCheck{Status: HealthStatusOK, Current: 20, Expected: 20}, readiness.Migrations)
+}
+
+func TestHealthService_GetReadiness_MigrationsBehind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	healthService, healthRepo := newTestHealthService(ctrl)
+
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	healthRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(int64(19), nil)
+
+	readiness := healthService.GetReadiness(context.Background())
+	require.Equal(t, HealthStatusFail, readiness.Status)
+	require.Equal(t, HealthStatusOK, readiness.Database.Status)
+	require.Equal(t, &MigrationsCheck{Status: HealthStatusFail, Current: 19, Expected: 20}, readiness.Migrations)
+}
+
+func TestHealthService_GetReadiness_DatabaseDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	healthService, healthRepo := newTestHealthService(ctrl)
+
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(errors.New("connection refused"))
+
+	readiness := healthService.GetReadiness(context.Background())
+	require.Equal(t, HealthStatusFail, readiness.Status)
+	require.Equal(t, HealthStatusFail, readiness.Database.Status)
+	require.Empty(t, readiness.Database.Error)
+	require.Equal(t, HealthStatusFail, readiness.Migrations.Status)
+}
+
+func TestHealthService_GetReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	healthService, healthRepo := newTestHealthService(ctrl)
+	healthService.startedAt = time.Date(2026, time.October, 19, 8, 0, 0, 0, time.UTC)
+	healthService.now = func() time.Time {
+		return time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC)
+	}
+
+	pool := &repository.PoolStat{TotalConns: 4, IdleConns: 3, AcquiredConns: 1, MaxConns: 10}
+	healthRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+	healthRepo.EXPECT().GetMigrationVersion(gomock.Any()).Return(int64(21), nil)
+	healthRepo.EXPECT().GetPoolStat().Return(pool)
+	healthRepo.EXPECT().CountOutboxByStatus(gomock.Any()).Return(map[string]int{
+		constant.PublishedOutboxStatus: 7,
+		constant.FailedOutboxStatus:    1,
+	}, nil)
+
+	report := healthService.GetReport(context.Background())
+	require.Equal(t, HealthStatusFail, report.Status)
+	require.Equal(t, "schema version is 21, expected 20", report.Migrations.Error)
+	require.Equal(t, int64(3600), report.UptimeSeconds)
+	require.Equal(t, pool, report.Pool)
+	require.Equal(t, map[string]int{
+		constant.PendingOutboxStatus:   0,
+		constant.PublishedOutboxStatus: 7,
+		constant.FailedOutboxStatus:    1,
+	}, report.Outbox)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/markdown"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+type IMentionService interface {
+	Render(ctx context.Context, description string) (template.HTML, error)
+	Sync(ctx context.Context, actor *repository.User, taskID int, description string) ([]repository.User, error)
+	NotifyCommentMentions(ctx context.Context,
+		actor *repository.User,
+		taskID int,
+		body string,
+	) ([]repository.User, error)
+	GetBacklinks(ctx context.Context, taskID int) ([]repository.TaskBacklink, error)
+}
+
+type MentionService struct {
+	mentionRepository   repository.IMentionRepo
+	taskRepository      repository.ITaskRepo
+	userRepository      repository.IUserRepo
+	notificationService INotificationService
+}
+
+func NewMentionService(
+	mentionRepository repository.IMentionRepo,
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	notificationService INotificationService,
+) *MentionService {
+	return &MentionService{
+		mentionRepository:   mentionRepository,
+		taskRepository:      taskRepository,
+		userRepository:      userRepository,
+		notificationService: notificationService,
+	}
+}
+
+// Render рендерит описание задачи, превращая упоминания существующих активных пользователей
+// и существующих задач в ссылки. Остальные упоминания остаются простым текстом.
+func (m *MentionService) Render(ctx context.Context, description string) (template.HTML, error) {
+	users, taskIDs, err := m.resolve(ctx, description)
+	if err != nil {
+		return "", err
+	}
+
+	refs := markdown.References{
+		Logins:  make(map[string]bool, len(users)),
+		TaskIDs: make(map[int]bool, len(taskIDs)),
+	}
+	for _, user := range users {
+		refs.Logins[user.Login] = true
+	}
+	for _, taskID := range taskIDs {
+		refs.TaskIDs[taskID] = true
+	}
+
+	return markdown.ToHTMLWithReferences(description, refs), nil
+}
+
+// Sync сохраняет упоминания из описания задачи, уведомляет пользователей, упомянутых в ней впервые,
+// и возвращает их. actor - автор изменения, может быть nil.
+func (m *MentionService) Sync(ctx context.Context,
+	actor *repository.User,
+	taskID int,
+	description string,
+) ([]repository.User, error) {
+	users, taskIDs, err := m.resolve(ctx, description)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	referencedTaskIDs := make([]int, 0, len(taskIDs))
+	for _, referencedTaskID := range taskIDs {
+		if referencedTaskID != taskID {
+			referencedTaskIDs = append(referencedTaskIDs, referencedTaskID)
+		}
+	}
+
+	newUserIDs, err := m.mentionRepository.ReplaceForTask(ctx, taskID, userIDs, referencedTaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	mentioned := make([]repository.User, 0, len(newUserIDs))
+	for _, user := range users {
+		for _, userID := range newUserIDs {
+			if user.ID == userID {
+				mentioned = append(mentioned, user)
+				m.notify(ctx, actor, user, taskID, fmt.Sprintf("Вас упомянули в задаче #%d", taskID))
+			}
+		}
+	}
+
+	return mentioned, nil
+}
+
+// NotifyCommentMentions уведомляет пользователей, упомянутых в новом комментарии к задаче, и возвращает их.
+// Комментарий не меняется после создания, поэтому все упоминания в нём новые и отдельно не сохраняются.
+func (m *MentionService) NotifyCommentMentions(ctx context.Context,
+	actor *repository.User,
+	taskID int,
+	body string,
+) ([]repository.User, error) {
+	users, _, err := m.resolve(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		m.notify(ctx, actor, user, taskID, fmt.Sprintf("Вас упомянули в комментарии к задаче #%d", taskID))
+	}
+
+	return users, nil
+}
+
+func (m *MentionService) notify(ctx context.Context,
+	actor *repository.User,
+	user repository.User,
+	taskID int,
+	message string,
+) {
+	err := m.notificationService.Notify(ctx, actor, &repository.Notification{
+		UserID:  user.ID,
+		TaskID:  taskID,
+		Event:   constant.UserMentionedEvent,
+		Message: message,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "cannot save mention notification",
+			slog.Int("taskId", taskID),
+			slog.String("login", user.Login),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (m *MentionService) GetBacklinks(ctx context.Context, taskID int) ([]repository.TaskBacklink, error) {
+	return m.mentionRepository.GetBacklinks(ctx, taskID)
+}
+
+// resolve находит упомянутых активных пользователей и существующие задачи.
+func (m *MentionService) resolve(ctx context.Context, description string) ([]repository.User, []int, error) {
+	logins, taskIDs := markdown.ExtractReferences(description)
+
+	users := make([]repository.User, 0, len(logins))
+	for _, login := range logins {
+		user, err := m.userRepository.GetByLogin(ctx, login)
+		if err != nil && errors.Is(err, pgx.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if user.Active {
+			users = append(users, *user)
+		}
+	}
+
+	existingTaskIDs := make([]int, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		_, err := m.taskRepository.GetByID(ctx, taskID)
+		if err != nil && errors.Is(err, pgx.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		existingTaskIDs = append(existingTaskIDs, taskID)
+	}
+
+	return users, existingTaskIDs, nil
+}
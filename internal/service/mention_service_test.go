@@ -0,0 +1,60 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMentionService_Render_OnlyActiveUsersLinked(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	mentionService := NewMentionService(nil, nil, userRepo, nil)
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "ivan").Return(&repository.User{ID: 2, Login: "ivan", Active: true}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "petr").Return(&repository.User{ID: 3, Login: "petr"}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "nobody").Return(nil, pgx.ErrNoRows)
+
+	rendered, err := mentionService.Render(ctx, "@ivan @petr @nobody")
+	require.NoError(t, err)
+	require.Equal(t, `<p><a href="/tasks/user/ivan" rel="nofollow">@ivan</a> @petr @nobody</p>`+"\n", string(rendered))
+}
+
+func TestMentionService_Sync_NewMentionsReturned(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mentionRepo := mockRepository.NewMockIMentionRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	notificationService := NewNotificationService(notificationRepo, disabledEmailService)
+	mentionService := NewMentionService(mentionRepo, taskRepo, userRepo, notificationService)
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "ivan").Return(&repository.User{ID: 2, Login: "ivan", Active: true}, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "anna").Return(&repository.User{ID: 4, Login: "anna", Active: true}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.Task{ID: 5}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 9).Return(nil, pgx.ErrNoRows)
+	mentionRepo.EXPECT().ReplaceForTask(gomock.Any(), 1, []int{2, 4}, []int{5}).Return([]int{4}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 4).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  4,
+		TaskID:  1,
+		Event:   constant.UserMentionedEvent,
+		Message: "Вас упомянули в задаче #1",
+	}).Return(nil)
+
+	actor := &repository.User{ID: 2, Login: "ivan"}
+	mentioned, err := mentionService.Sync(ctx, actor, 1, "@ivan @anna: same as #1, see #5 and #9")
+	require.NoError(t, err)
+	require.Equal(t, []repository.User{{ID: 4, Login: "anna", Active: true}}, mentioned)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// inboxLimit - сколько последних уведомлений показывать во входящих.
+const inboxLimit = 100
+
+type INotificationService interface {
+	Notify(ctx context.Context, actor *repository.User, notification *repository.Notification) error
+	GetByUser(ctx context.Context, user *repository.User) ([]repository.Notification, error)
+	CountUnread(ctx context.Context, user *repository.User) (int, error)
+	MarkRead(ctx context.Context, user *repository.User, notificationID int) error
+	MarkAllRead(ctx context.Context, user *repository.User) error
+	GetPreferences(ctx context.Context, user *repository.User) ([]repository.NotificationPreference, error)
+	SetPreferences(ctx context.Context, user *repository.User, enabledEvents []string) error
+}
+
+type NotificationService struct {
+	notificationRepository repository.INotificationRepo
+	emailService           IEmailService
+}
+
+func NewNotificationService(
+	notificationRepository repository.INotificationRepo,
+	emailService IEmailService,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepository: notificationRepository,
+		emailService:           emailService,
+	}
+}
+
+// Notify сохраняет уведомление, если получатель не отключил этот тип событий.
+// Пользователь не получает уведомлений о собственных действиях; actor может быть nil, если автор неизвестен.
+// Уведомление с EventID создаётся не больше одного раза, поэтому обработчики outbox могут повторять событие.
+func (n *NotificationService) Notify(ctx context.Context,
+	actor *repository.User,
+	notification *repository.Notification,
+) error {
+	if actor != nil && actor.ID == notification.UserID {
+		return nil
+	}
+
+	enabled, err := n.isEnabled(ctx, notification.UserID, notification.Event)
+	if err != nil || !enabled {
+		return err
+	}
+
+	err = n.notificationRepository.Create(ctx, notification)
+	if errors.Is(err, repository.ErrNotificationExists) {
+		// событие outbox обрабатывается повторно, а уведомление и письмо о нём уже отправлены.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// уведомление уже сохранено во входящих, поэтому ошибка постановки письма в очередь только логируется.
+	if err = n.emailService.NotifyImmediately(ctx, notification); err != nil {
+		slog.ErrorContext(ctx, "cannot enqueue notification email",
+			slog.Int("notificationId", notification.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	return nil
+}
+
+func (n *NotificationService) GetByUser(ctx context.Context, user *repository.User) ([]repository.Notification, error) {
+	return n.notificationRepository.GetByUserID(ctx, user.ID, inboxLimit)
+}
+
+func (n *NotificationService) CountUnread(ctx context.Context, user *repository.User) (int, error) {
+	return n.notificationRepository.CountUnread(ctx, user.ID)
+}
+
+func (n *NotificationService) MarkRead(ctx context.Context, user *repository.User, notificationID int) error {
+	found, err := n.notificationRepository.MarkRead(ctx, user.ID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+func (n *NotificationService) MarkAllRead(ctx context.Context, user *repository.User) error {
+	return n.notificationRepository.MarkAllRead(ctx, user.ID)
+}
+
+// GetPreferences возвращает настройки по всем типам событий; по умолчанию все уведомления включены.
+func (n *NotificationService) GetPreferences(ctx context.Context,
+	user *repository.User,
+) ([]repository.NotificationPreference, error) {
+	stored, err := n.notificationRepository.GetPreferences(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]repository.NotificationPreference, 0, len(constant.NotificationEvents))
+	for _, event := range constant.NotificationEvents {
+		preference := repository.NotificationPreference{UserID: user.ID, Event: event, Enabled: true}
+		for _, storedPreference := range stored {
+			if storedPreference.Event == event {
+				preference.Enabled = storedPreference.Enabled
+			}
+		}
+		res = append(res, preference)
+	}
+
+	return res, nil
+}
+
+// SetPreferences включает уведомления о событиях enabledEvents и отключает остальные.
+func (n *NotificationService) SetPreferences(ctx context.Context, user *repository.User, enabledEvents []string) error {
+	for _, event := range enabledEvents {
+		if !slices.Contains(constant.NotificationEvents, event) {
+			return errs.BadReqErr{}
+		}
+	}
+
+	for _, event := range constant.NotificationEvents {
+		err := n.notificationRepository.SetPreference(ctx, &repository.NotificationPreference{
+			UserID:  user.ID,
+			Event:   event,
+			Enabled: slices.Contains(enabledEvents, event),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *NotificationService) isEnabled(ctx context.Context, userID int, event string) (bool, error) {
+	preferences, err := n.GetPreferences(ctx, &repository.User{ID: userID})
+	if err != nil {
+		return false, err
+	}
+
+	for _, preference := range preferences {
+		if preference.Event == event {
+			return preference.Enabled, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,81 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationService_MarkRead_ForeignNotification(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	notificationService := NewNotificationService(notificationRepo, disabledEmailService)
+
+	notificationRepo.EXPECT().MarkRead(gomock.Any(), 2, 10).Return(false, nil)
+
+	err := notificationService.MarkRead(ctx, &repository.User{ID: 2}, 10)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+func TestNotificationService_GetPreferences_EnabledByDefault(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	notificationService := NewNotificationService(notificationRepo, disabledEmailService)
+
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 2).Return([]repository.NotificationPreference{
+		{UserID: 2, Event: constant.UserMentionedEvent, Enabled: false},
+	}, nil)
+
+	preferences, err := notificationService.GetPreferences(ctx, &repository.User{ID: 2})
+	require.NoError(t, err)
+	require.Equal(t, []repository.NotificationPreference{
+		{UserID: 2, Event: constant.TaskAssignedEvent, Enabled: true},
+		{UserID: 2, Event: constant.TaskStatusChangedEvent, Enabled: true},
+		{UserID: 2, Event: constant.UserMentionedEvent, Enabled: false},
+		{UserID: 2, Event: constant.FilterMatchedEvent, Enabled: true},
+		{UserID: 2, Event: constant.CommentAddedEvent, Enabled: true},
+		{UserID: 2, Event: constant.TaskDueSoonEvent, Enabled: true},
+	}, preferences)
+}
+
+func TestNotificationService_SetPreferences_AllEventsSaved(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	notificationService := NewNotificationService(notificationRepo, disabledEmailService)
+
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.TaskAssignedEvent, Enabled: true}).Return(nil)
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.TaskStatusChangedEvent, Enabled: false}).
+		Return(nil)
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.UserMentionedEvent, Enabled: false}).Return(nil)
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.FilterMatchedEvent, Enabled: false}).Return(nil)
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.CommentAddedEvent, Enabled: false}).Return(nil)
+	notificationRepo.EXPECT().SetPreference(gomock.Any(),
+		&repository.NotificationPreference{UserID: 2, Event: constant.TaskDueSoonEvent, Enabled: false}).Return(nil)
+
+	err := notificationService.SetPreferences(ctx, &repository.User{ID: 2}, []string{constant.TaskAssignedEvent})
+	require.NoError(t, err)
+}
+
+func TestNotificationService_SetPreferences_UnknownEvent(t *testing.T) {
+	ctx := context.Background()
+	notificationService := NewNotificationService(nil, disabledEmailService)
+
+	err := notificationService.SetPreferences(ctx, &repository.User{ID: 2}, []string{"TASK_DELETED"})
+	require.Equal(t, errs.BadReqErr{}, err)
+}
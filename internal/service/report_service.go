@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	// DefaultReportWeeks - период отчётов по неделям, если он не задан.
+	DefaultReportWeeks = 12
+	maxReportWeeks     = 104
+)
+
+// weekDateLayout - ключ недели при сопоставлении результатов запросов.
+const weekDateLayout = "2006-01-02"
+
+// agingBuckets - корзины возраста невыполненных задач: название и верхняя граница возраста в днях.
+// Последняя корзина без границы собирает все более старые задачи.
+var agingBuckets = []struct {
+	name    string
+	maxDays int
+}{
+	{name: "0-7", maxDays: 7},
+	{name: "8-30", maxDays: 30},
+	{name: "31-90", maxDays: 90},
+	{name: "90+"},
+}
+
+// WeeklyThroughput - сколько задач создано и выполнено за неделю. WeekStart - понедельник недели.
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"weekStart" example:"2026-10-12T00:00:00Z"`
+	Created   int       `json:"created" example:"5"`
+	Completed int       `json:"completed" example:"4"`
+}
+
+// AgingBucket - число невыполненных задач, созданных Bucket дней назад.
+type AgingBucket struct {
+	Bucket string `json:"bucket" example:"8-30"`
+	Tasks  int    `json:"tasks" example:"3"`
+}
+
+// TaskBreakdown - задачи по исполнителям и по приоритетам в разрезе статусов.
+type TaskBreakdown struct {
+	ByAssignee []repository.AssigneeStatusCounts `json:"byAssignee"`
+	ByPriority []repository.PriorityStatusCounts `json:"byPriority"`
+}
+
+// TaskReport - все отчёты по задачам за период Weeks.
+type TaskReport struct {
+	Weeks      int                   `json:"weeks" example:"12"`
+	Throughput []WeeklyThroughput    `json:"throughput"`
+	CycleTime  *repository.CycleTime `json:"cycleTime"`
+	Aging      []AgingBucket         `json:"aging"`
+	TaskBreakdown
+}
+
+// IReportService строит отчёты по задачам. Видимость как в списке задач: ADMIN видит отчёты по всем задачам,
+// остальные - по своим. weeks - период в неделях, включая текущую; 0 - DefaultReportWeeks.
+type IReportService interface {
+	GetThroughput(ctx context.Context, user *repository.User, weeks int) ([]WeeklyThroughput, error)
+	GetCycleTime(ctx context.Context, user *repository.User, weeks int) (*repository.CycleTime, error)
+	GetAging(ctx context.Context, user *repository.User) ([]AgingBucket, error)
+	GetBreakdown(ctx context.Context, user *repository.User) (*TaskBreakdown, error)
+	GetReport(ctx context.Context, user *repository.User, weeks int) (*TaskReport, error)
+}
+
+type ReportService struct {
+	reportRepository repository.IReportRepo
+	now              func() time.Time
+}
+
+func NewReportService(reportRepository repository.IReportRepo) *ReportService {
+	return &ReportService{reportRepository: reportRepository, now: time.Now}
+}
+
+// GetThroughput возвращает число созданных и выполненных задач по неделям, включая недели без задач.
+// Выполненной считается задача, переведённая в DONE; задача, выполненная повторно, учитывается повторно.
+func (r *ReportService) GetThroughput(ctx context.Context,
+	user *repository.User,
+	weeks int,
+) ([]WeeklyThroughput, error) {
+	since, err := r.reportSince(weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.reportRepository.CountCreatedByWeek(ctx, reportUserID(user), since)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := r.reportRepository.CountCompletedByWeek(ctx, reportUserID(user), since)
+	if err != nil {
+		return nil, err
+	}
+
+	createdByWeek := weekCountsByDate(created)
+	completedByWeek := weekCountsByDate(completed)
+	throughput := make([]WeeklyThroughput, 0, weeks)
+	for week := since; !week.After(r.now()); week = week.AddDate(0, 0, 7) {
+		key := week.Format(weekDateLayout)
+		throughput = append(throughput, WeeklyThroughput{
+			WeekStart: week,
+			Created:   createdByWeek[key],
+			Completed: completedByWeek[key],
+		})
+	}
+
+	return throughput, nil
+}
+
+// GetCycleTime возвращает среднее время от открытия до выполнения задач, выполненных за период.
+func (r *ReportService) GetCycleTime(ctx context.Context,
+	user *repository.User,
+	weeks int,
+) (*repository.CycleTime, error) {
+	since, err := r.reportSince(weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.reportRepository.GetCycleTime(ctx, reportUserID(user), since)
+}
+
+// GetAging возвращает число невыполненных задач по возрасту.
+func (r *ReportService) GetAging(ctx context.Context, user *repository.User) ([]AgingBucket, error) {
+	now := r.now()
+	bounds := make([]time.Time, 0, len(agingBuckets)-1)
+	for _, bucket := range agingBuckets[:len(agingBuckets)-1] {
+		bounds = append(bounds, now.AddDate(0, 0, -bucket.maxDays))
+	}
+
+	counts, err := r.reportRepository.CountOpenByAge(ctx, reportUserID(user), bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	aging := make([]AgingBucket, 0, len(agingBuckets))
+	for i, bucket := range agingBuckets {
+		aging = append(aging, AgingBucket{Bucket: bucket.name, Tasks: counts[i]})
+	}
+
+	return aging, nil
+}
+
+// GetBreakdown возвращает число задач по исполнителям и приоритетам в разрезе статусов.
+func (r *ReportService) GetBreakdown(ctx context.Context, user *repository.User) (*TaskBreakdown, error) {
+	byAssignee, err := r.reportRepository.CountByAssignee(ctx, reportUserID(user))
+	if err != nil {
+		return nil, err
+	}
+	byPriority, err := r.reportRepository.CountByPriority(ctx, reportUserID(user))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskBreakdown{ByAssignee: byAssignee, ByPriority: byPriority}, nil
+}
+
+// GetReport собирает все отчёты для страницы отчётов.
+func (r *ReportService) GetReport(ctx context.Context, user *repository.User, weeks int) (*TaskReport, error) {
+	if weeks == 0 {
+		weeks = DefaultReportWeeks
+	}
+
+	throughput, err := r.GetThroughput(ctx, user, weeks)
+	if err != nil {
+		return nil, err
+	}
+	cycleTime, err := r.GetCycleTime(ctx, user, weeks)
+	if err != nil {
+		return nil, err
+	}
+	aging, err := r.GetAging(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	breakdown, err := r.GetBreakdown(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskReport{
+		Weeks:         weeks,
+		Throughput:    throughput,
+		CycleTime:     cycleTime,
+		Aging:         aging,
+		TaskBreakdown: *breakdown,
+	}, nil
+}
+
+// reportSince возвращает начало первой недели периода: понедельник weeks-1 недель назад.
+func (r *ReportService) reportSince(weeks int) (time.Time, error) {
+	if weeks == 0 {
+		weeks = DefaultReportWeeks
+	}
+	if weeks < 1 || weeks > maxReportWeeks {
+		return time.Time{}, errs.BadReqErr{}
+	}
+
+	now := r.now()
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	monday := time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, now.Location())
+
+	return monday.AddDate(0, 0, -7*(weeks-1)), nil
+}
+
+func reportUserID(user *repository.User) int {
+	if user.Role == constant.AdminRole {
+		return 0
+	}
+
+	return user.ID
+}
+
+func weekCountsByDate(weekCounts []repository.WeekCount) map[string]int {
+	counts := make(map[string]int, len(weekCounts))
+	for _, weekCount := range weekCounts {
+		counts[weekCount.Week.Format(weekDateLayout)] = weekCount.Count
+	}
+
+	return counts
+}
@@ -0,0 +1,77 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// reportNow - четверг, неделя начинается в понедельник 2026-10-12.
+var reportNow = time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC)
+
+func TestReportService_GetThroughput(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	reportRepo := mockRepository.NewMockIReportRepo(ctrl)
+	reportService := NewReportService(reportRepo)
+	reportService.now = func() time.Time { return reportNow }
+
+	since := time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC)
+	reportRepo.EXPECT().CountCreatedByWeek(gomock.Any(), 2, since).Return([]repository.WeekCount{
+		{Week: since, Count: 3},
+		{Week: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Count: 1},
+	}, nil)
+	reportRepo.EXPECT().CountCompletedByWeek(gomock.Any(), 2, since).Return([]repository.WeekCount{
+		{Week: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), Count: 2},
+	}, nil)
+
+	throughput, err := reportService.GetThroughput(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 3)
+	require.NoError(t, err)
+	require.Equal(t, []WeeklyThroughput{
+		{WeekStart: since, Created: 3},
+		{WeekStart: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), Completed: 2},
+		{WeekStart: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Created: 1},
+	}, throughput)
+}
+
+func TestReportService_GetThroughput_InvalidWeeks(t *testing.T) {
+	ctx := context.Background()
+	reportService := NewReportService(nil)
+
+	_, err := reportService.GetThroughput(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, -1)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+	_, err = reportService.GetCycleTime(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, maxReportWeeks+1)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
+
+func TestReportService_GetAging(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	reportRepo := mockRepository.NewMockIReportRepo(ctrl)
+	reportService := NewReportService(reportRepo)
+	reportService.now = func() time.Time { return reportNow }
+
+	reportRepo.EXPECT().CountOpenByAge(gomock.Any(), 0, []time.Time{
+		reportNow.AddDate(0, 0, -7),
+		reportNow.AddDate(0, 0, -30),
+		reportNow.AddDate(0, 0, -90),
+	}).Return([]int{4, 3, 2, 1}, nil)
+
+	aging, err := reportService.GetAging(ctx, &repository.User{ID: 1, Role: constant.AdminRole})
+	require.NoError(t, err)
+	require.Equal(t, []AgingBucket{
+		{Bucket: "0-7", Tasks: 4},
+		{Bucket: "8-30", Tasks: 3},
+		{Bucket: "31-90", Tasks: 2},
+		{Bucket: "90+", Tasks: 1},
+	}, aging)
+}
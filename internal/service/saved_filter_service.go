@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	maxFilterNameLen = 128
+	maxFilterTextLen = 255
+)
+
+type ISavedFilterService interface {
+	Create(ctx context.Context, user *repository.User, filter *repository.SavedFilter) error
+	Update(ctx context.Context, user *repository.User, filter *repository.SavedFilter) error
+	Delete(ctx context.Context, user *repository.User, filterID int) error
+	GetVisible(ctx context.Context, user *repository.User) ([]repository.SavedFilter, error)
+	GetPinned(ctx context.Context, user *repository.User) ([]repository.SavedFilter, error)
+	Resolve(ctx context.Context, user *repository.User, filterID int) (*repository.TaskFilter, error)
+	SetPinned(ctx context.Context, user *repository.User, filterID int, pinned bool) error
+	SetNotify(ctx context.Context, user *repository.User, filterID int, notify bool) error
+}
+
+type SavedFilterService struct {
+	savedFilterRepository repository.ISavedFilterRepo
+}
+
+func NewSavedFilterService(savedFilterRepository repository.ISavedFilterRepo) *SavedFilterService {
+	return &SavedFilterService{savedFilterRepository: savedFilterRepository}
+}
+
+// Create сохраняет фильтр пользователя. Пустая видимость означает PRIVATE, GLOBAL может задать только ADMIN.
+func (s *SavedFilterService) Create(ctx context.Context, user *repository.User, filter *repository.SavedFilter) error {
+	if err := normalizeSavedFilter(user, filter); err != nil {
+		return err
+	}
+	filter.UserID = user.ID
+
+	err := s.savedFilterRepository.Create(ctx, filter)
+	if errors.Is(err, repository.ErrFilterNameTaken) {
+		return errs.FilterExistsErr{}
+	}
+
+	return err
+}
+
+// Update заменяет имя, условия и видимость фильтра. Менять фильтр может владелец или ADMIN.
+func (s *SavedFilterService) Update(ctx context.Context, user *repository.User, filter *repository.SavedFilter) error {
+	savedFilter, err := s.getOwned(ctx, user, filter.ID)
+	if err != nil {
+		return err
+	}
+	if err = normalizeSavedFilter(user, filter); err != nil {
+		return err
+	}
+	filter.UserID = savedFilter.UserID
+	filter.CreatedAt = savedFilter.CreatedAt
+
+	found, err := s.savedFilterRepository.Update(ctx, filter)
+	if errors.Is(err, repository.ErrFilterNameTaken) {
+		return errs.FilterExistsErr{}
+	} else if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+// Delete удаляет фильтр. Удалять фильтр может владелец или ADMIN.
+func (s *SavedFilterService) Delete(ctx context.Context, user *repository.User, filterID int) error {
+	if _, err := s.getOwned(ctx, user, filterID); err != nil {
+		return err
+	}
+
+	found, err := s.savedFilterRepository.DeleteByID(ctx, filterID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+// GetVisible возвращает свои фильтры пользователя и фильтры, доступные всем, с его настройками.
+// Фильтры GLOBAL закреплены у всех.
+func (s *SavedFilterService) GetVisible(ctx context.Context, user *repository.User) ([]repository.SavedFilter, error) {
+	filters, err := s.savedFilterRepository.GetVisible(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.savedFilterRepository.GetSettings(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	settingsByFilter := make(map[int]repository.SavedFilterSettings, len(settings))
+	for _, filterSettings := range settings {
+		settingsByFilter[filterSettings.FilterID] = filterSettings
+	}
+
+	for i := range filters {
+		filterSettings := settingsByFilter[filters[i].ID]
+		filters[i].Pinned = filterSettings.Pinned || filters[i].Visibility == constant.GlobalFilterVisibility
+		filters[i].Notify = filterSettings.Notify
+	}
+
+	return filters, nil
+}
+
+// GetPinned возвращает фильтры, закреплённые на странице задач пользователя.
+func (s *SavedFilterService) GetPinned(ctx context.Context, user *repository.User) ([]repository.SavedFilter, error) {
+	filters, err := s.GetVisible(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(filters, func(filter repository.SavedFilter) bool {
+		return !filter.Pinned
+	}), nil
+}
+
+// Resolve возвращает условия сохранённого фильтра, доступного пользователю.
+func (s *SavedFilterService) Resolve(ctx context.Context,
+	user *repository.User,
+	filterID int,
+) (*repository.TaskFilter, error) {
+	savedFilter, err := s.getVisible(ctx, user, filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &savedFilter.Filter, nil
+}
+
+// SetPinned закрепляет фильтр на странице задач пользователя или открепляет его.
+func (s *SavedFilterService) SetPinned(ctx context.Context, user *repository.User, filterID int, pinned bool) error {
+	return s.updateSettings(ctx, user, filterID, func(settings *repository.SavedFilterSettings) {
+		settings.Pinned = pinned
+	})
+}
+
+// SetNotify подписывает пользователя на уведомления о задачах, подходящих под фильтр, или отписывает от них.
+func (s *SavedFilterService) SetNotify(ctx context.Context, user *repository.User, filterID int, notify bool) error {
+	return s.updateSettings(ctx, user, filterID, func(settings *repository.SavedFilterSettings) {
+		settings.Notify = notify
+	})
+}
+
+func (s *SavedFilterService) updateSettings(ctx context.Context,
+	user *repository.User,
+	filterID int,
+	update func(settings *repository.SavedFilterSettings),
+) error {
+	if _, err := s.getVisible(ctx, user, filterID); err != nil {
+		return err
+	}
+
+	allSettings, err := s.savedFilterRepository.GetSettings(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	settings := repository.SavedFilterSettings{FilterID: filterID, UserID: user.ID}
+	for _, filterSettings := range allSettings {
+		if filterSettings.FilterID == filterID {
+			settings = filterSettings
+		}
+	}
+	update(&settings)
+
+	return s.savedFilterRepository.SaveSettings(ctx, &settings)
+}
+
+// getVisible возвращает фильтр, если он доступен пользователю, иначе errs.NotFoundErr.
+func (s *SavedFilterService) getVisible(ctx context.Context,
+	user *repository.User,
+	filterID int,
+) (*repository.SavedFilter, error) {
+	savedFilter, err := s.savedFilterRepository.GetByID(ctx, filterID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !isFilterVisible(user, savedFilter) {
+		return nil, errs.NotFoundErr{}
+	}
+
+	return savedFilter, nil
+}
+
+// getOwned возвращает фильтр, который пользователь может менять: свой, а ADMIN - любой.
+func (s *SavedFilterService) getOwned(ctx context.Context,
+	user *repository.User,
+	filterID int,
+) (*repository.SavedFilter, error) {
+	savedFilter, err := s.getVisible(ctx, user, filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != constant.AdminRole && savedFilter.UserID != user.ID {
+		return nil, errs.ForbiddenErr{}
+	}
+
+	return savedFilter, nil
+}
+
+func isFilterVisible(user *repository.User, savedFilter *repository.SavedFilter) bool {
+	return savedFilter.UserID == user.ID || savedFilter.Visibility != constant.PrivateFilterVisibility
+}
+
+// normalizeSavedFilter проверяет имя, видимость и условия фильтра.
+func normalizeSavedFilter(user *repository.User, filter *repository.SavedFilter) error {
+	filter.Name = strings.TrimSpace(filter.Name)
+	if filter.Visibility == "" {
+		filter.Visibility = constant.PrivateFilterVisibility
+	}
+
+	if filter.Name == "" || utf8.RuneCountInString(filter.Name) > maxFilterNameLen ||
+		!slices.Contains(constant.FilterVisibilities, filter.Visibility) {
+		return errs.BadReqErr{}
+	}
+	if filter.Visibility == constant.GlobalFilterVisibility && user.Role != constant.AdminRole {
+		return errs.ForbiddenErr{}
+	}
+
+	return normalizeTaskFilter(&filter.Filter)
+}
+
+// normalizeTaskFilter проверяет условия фильтра задач и убирает пустые и повторяющиеся метки.
+func normalizeTaskFilter(filter *repository.TaskFilter) error {
+	filter.Text = strings.TrimSpace(filter.Text)
+	column, _ := strings.CutPrefix(filter.Sort, "-")
+
+	switch {
+	case filter.Status != "" && !slices.Contains(constant.TaskStatuses, filter.Status),
+		filter.Priority < 0 || filter.Priority > constant.Low,
+		utf8.RuneCountInString(filter.Text) > maxFilterTextLen,
+		filter.Sort != "" && repository.TaskSortColumns[column] == "":
+		return errs.BadReqErr{}
+	}
+
+	labels := make([]string, 0, len(filter.Labels))
+	for _, label := range filter.Labels {
+		label = strings.TrimSpace(label)
+		if utf8.RuneCountInString(label) > maxTaskLabelLen {
+			return errs.BadReqErr{}
+		}
+		if label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	filter.Labels = labels
+
+	return nil
+}
@@ -0,0 +1,152 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSavedFilterService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	savedFilterService := NewSavedFilterService(savedFilterRepo)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	savedFilterRepo.EXPECT().Create(gomock.Any(), &repository.SavedFilter{
+		UserID: 2,
+		Name:   "Срочные",
+		Filter: repository.TaskFilter{
+			Status:   constant.OpenTaskStatus,
+			Priority: constant.High,
+			Labels:   []string{"backend"},
+			Text:     "релиз",
+			Sort:     "-updatedAt",
+		},
+		Visibility: constant.PrivateFilterVisibility,
+	}).Return(nil)
+	savedFilterRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrFilterNameTaken)
+
+	err := savedFilterService.Create(ctx, user, &repository.SavedFilter{
+		Name: " Срочные ",
+		Filter: repository.TaskFilter{
+			Status:   constant.OpenTaskStatus,
+			Priority: constant.High,
+			Labels:   []string{"backend", " ", "backend"},
+			Text:     " релиз ",
+			Sort:     "-updatedAt",
+		},
+	})
+	require.NoError(t, err)
+
+	err = savedFilterService.Create(ctx, user, &repository.SavedFilter{Name: "Срочные"})
+	require.ErrorIs(t, err, errs.FilterExistsErr{})
+
+	err = savedFilterService.Create(ctx, user, &repository.SavedFilter{
+		Name: "Сортировка", Filter: repository.TaskFilter{Sort: "-password"},
+	})
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	err = savedFilterService.Create(ctx, user, &repository.SavedFilter{
+		Name: "Для всех", Visibility: constant.GlobalFilterVisibility,
+	})
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+}
+
+func TestSavedFilterService_Update_NotOwner(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	savedFilterService := NewSavedFilterService(savedFilterRepo)
+
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 4).Return(&repository.SavedFilter{
+		ID: 4, UserID: 3, Name: "Общий", Visibility: constant.SharedFilterVisibility,
+	}, nil)
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.SavedFilter{
+		ID: 5, UserID: 3, Name: "Личный", Visibility: constant.PrivateFilterVisibility,
+	}, nil)
+
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+	err := savedFilterService.Update(ctx, user, &repository.SavedFilter{ID: 4, Name: "Мой"})
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+
+	err = savedFilterService.Delete(ctx, user, 5)
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+}
+
+func TestSavedFilterService_GetPinned(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	savedFilterService := NewSavedFilterService(savedFilterRepo)
+
+	savedFilterRepo.EXPECT().GetVisible(gomock.Any(), 2).Return([]repository.SavedFilter{
+		{ID: 1, UserID: 2, Name: "Мои", Visibility: constant.PrivateFilterVisibility},
+		{ID: 2, UserID: 3, Name: "Общий", Visibility: constant.SharedFilterVisibility},
+		{ID: 3, UserID: 1, Name: "Глобальный", Visibility: constant.GlobalFilterVisibility},
+	}, nil)
+	savedFilterRepo.EXPECT().GetSettings(gomock.Any(), 2).Return([]repository.SavedFilterSettings{
+		{FilterID: 1, UserID: 2, Pinned: true},
+		{FilterID: 2, UserID: 2, Notify: true},
+	}, nil)
+
+	filters, err := savedFilterService.GetPinned(ctx, &repository.User{ID: 2, Role: constant.UserRole})
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	require.Equal(t, 1, filters[0].ID)
+	require.Equal(t, 3, filters[1].ID)
+}
+
+func TestSavedFilterService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	savedFilterService := NewSavedFilterService(savedFilterRepo)
+
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 4).Return(&repository.SavedFilter{
+		ID:         4,
+		UserID:     3,
+		Filter:     repository.TaskFilter{Status: constant.DoneTaskStatus},
+		Visibility: constant.PrivateFilterVisibility,
+	}, nil).Times(2)
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 5).Return(nil, pgx.ErrNoRows)
+
+	_, err := savedFilterService.Resolve(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 4)
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+
+	filter, err := savedFilterService.Resolve(ctx, &repository.User{ID: 3, Role: constant.UserRole}, 4)
+	require.NoError(t, err)
+	require.Equal(t, constant.DoneTaskStatus, filter.Status)
+
+	_, err = savedFilterService.Resolve(ctx, &repository.User{ID: 3, Role: constant.UserRole}, 5)
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+}
+
+func TestSavedFilterService_SetNotify_KeepsPinned(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	savedFilterService := NewSavedFilterService(savedFilterRepo)
+
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 4).Return(&repository.SavedFilter{
+		ID: 4, UserID: 3, Visibility: constant.SharedFilterVisibility,
+	}, nil)
+	savedFilterRepo.EXPECT().GetSettings(gomock.Any(), 2).Return([]repository.SavedFilterSettings{
+		{FilterID: 4, UserID: 2, Pinned: true},
+	}, nil)
+	savedFilterRepo.EXPECT().SaveSettings(gomock.Any(), &repository.SavedFilterSettings{
+		FilterID: 4, UserID: 2, Pinned: true, Notify: true,
+	}).Return(nil)
+
+	err := savedFilterService.SetNotify(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 4, true)
+	require.NoError(t, err)
+}
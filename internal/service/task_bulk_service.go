@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	maxBulkTasks    = 500
+	maxTaskLabelLen = 64
+)
+
+// TaskBulkResult - результат массовой операции для одной задачи.
+type TaskBulkResult struct {
+	TaskID int    `json:"taskId"`
+	Status string `json:"status" example:"OK"`
+}
+
+type ITaskBulkService interface {
+	Apply(ctx context.Context,
+		actor *repository.User,
+		action, value string,
+		taskIDs []int,
+		filter *repository.TaskFilter,
+	) ([]TaskBulkResult, error)
+}
+
+type TaskBulkService struct {
+	taskRepository    repository.ITaskRepo
+	userRepository    repository.IUserRepo
+	labelRepository   repository.ILabelRepo
+	outboxRepository  repository.IOutboxRepo
+	transactor        repository.ITransactor
+	attachmentService IAttachmentService
+}
+
+func NewTaskBulkService(
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	labelRepository repository.ILabelRepo,
+	outboxRepository repository.IOutboxRepo,
+	transactor repository.ITransactor,
+	attachmentService IAttachmentService,
+) *TaskBulkService {
+	return &TaskBulkService{
+		taskRepository:    taskRepository,
+		userRepository:    userRepository,
+		labelRepository:   labelRepository,
+		outboxRepository:  outboxRepository,
+		transactor:        transactor,
+		attachmentService: attachmentService,
+	}
+}
+
+// bulkChange - проверенное значение массовой операции.
+type bulkChange struct {
+	action   string
+	status   string
+	priority int
+	userID   int
+	label    string
+}
+
+// bulkTarget - задача массовой операции и её результат. task равен nil, если задача не найдена или недоступна.
+type bulkTarget struct {
+	taskID int
+	task   *repository.Task
+	status string
+}
+
+// Apply применяет действие к задачам из taskIDs или, если их нет, к задачам по фильтру. Все изменения выполняются
+// в одной транзакции. Задачи, которые не найдены или недоступны пользователю, пропускаются и отмечаются в результате.
+func (b *TaskBulkService) Apply(ctx context.Context,
+	actor *repository.User,
+	action, value string,
+	taskIDs []int,
+	filter *repository.TaskFilter,
+) ([]TaskBulkResult, error) {
+	change, err := b.prepareChange(ctx, actor, action, value)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := b.getTargets(ctx, actor, taskIDs, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make(map[int][]repository.Attachment)
+	if change.action == constant.BulkDeleteAction {
+		for _, target := range targets {
+			if target.task == nil {
+				continue
+			}
+			taskAttachments, getErr := b.attachmentService.GetFiles(ctx, target.taskID)
+			if getErr != nil {
+				return nil, getErr
+			}
+			attachments[target.taskID] = taskAttachments
+		}
+	}
+
+	err = b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for i := range targets {
+			if targets[i].task == nil {
+				continue
+			}
+
+			status, applyErr := b.apply(ctx, actor, change, targets[i].task)
+			if applyErr != nil {
+				return applyErr
+			}
+			targets[i].status = status
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]TaskBulkResult, 0, len(targets))
+	for _, target := range targets {
+		results = append(results, TaskBulkResult{TaskID: target.taskID, Status: target.status})
+		// записи о вложениях удалены каскадно вместе с задачей, файлы в хранилище - здесь.
+		if target.status == constant.BulkItemOK && len(attachments[target.taskID]) > 0 {
+			b.attachmentService.DeleteFiles(ctx, attachments[target.taskID])
+		}
+	}
+
+	return results, nil
+}
+
+func (b *TaskBulkService) prepareChange(ctx context.Context,
+	actor *repository.User,
+	action, value string,
+) (*bulkChange, error) {
+	change := &bulkChange{action: action}
+
+	switch action {
+	case constant.BulkStatusAction:
+		if !slices.Contains(constant.TaskStatuses, value) {
+			return nil, errs.BadReqErr{}
+		}
+		change.status = value
+	case constant.BulkPriorityAction:
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority < constant.Blocker || priority > constant.Low {
+			return nil, errs.BadReqErr{}
+		}
+		change.priority = priority
+	case constant.BulkAssignAction:
+		user, err := b.userRepository.GetByLogin(ctx, value)
+		if err != nil {
+			return nil, errs.BadReqErr{}
+		}
+		// назначать задачи на других пользователей может только ADMIN, как и при создании задачи.
+		if actor.Role != constant.AdminRole && user.ID != actor.ID {
+			return nil, errs.ForbiddenErr{}
+		}
+		change.userID = user.ID
+	case constant.BulkLabelAction:
+		label := strings.TrimSpace(value)
+		if label == "" || utf8.RuneCountInString(label) > maxTaskLabelLen {
+			return nil, errs.BadReqErr{}
+		}
+		change.label = label
+	case constant.BulkDeleteAction:
+	default:
+		return nil, errs.BadReqErr{}
+	}
+
+	return change, nil
+}
+
+// getTargets возвращает задачи по идентификаторам в порядке запроса или задачи по фильтру.
+// Фильтр пользователя, не являющегося ADMIN, ограничивается его задачами.
+func (b *TaskBulkService) getTargets(ctx context.Context,
+	actor *repository.User,
+	taskIDs []int,
+	filter *repository.TaskFilter,
+) ([]bulkTarget, error) {
+	if len(taskIDs) > 0 {
+		return b.getTargetsByIDs(ctx, actor, taskIDs)
+	}
+	if filter == nil || filter.IsEmpty() {
+		return nil, errs.BadReqErr{}
+	}
+
+	userFilter := *filter
+	if actor.Role != constant.AdminRole {
+		userFilter.UserID = actor.ID
+	}
+	tasks, err := b.taskRepository.GetByFilter(ctx, &userFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) > maxBulkTasks {
+		return nil, errs.BadReqErr{}
+	}
+
+	targets := make([]bulkTarget, 0, len(tasks))
+	for i := range tasks {
+		targets = append(targets, bulkTarget{taskID: tasks[i].ID, task: &tasks[i]})
+	}
+
+	return targets, nil
+}
+
+func (b *TaskBulkService) getTargetsByIDs(ctx context.Context,
+	actor *repository.User,
+	taskIDs []int,
+) ([]bulkTarget, error) {
+	targets := make([]bulkTarget, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if slices.ContainsFunc(targets, func(target bulkTarget) bool { return target.taskID == taskID }) {
+			continue
+		}
+		if len(targets) == maxBulkTasks {
+			return nil, errs.BadReqErr{}
+		}
+
+		task, err := getAccessibleTask(ctx, b.taskRepository, actor, taskID)
+		switch {
+		case errors.Is(err, errs.NotFoundErr{}):
+			targets = append(targets, bulkTarget{taskID: taskID, status: constant.BulkItemNotFound})
+		case errors.Is(err, errs.ForbiddenErr{}):
+			targets = append(targets, bulkTarget{taskID: taskID, status: constant.BulkItemForbidden})
+		case err != nil:
+			return nil, err
+		default:
+			targets = append(targets, bulkTarget{taskID: taskID, task: task})
+		}
+	}
+
+	return targets, nil
+}
+
+// apply выполняет действие над одной задачей внутри транзакции и возвращает его результат.
+func (b *TaskBulkService) apply(ctx context.Context,
+	actor *repository.User,
+	change *bulkChange,
+	task *repository.Task,
+) (string, error) {
+	switch change.action {
+	case constant.BulkDeleteAction:
+		if err := b.taskRepository.DeleteByID(ctx, task.ID); err != nil {
+			return "", err
+		}
+
+		return constant.BulkItemOK, addTaskEvent(ctx, b.outboxRepository, constant.TaskDeletedEvent, &TaskEvent{
+			Task:    *task,
+			ActorID: actorID(actor),
+		})
+	}
+
+	previousStatus, previousUserID := task.Status, task.UserID
+	switch change.action {
+	case constant.BulkStatusAction:
+		task.Status = change.status
+	case constant.BulkPriorityAction:
+		task.Priority = change.priority
+	case constant.BulkAssignAction:
+		task.UserID = change.userID
+	}
+
+	err := b.taskRepository.Update(ctx, task)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return constant.BulkItemConflict, nil
+	} else if err != nil {
+		return "", err
+	}
+	// метка добавляется после обновления, чтобы при конфликте версий задача осталась нетронутой.
+	if change.action == constant.BulkLabelAction {
+		if err = b.labelRepository.Add(ctx, task.ID, change.label); err != nil {
+			return "", err
+		}
+	}
+
+	return constant.BulkItemOK, addTaskEvent(ctx, b.outboxRepository, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           *task,
+		ActorID:        actorID(actor),
+		PreviousStatus: previousStatus,
+		PreviousUserID: previousUserID,
+	})
+}
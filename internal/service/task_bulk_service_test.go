@@ -0,0 +1,157 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	mockStorage "github.com/romakorinenko/task-manager/internal/storage/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskBulkService_Apply_StatusChanged(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	bulkService := NewTaskBulkService(taskRepo, nil, nil, outboxRepo, newTransactorMock(ctrl), nil)
+	user := &repository.User{ID: 1, Role: constant.UserRole}
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 1, Status: "OPEN"}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.Task{ID: 2, UserID: 2, Status: "OPEN"}, nil)
+	taskRepo.EXPECT().GetByID(gomock.Any(), 3).Return(nil, pgx.ErrNoRows)
+	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) error {
+			require.Equal(t, 1, task.ID)
+			require.Equal(t, constant.DoneTaskStatus, task.Status)
+			return nil
+		})
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.TaskUpdatedEvent, event.Event)
+
+			var taskEvent TaskEvent
+			require.NoError(t, json.Unmarshal([]byte(event.Payload), &taskEvent))
+			require.Equal(t, constant.OpenTaskStatus, taskEvent.PreviousStatus)
+			require.Equal(t, 1, taskEvent.ActorID)
+			return nil
+		})
+
+	results, err := bulkService.Apply(ctx, user, constant.BulkStatusAction, constant.DoneTaskStatus,
+		[]int{1, 2, 3, 1}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []TaskBulkResult{
+		{TaskID: 1, Status: constant.BulkItemOK},
+		{TaskID: 2, Status: constant.BulkItemForbidden},
+		{TaskID: 3, Status: constant.BulkItemNotFound},
+	}, results)
+}
+
+func TestTaskBulkService_Apply_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	bulkService := NewTaskBulkService(taskRepo, nil, nil, nil, newTransactorMock(ctrl), nil)
+	admin := &repository.User{ID: 1, Role: constant.AdminRole}
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 5).Return(&repository.Task{ID: 5, UserID: 2, Priority: 1}, nil)
+	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+
+	results, err := bulkService.Apply(ctx, admin, constant.BulkPriorityAction, "3", []int{5}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []TaskBulkResult{{TaskID: 5, Status: constant.BulkItemConflict}}, results)
+}
+
+func TestTaskBulkService_Apply_FilterLimitedToOwnTasks(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	labelRepo := mockRepository.NewMockILabelRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	bulkService := NewTaskBulkService(taskRepo, nil, labelRepo, outboxRepo, newTransactorMock(ctrl), nil)
+	user := &repository.User{ID: 1, Role: constant.UserRole}
+
+	taskRepo.EXPECT().GetByFilter(gomock.Any(), &repository.TaskFilter{Status: "DONE", UserID: 1}).
+		Return([]repository.Task{{ID: 4, UserID: 1, Version: 2}, {ID: 6, UserID: 1, Version: 5}}, nil)
+	taskRepo.EXPECT().Update(gomock.Any(), &repository.Task{ID: 4, UserID: 1, Version: 2}).Return(nil)
+	taskRepo.EXPECT().Update(gomock.Any(), &repository.Task{ID: 6, UserID: 1, Version: 5}).Return(nil)
+	labelRepo.EXPECT().Add(gomock.Any(), 4, "sprint-12").Return(nil)
+	labelRepo.EXPECT().Add(gomock.Any(), 6, "sprint-12").Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.TaskUpdatedEvent, event.Event)
+			return nil
+		}).Times(2)
+
+	results, err := bulkService.Apply(ctx, user, constant.BulkLabelAction, " sprint-12 ", nil,
+		&repository.TaskFilter{Status: "DONE"})
+	require.NoError(t, err)
+	require.Equal(t, []TaskBulkResult{
+		{TaskID: 4, Status: constant.BulkItemOK},
+		{TaskID: 6, Status: constant.BulkItemOK},
+	}, results)
+}
+
+func TestTaskBulkService_Apply_TasksDeleted(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, attachmentsConfig)
+	bulkService := NewTaskBulkService(taskRepo, nil, nil, outboxRepo, newTransactorMock(ctrl), attachmentService)
+	admin := &repository.User{ID: 1, Role: constant.AdminRole}
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&repository.Task{ID: 7, UserID: 2}, nil)
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 7).
+		Return([]repository.Attachment{{ID: 1, TaskID: 7, StorageKey: "7/log.txt"}}, nil)
+	taskRepo.EXPECT().DeleteByID(gomock.Any(), 7).Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.TaskDeletedEvent, event.Event)
+			return nil
+		})
+	attachmentStorage.EXPECT().Delete(gomock.Any(), "7/log.txt").Return(nil)
+
+	results, err := bulkService.Apply(ctx, admin, constant.BulkDeleteAction, "", []int{7}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []TaskBulkResult{{TaskID: 7, Status: constant.BulkItemOK}}, results)
+}
+
+func TestTaskBulkService_Apply_UserCannotAssignOthers(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	bulkService := NewTaskBulkService(nil, userRepo, nil, nil, nil, nil)
+	user := &repository.User{ID: 1, Role: constant.UserRole}
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "admin").Return(&repository.User{ID: 2}, nil)
+
+	results, err := bulkService.Apply(ctx, user, constant.BulkAssignAction, "admin", []int{1}, nil)
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+	require.Nil(t, results)
+}
+
+func TestTaskBulkService_Apply_BadRequest(t *testing.T) {
+	ctx := context.Background()
+	bulkService := NewTaskBulkService(nil, nil, nil, nil, nil, nil)
+	user := &repository.User{ID: 1, Role: constant.UserRole}
+
+	_, err := bulkService.Apply(ctx, user, "archive", "", []int{1}, nil)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	_, err = bulkService.Apply(ctx, user, constant.BulkPriorityAction, "5", []int{1}, nil)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	_, err = bulkService.Apply(ctx, user, constant.BulkStatusAction, "DONE", nil, &repository.TaskFilter{})
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/metrics"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+// TaskEvent - содержимое событий задач в outbox. ActorID - автор изменения, 0 если неизвестен.
+// PreviousStatus и PreviousUserID заполняются только для task.updated.
+type TaskEvent struct {
+	Task           repository.Task `json:"task"`
+	ActorID        int             `json:"actorId,omitempty"`
+	PreviousStatus string          `json:"previousStatus,omitempty"`
+	PreviousUserID int             `json:"previousUserId,omitempty"`
+}
+
+// TaskNotificationHandler уведомляет исполнителя о назначенной или переназначенной задаче,
+// а владельца и тех, кто следит за задачей, - о смене её статуса. При повторной обработке события
+// уже отправленные уведомления не повторяются.
+type TaskNotificationHandler struct {
+	notificationService INotificationService
+	watcherRepository   repository.IWatcherRepo
+}
+
+func NewTaskNotificationHandler(
+	notificationService INotificationService,
+	watcherRepository repository.IWatcherRepo,
+) *TaskNotificationHandler {
+	return &TaskNotificationHandler{notificationService: notificationService, watcherRepository: watcherRepository}
+}
+
+func (h *TaskNotificationHandler) Name() string {
+	return "task-notifications"
+}
+
+func (h *TaskNotificationHandler) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+	task := taskEvent.Task
+
+	var actor *repository.User
+	if taskEvent.ActorID != 0 {
+		actor = &repository.User{ID: taskEvent.ActorID}
+	}
+
+	// одно изменение может и переназначить задачу, и сменить её статус, например в массовом действии,
+	// поэтому условия проверяются независимо.
+	if event.Event == constant.TaskCreatedEvent || event.Event == constant.TaskUpdatedEvent &&
+		taskEvent.PreviousUserID != 0 && taskEvent.PreviousUserID != task.UserID {
+		if err := h.notificationService.Notify(ctx, actor, &repository.Notification{
+			UserID:  task.UserID,
+			TaskID:  task.ID,
+			Event:   constant.TaskAssignedEvent,
+			Message: fmt.Sprintf("Вам назначена задача «%s»", task.Title),
+			EventID: event.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if event.Event == constant.TaskUpdatedEvent && taskEvent.PreviousStatus != task.Status {
+		return h.notifyStatusChanged(ctx, actor, event.ID, &taskEvent)
+	}
+
+	return nil
+}
+
+// notifyStatusChanged уведомляет о смене статуса владельца задачи и тех, кто за ней следит. Каждый получает
+// одно уведомление, автор изменения - ни одного.
+func (h *TaskNotificationHandler) notifyStatusChanged(ctx context.Context,
+	actor *repository.User,
+	eventID int,
+	taskEvent *TaskEvent,
+) error {
+	task := taskEvent.Task
+	watcherIDs, err := h.watcherRepository.GetUserIDs(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	notified := make(map[int]bool, len(watcherIDs)+1)
+	for _, userID := range append([]int{task.UserID}, watcherIDs...) {
+		if notified[userID] {
+			continue
+		}
+		notified[userID] = true
+
+		if err = h.notificationService.Notify(ctx, actor, &repository.Notification{
+			UserID: userID,
+			TaskID: task.ID,
+			Event:  constant.TaskStatusChangedEvent,
+			Message: fmt.Sprintf("Статус задачи «%s» изменён: %s → %s",
+				task.Title, taskEvent.PreviousStatus, task.Status),
+			EventID: eventID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TaskWebhookHandler ставит события задач в очередь доставки подписчикам вебхуков.
+type TaskWebhookHandler struct {
+	webhookService IWebhookService
+}
+
+func NewTaskWebhookHandler(webhookService IWebhookService) *TaskWebhookHandler {
+	return &TaskWebhookHandler{webhookService: webhookService}
+}
+
+func (h *TaskWebhookHandler) Name() string {
+	return "task-webhooks"
+}
+
+func (h *TaskWebhookHandler) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+
+	return h.webhookService.Publish(ctx, event.ID, event.Event, &taskEvent.Task)
+}
+
+// SavedFilterNotificationHandler уведомляет пользователей, подписанных на сохранённые фильтры, о созданных задачах
+// и о задачах, у которых сменился статус или исполнитель, если задача подходит под фильтр и видна пользователю.
+// Пользователь получает одно уведомление о событии, даже если задача подходит под несколько его фильтров.
+type SavedFilterNotificationHandler struct {
+	savedFilterRepository repository.ISavedFilterRepo
+	taskRepository        repository.ITaskRepo
+	userRepository        repository.IUserRepo
+	notificationService   INotificationService
+}
+
+func NewSavedFilterNotificationHandler(
+	savedFilterRepository repository.ISavedFilterRepo,
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	notificationService INotificationService,
+) *SavedFilterNotificationHandler {
+	return &SavedFilterNotificationHandler{
+		savedFilterRepository: savedFilterRepository,
+		taskRepository:        taskRepository,
+		userRepository:        userRepository,
+		notificationService:   notificationService,
+	}
+}
+
+func (h *SavedFilterNotificationHandler) Name() string {
+	return "saved-filter-notifications"
+}
+
+func (h *SavedFilterNotificationHandler) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+	task := taskEvent.Task
+
+	switch {
+	case event.Event == constant.TaskCreatedEvent,
+		event.Event == constant.TaskUpdatedEvent && (taskEvent.PreviousStatus != task.Status ||
+			taskEvent.PreviousUserID != 0 && taskEvent.PreviousUserID != task.UserID):
+	default:
+		return nil
+	}
+
+	subscriptions, err := h.savedFilterRepository.GetNotifySettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	var actor *repository.User
+	if taskEvent.ActorID != 0 {
+		actor = &repository.User{ID: taskEvent.ActorID}
+	}
+
+	notified := make(map[int]bool)
+	for _, subscription := range subscriptions {
+		if notified[subscription.UserID] {
+			continue
+		}
+
+		savedFilter, matches, matchErr := h.match(ctx, &task, &subscription)
+		if matchErr != nil {
+			return matchErr
+		}
+		if !matches {
+			continue
+		}
+
+		err = h.notificationService.Notify(ctx, actor, &repository.Notification{
+			UserID:  subscription.UserID,
+			TaskID:  task.ID,
+			Event:   constant.FilterMatchedEvent,
+			Message: fmt.Sprintf("Задача «%s» подходит под фильтр «%s»", task.Title, savedFilter.Name),
+			EventID: event.ID,
+		})
+		if err != nil {
+			return err
+		}
+		notified[subscription.UserID] = true
+	}
+
+	return nil
+}
+
+// match сообщает, подходит ли задача под фильтр подписки. Удалённые фильтры и пользователи,
+// а также фильтры и задачи, которые пользователю больше не видны, не подходят.
+func (h *SavedFilterNotificationHandler) match(ctx context.Context,
+	task *repository.Task,
+	subscription *repository.SavedFilterSettings,
+) (*repository.SavedFilter, bool, error) {
+	user, err := h.userRepository.GetByID(ctx, subscription.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	if user.Role != constant.AdminRole && task.UserID != user.ID {
+		return nil, false, nil
+	}
+
+	savedFilter, err := h.savedFilterRepository.GetByID(ctx, subscription.FilterID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	if !isFilterVisible(user, savedFilter) {
+		return nil, false, nil
+	}
+
+	matches, err := h.taskRepository.MatchesFilter(ctx, task.ID, &savedFilter.Filter)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return savedFilter, matches, nil
+}
+
+// TaskStatusHistoryHandler записывает в историю статусов статус созданной задачи и каждую смену статуса.
+// По истории строятся отчёты о выполнении задач.
+type TaskStatusHistoryHandler struct {
+	taskStatusHistoryRepository repository.ITaskStatusHistoryRepo
+}
+
+func NewTaskStatusHistoryHandler(
+	taskStatusHistoryRepository repository.ITaskStatusHistoryRepo,
+) *TaskStatusHistoryHandler {
+	return &TaskStatusHistoryHandler{taskStatusHistoryRepository: taskStatusHistoryRepository}
+}
+
+func (h *TaskStatusHistoryHandler) Name() string {
+	return "task-status-history"
+}
+
+func (h *TaskStatusHistoryHandler) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	if event.Event != constant.TaskCreatedEvent && event.Event != constant.TaskUpdatedEvent {
+		return nil
+	}
+
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+	if event.Event == constant.TaskUpdatedEvent && taskEvent.PreviousStatus == taskEvent.Task.Status {
+		return nil
+	}
+
+	return h.taskStatusHistoryRepository.Add(ctx, &repository.TaskStatusChange{
+		EventID:   event.ID,
+		TaskID:    taskEvent.Task.ID,
+		OldStatus: taskEvent.PreviousStatus,
+		NewStatus: taskEvent.Task.Status,
+		ChangedAt: event.CreatedAt,
+	})
+}
+
+// TaskMetricsHandler считает в метриках Prometheus созданные задачи и переходы задач в DONE. Через outbox
+// учитываются задачи, созданные любым способом: по одной, массово, импортом или из шаблона.
+type TaskMetricsHandler struct{}
+
+func NewTaskMetricsHandler() *TaskMetricsHandler {
+	return &TaskMetricsHandler{}
+}
+
+func (h *TaskMetricsHandler) Name() string {
+	return "task-metrics"
+}
+
+func (h *TaskMetricsHandler) Handle(_ context.Context, event *repository.OutboxEvent) error {
+	if event.Event != constant.TaskCreatedEvent && event.Event != constant.TaskUpdatedEvent {
+		return nil
+	}
+
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return err
+	}
+
+	if event.Event == constant.TaskCreatedEvent {
+		metrics.TasksCreated.Inc()
+	}
+	if taskEvent.Task.Status == constant.DoneTaskStatus && taskEvent.PreviousStatus != constant.DoneTaskStatus {
+		metrics.TasksCompleted.Inc()
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/metrics"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskNotificationHandler_Handle_AssigneeNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 1).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  1,
+		TaskID:  5,
+		Event:   constant.TaskAssignedEvent,
+		Message: "Вам назначена задача «Title»",
+		EventID: 1,
+	}).Return(nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskCreatedEvent, &TaskEvent{
+		Task:    repository.Task{ID: 5, UserID: 1, Title: "Title"},
+		ActorID: 2,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_SelfAssignedNotNotified(t *testing.T) {
+	ctx := context.Background()
+	handler := NewTaskNotificationHandler(NewNotificationService(nil, disabledEmailService), nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskCreatedEvent, &TaskEvent{
+		Task:    repository.Task{ID: 5, UserID: 1, Title: "Title"},
+		ActorID: 1,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_ReassignedNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 4).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  4,
+		TaskID:  1,
+		Event:   constant.TaskAssignedEvent,
+		Message: "Вам назначена задача «title»",
+		EventID: 1,
+	}).Return(nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 4, Title: "title", Status: constant.OpenTaskStatus},
+		ActorID:        2,
+		PreviousStatus: constant.OpenTaskStatus,
+		PreviousUserID: 3,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_StatusChangeNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	watcherRepo.EXPECT().GetUserIDs(gomock.Any(), 1).Return([]int{}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 3).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  3,
+		TaskID:  1,
+		Event:   constant.TaskStatusChangedEvent,
+		Message: "Статус задачи «title» изменён: OPEN → DONE",
+		EventID: 1,
+	}).Return(nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 3, Title: "title", Status: "DONE"},
+		PreviousStatus: constant.OpenTaskStatus,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_ReassignedWithStatusChangeBothNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	watcherRepo.EXPECT().GetUserIDs(gomock.Any(), 1).Return([]int{4}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 4).
+		Return([]repository.NotificationPreference{}, nil).Times(2)
+	gomock.InOrder(
+		notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+			UserID:  4,
+			TaskID:  1,
+			Event:   constant.TaskAssignedEvent,
+			Message: "Вам назначена задача «title»",
+			EventID: 1,
+		}).Return(nil),
+		notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+			UserID:  4,
+			TaskID:  1,
+			Event:   constant.TaskStatusChangedEvent,
+			Message: "Статус задачи «title» изменён: OPEN → IN_PROGRESS",
+			EventID: 1,
+		}).Return(nil),
+	)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 4, Title: "title", Status: constant.InProgressTaskStatus},
+		ActorID:        2,
+		PreviousStatus: constant.OpenTaskStatus,
+		PreviousUserID: 3,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_StatusChangeWatchersNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	// 2 - автор изменения, 3 - владелец и одновременно наблюдатель, 5 - наблюдатель.
+	watcherRepo.EXPECT().GetUserIDs(gomock.Any(), 1).Return([]int{2, 3, 5}, nil)
+	for _, userID := range []int{3, 5} {
+		notificationRepo.EXPECT().GetPreferences(gomock.Any(), userID).
+			Return([]repository.NotificationPreference{}, nil)
+		notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+			UserID:  userID,
+			TaskID:  1,
+			Event:   constant.TaskStatusChangedEvent,
+			Message: "Статус задачи «title» изменён: OPEN → DONE",
+			EventID: 1,
+		}).Return(nil)
+	}
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 3, Title: "title", Status: constant.DoneTaskStatus},
+		ActorID:        2,
+		PreviousStatus: constant.OpenTaskStatus,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_StatusUnchangedNotNotified(t *testing.T) {
+	ctx := context.Background()
+	handler := NewTaskNotificationHandler(NewNotificationService(nil, disabledEmailService), nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 3, Title: "title", Status: constant.OpenTaskStatus},
+		PreviousStatus: constant.OpenTaskStatus,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_DisabledEventNotNotified(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	watcherRepo.EXPECT().GetUserIDs(gomock.Any(), 1).Return([]int{}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 3).Return([]repository.NotificationPreference{
+		{UserID: 3, Event: constant.TaskStatusChangedEvent, Enabled: false},
+	}, nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 3, Title: "title", Status: "DONE"},
+		PreviousStatus: constant.OpenTaskStatus,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskNotificationHandler_Handle_RetryNotifiesOnlyRemaining(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	watcherRepo := mockRepository.NewMockIWatcherRepo(ctrl)
+	handler := NewTaskNotificationHandler(NewNotificationService(notificationRepo, disabledEmailService), watcherRepo)
+
+	// владелец 3 получил уведомление при прошлой попытке, наблюдатель 5 - нет.
+	watcherRepo.EXPECT().GetUserIDs(gomock.Any(), 1).Return([]int{5}, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), gomock.Any()).
+		Return([]repository.NotificationPreference{}, nil).Times(2)
+	gomock.InOrder(
+		notificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrNotificationExists),
+		notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+			UserID:  5,
+			TaskID:  1,
+			Event:   constant.TaskStatusChangedEvent,
+			Message: "Статус задачи «title» изменён: OPEN → DONE",
+			EventID: 1,
+		}).Return(nil),
+	)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 1, UserID: 3, Title: "title", Status: constant.DoneTaskStatus},
+		ActorID:        2,
+		PreviousStatus: constant.OpenTaskStatus,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskWebhookHandler_Handle_EventPublished(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	handler := NewTaskWebhookHandler(NewWebhookService(webhookRepo, nil, webhookConfig))
+
+	webhookRepo.EXPECT().GetByEvent(gomock.Any(), constant.TaskDeletedEvent).Return([]repository.Webhook{{ID: 1}}, nil)
+	webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, 1, delivery.EventID)
+			require.Contains(t, delivery.Payload, `"event":"task.deleted"`)
+			require.Contains(t, delivery.Payload, `"data":{"id":5`)
+			return nil
+		})
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskDeletedEvent, &TaskEvent{
+		Task: repository.Task{ID: 5, Title: "Release"},
+	}))
+	require.NoError(t, err)
+}
+
+func newTaskOutboxEvent(t *testing.T, event string, taskEvent *TaskEvent) *repository.OutboxEvent {
+	payload, err := json.Marshal(taskEvent)
+	require.NoError(t, err)
+
+	return &repository.OutboxEvent{ID: 1, AggregateID: taskEvent.Task.ID, Event: event, Payload: string(payload)}
+}
+
+func TestSavedFilterNotificationHandler_Handle_SubscriberNotifiedOnce(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	savedFilterRepo := mockRepository.NewMockISavedFilterRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	notificationRepo := mockRepository.NewMockINotificationRepo(ctrl)
+	handler := NewSavedFilterNotificationHandler(savedFilterRepo, taskRepo, userRepo,
+		NewNotificationService(notificationRepo, disabledEmailService))
+
+	admin := &repository.User{ID: 1, Role: constant.AdminRole}
+	urgent := &repository.SavedFilter{
+		ID: 4, UserID: 1, Name: "Срочные", Filter: repository.TaskFilter{Priority: constant.High},
+		Visibility: constant.PrivateFilterVisibility,
+	}
+	savedFilterRepo.EXPECT().GetNotifySettings(gomock.Any()).Return([]repository.SavedFilterSettings{
+		{FilterID: 4, UserID: 1, Notify: true},
+		{FilterID: 6, UserID: 1, Notify: true},
+		{FilterID: 4, UserID: 3, Notify: true},
+	}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 1).Return(admin, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 3).Return(&repository.User{ID: 3, Role: constant.UserRole}, nil)
+	savedFilterRepo.EXPECT().GetByID(gomock.Any(), 4).Return(urgent, nil)
+	taskRepo.EXPECT().MatchesFilter(gomock.Any(), 5, &urgent.Filter).Return(true, nil)
+	notificationRepo.EXPECT().GetPreferences(gomock.Any(), 1).Return([]repository.NotificationPreference{}, nil)
+	notificationRepo.EXPECT().Create(gomock.Any(), &repository.Notification{
+		UserID:  1,
+		TaskID:  5,
+		Event:   constant.FilterMatchedEvent,
+		Message: "Задача «Title» подходит под фильтр «Срочные»",
+		EventID: 1,
+	}).Return(nil)
+
+	// пользователь 3 не видит чужую задачу, поэтому его фильтр не проверяется.
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskCreatedEvent, &TaskEvent{
+		Task:    repository.Task{ID: 5, UserID: 2, Title: "Title", Priority: constant.High},
+		ActorID: 2,
+	}))
+	require.NoError(t, err)
+}
+
+func TestSavedFilterNotificationHandler_Handle_UnchangedTaskSkipped(t *testing.T) {
+	ctx := context.Background()
+	handler := NewSavedFilterNotificationHandler(nil, nil, nil, nil)
+
+	err := handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, UserID: 2, Title: "New title", Status: constant.OpenTaskStatus},
+		PreviousStatus: constant.OpenTaskStatus,
+		PreviousUserID: 2,
+	}))
+	require.NoError(t, err)
+}
+
+func TestTaskStatusHistoryHandler_Handle_StatusChangeRecorded(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	statusHistoryRepo := mockRepository.NewMockITaskStatusHistoryRepo(ctrl)
+	handler := NewTaskStatusHistoryHandler(statusHistoryRepo)
+
+	event := newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, UserID: 1, Status: constant.DoneTaskStatus},
+		PreviousStatus: constant.InProgressTaskStatus,
+	})
+	event.ID = 42
+	event.CreatedAt = time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC)
+	statusHistoryRepo.EXPECT().Add(gomock.Any(), &repository.TaskStatusChange{
+		EventID:   42,
+		TaskID:    5,
+		OldStatus: constant.InProgressTaskStatus,
+		NewStatus: constant.DoneTaskStatus,
+		ChangedAt: time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC),
+	}).Return(nil)
+
+	require.NoError(t, handler.Handle(ctx, event))
+
+	// изменение без смены статуса в историю не попадает.
+	require.NoError(t, handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, UserID: 1, Status: constant.DoneTaskStatus},
+		PreviousStatus: constant.DoneTaskStatus,
+	})))
+}
+
+func TestTaskMetricsHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	handler := NewTaskMetricsHandler()
+	created := testutil.ToFloat64(metrics.TasksCreated)
+	completed := testutil.ToFloat64(metrics.TasksCompleted)
+
+	require.NoError(t, handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskCreatedEvent, &TaskEvent{
+		Task: repository.Task{ID: 5, Status: constant.OpenTaskStatus},
+	})))
+	require.NoError(t, handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, Status: constant.DoneTaskStatus},
+		PreviousStatus: constant.InProgressTaskStatus,
+	})))
+	// изменение выполненной задачи без смены статуса повторно не считается.
+	require.NoError(t, handler.Handle(ctx, newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, Status: constant.DoneTaskStatus},
+		PreviousStatus: constant.DoneTaskStatus,
+	})))
+
+	require.InDelta(t, created+1, testutil.ToFloat64(metrics.TasksCreated), 0)
+	require.InDelta(t, completed+1, testutil.ToFloat64(metrics.TasksCompleted), 0)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/export"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	CSVExportFormat  = "csv"
+	JSONExportFormat = "json"
+	XLSXExportFormat = "xlsx"
+)
+
+// taskExportColumns - столбцы, которые можно выгрузить, и их значения.
+var taskExportColumns = map[string]func(task *repository.TaskWithLogin) any{
+	"id":          func(task *repository.TaskWithLogin) any { return task.ID },
+	"title":       func(task *repository.TaskWithLogin) any { return task.Title },
+	"description": func(task *repository.TaskWithLogin) any { return task.Description },
+	"priority":    func(task *repository.TaskWithLogin) any { return task.Priority },
+	"status":      func(task *repository.TaskWithLogin) any { return task.Status },
+	"createdAt":   func(task *repository.TaskWithLogin) any { return task.CreatedAt },
+	"updatedAt":   func(task *repository.TaskWithLogin) any { return task.UpdatedAt },
+	"userLogin":   func(task *repository.TaskWithLogin) any { return task.UserLogin },
+	"labels":      func(task *repository.TaskWithLogin) any { return task.Labels },
+	"version":     func(task *repository.TaskWithLogin) any { return task.Version },
+	"dueAt": func(task *repository.TaskWithLogin) any {
+		if task.DueAt == nil {
+			return nil
+		}
+		return *task.DueAt
+	},
+}
+
+// DefaultTaskExportColumns выгружаются, если столбцы не выбраны.
+var DefaultTaskExportColumns = []string{
+	"id", "title", "description", "priority", "status", "createdAt", "updatedAt", "dueAt", "userLogin", "labels",
+}
+
+type ITaskExportService interface {
+	Export(ctx context.Context,
+		user *repository.User,
+		format string,
+		columns []string,
+		filter *repository.TaskFilter,
+		w io.Writer,
+	) error
+}
+
+type TaskExportService struct {
+	taskRepository repository.ITaskRepo
+}
+
+func NewTaskExportService(taskRepository repository.ITaskRepo) *TaskExportService {
+	return &TaskExportService{taskRepository: taskRepository}
+}
+
+// Export пишет в w задачи по фильтру в формате csv, json (JSON Lines) или xlsx, читая их из базы по одной.
+// Видимость та же, что у списка задач: ADMIN выгружает все задачи, остальные - только свои.
+// Неизвестный формат или столбец - errs.BadReqErr, в этом случае в w ничего не записывается.
+func (e *TaskExportService) Export(ctx context.Context,
+	user *repository.User,
+	format string,
+	columns []string,
+	filter *repository.TaskFilter,
+	w io.Writer,
+) error {
+	if len(columns) == 0 {
+		columns = DefaultTaskExportColumns
+	}
+	for i, column := range columns {
+		if _, ok := taskExportColumns[column]; !ok || slices.Contains(columns[:i], column) {
+			return errs.BadReqErr{}
+		}
+	}
+	if format != CSVExportFormat && format != JSONExportFormat && format != XLSXExportFormat {
+		return errs.BadReqErr{}
+	}
+
+	userFilter := repository.TaskFilter{}
+	if filter != nil {
+		userFilter = *filter
+	}
+	if user.Role != constant.AdminRole {
+		userFilter.UserID = user.ID
+	}
+
+	writer, err := newExportWriter(format, w, columns)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(columns))
+	err = e.taskRepository.IterateWithLogin(ctx, &userFilter, func(task *repository.TaskWithLogin) error {
+		for i, column := range columns {
+			values[i] = taskExportColumns[column](task)
+		}
+
+		return writer.WriteRow(values)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func newExportWriter(format string, w io.Writer, columns []string) (export.Writer, error) {
+	switch format {
+	case JSONExportFormat:
+		return export.NewJSONWriter(w, columns), nil
+	case XLSXExportFormat:
+		return export.NewXLSXWriter(w, columns)
+	default:
+		return export.NewCSVWriter(w, columns)
+	}
+}
@@ -0,0 +1,68 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskExportService_Export_OwnTasksExported(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	exportService := NewTaskExportService(taskRepo)
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+
+	taskRepo.EXPECT().IterateWithLogin(gomock.Any(), &repository.TaskFilter{Status: "OPEN", UserID: 2}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *repository.TaskFilter, fn func(*repository.TaskWithLogin) error) error {
+			require.NoError(t, fn(&repository.TaskWithLogin{ID: 1, Title: "Release", UserLogin: "user"}))
+			return fn(&repository.TaskWithLogin{ID: 3, Title: "Docs", UserLogin: "user"})
+		})
+
+	buf := &bytes.Buffer{}
+	err := exportService.Export(ctx, user, CSVExportFormat, []string{"id", "title", "userLogin"},
+		&repository.TaskFilter{Status: "OPEN"}, buf)
+	require.NoError(t, err)
+	require.Equal(t, "id,title,userLogin\n1,Release,user\n3,Docs,user\n", buf.String())
+}
+
+func TestTaskExportService_Export_AdminExportsAll(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	exportService := NewTaskExportService(taskRepo)
+	admin := &repository.User{ID: 1, Role: constant.AdminRole}
+
+	taskRepo.EXPECT().IterateWithLogin(gomock.Any(), &repository.TaskFilter{}, gomock.Any()).Return(nil)
+
+	buf := &bytes.Buffer{}
+	err := exportService.Export(ctx, admin, JSONExportFormat, nil, nil, buf)
+	require.NoError(t, err)
+	require.Empty(t, buf.String())
+}
+
+func TestTaskExportService_Export_BadRequest(t *testing.T) {
+	ctx := context.Background()
+	exportService := NewTaskExportService(nil)
+	user := &repository.User{ID: 2, Role: constant.UserRole}
+
+	buf := &bytes.Buffer{}
+	err := exportService.Export(ctx, user, "pdf", nil, nil, buf)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	err = exportService.Export(ctx, user, CSVExportFormat, []string{"id", "password"}, nil, buf)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	err = exportService.Export(ctx, user, CSVExportFormat, []string{"id", "id"}, nil, buf)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const (
+	CSVImportFormat  = "csv"
+	JSONImportFormat = "json"
+
+	MaxImportSize = 5 << 20
+	maxImportRows = 5000
+)
+
+// Поля задачи, в которые импортируются столбцы файла.
+const (
+	titleImportField       = "title"
+	descriptionImportField = "description"
+	priorityImportField    = "priority"
+	statusImportField      = "status"
+	userLoginImportField   = "userLogin"
+)
+
+var (
+	importFields = []string{
+		titleImportField, descriptionImportField, priorityImportField, statusImportField, userLoginImportField,
+	}
+	requiredImportFields = []string{titleImportField, descriptionImportField, priorityImportField, userLoginImportField}
+)
+
+// TaskImportError - ошибка в строке импорта. Row - номер записи начиная с 1, 0 - ошибка всего файла.
+type TaskImportError struct {
+	Row   int    `json:"row"`
+	Field string `json:"field,omitempty"`
+	Error string `json:"error"`
+}
+
+// TaskImportReport - результат проверки или импорта. Columns - столбцы файла, по ним составляется сопоставление.
+type TaskImportReport struct {
+	DryRun   bool              `json:"dryRun"`
+	Columns  []string          `json:"columns"`
+	Rows     int               `json:"rows"`
+	Imported int               `json:"imported"`
+	Errors   []TaskImportError `json:"errors"`
+}
+
+type ITaskImportService interface {
+	Import(ctx context.Context,
+		actor *repository.User,
+		format string,
+		reader io.Reader,
+		mapping map[string]string,
+		dryRun bool,
+	) (*TaskImportReport, error)
+}
+
+type TaskImportService struct {
+	taskRepository   repository.ITaskRepo
+	userRepository   repository.IUserRepo
+	outboxRepository repository.IOutboxRepo
+	transactor       repository.ITransactor
+}
+
+func NewTaskImportService(
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	outboxRepository repository.IOutboxRepo,
+	transactor repository.ITransactor,
+) *TaskImportService {
+	return &TaskImportService{
+		taskRepository:   taskRepository,
+		userRepository:   userRepository,
+		outboxRepository: outboxRepository,
+		transactor:       transactor,
+	}
+}
+
+// Import читает задачи из CSV или JSON и проверяет каждую запись по правилам создания задачи.
+// mapping сопоставляет поле задачи (title, description, priority, status, userLogin) столбцу файла;
+// не указанные поля берутся из одноимённых столбцов. Задачи создаются, только если ошибок нет и dryRun не задан,
+// все сразу в одной транзакции.
+func (i *TaskImportService) Import(ctx context.Context,
+	actor *repository.User,
+	format string,
+	reader io.Reader,
+	mapping map[string]string,
+	dryRun bool,
+) (*TaskImportReport, error) {
+	for field := range mapping {
+		if !slices.Contains(importFields, field) {
+			return nil, errs.BadReqErr{}
+		}
+	}
+
+	if format != CSVImportFormat && format != JSONImportFormat {
+		return nil, errs.BadReqErr{}
+	}
+
+	report := &TaskImportReport{DryRun: dryRun, Columns: []string{}, Errors: []TaskImportError{}}
+	columns, records, err := readImportRecords(format, reader)
+	if err != nil {
+		report.Errors = append(report.Errors, TaskImportError{Error: err.Error()})
+		return report, nil
+	}
+	if len(records) > maxImportRows {
+		return nil, errs.BadReqErr{}
+	}
+
+	report.Columns, report.Rows = columns, len(records)
+	for _, field := range requiredImportFields {
+		if column := importColumn(mapping, field); !slices.Contains(columns, column) {
+			report.Errors = append(report.Errors, TaskImportError{
+				Field: field,
+				Error: fmt.Sprintf("column %q not found", column),
+			})
+		}
+	}
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	tasks := make([]repository.Task, 0, len(records))
+	users := make(map[string]*repository.User)
+	for row, record := range records {
+		task, rowErr, err := i.toTask(ctx, record, mapping, users)
+		if err != nil {
+			return nil, err
+		}
+		if rowErr != nil {
+			rowErr.Row = row + 1
+			report.Errors = append(report.Errors, *rowErr)
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	err = i.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		for _, task := range tasks {
+			taskID, createErr := i.taskRepository.Create(ctx, &task)
+			if createErr != nil {
+				return createErr
+			}
+			task.ID = taskID
+
+			eventErr := addTaskEvent(ctx, i.outboxRepository, constant.TaskCreatedEvent, &TaskEvent{
+				Task:    task,
+				ActorID: actorID(actor),
+			})
+			if eventErr != nil {
+				return eventErr
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Imported = len(tasks)
+
+	return report, nil
+}
+
+// toTask собирает задачу из записи файла. Ошибки записи возвращаются в TaskImportError, error - только ошибки
+// чтения из базы. users кэширует исполнителей, найденных по логину.
+func (i *TaskImportService) toTask(ctx context.Context,
+	record map[string]string,
+	mapping map[string]string,
+	users map[string]*repository.User,
+) (*repository.Task, *TaskImportError, error) {
+	value := func(field string) string {
+		return strings.TrimSpace(record[importColumn(mapping, field)])
+	}
+
+	priority, err := strconv.Atoi(value(priorityImportField))
+	if err != nil {
+		return nil, &TaskImportError{Field: priorityImportField, Error: "priority is not a number"}, nil
+	}
+	title, description, userLogin := value(titleImportField), value(descriptionImportField), value(userLoginImportField)
+	if err = validateNewTask(title, description, userLogin, priority); err != nil {
+		return nil, &TaskImportError{Error: err.Error()}, nil
+	}
+
+	status := value(statusImportField)
+	if status == "" {
+		status = constant.OpenTaskStatus
+	} else if !slices.Contains(constant.TaskStatuses, status) {
+		return nil, &TaskImportError{Field: statusImportField, Error: fmt.Sprintf("unknown status %q", status)}, nil
+	}
+
+	user, ok := users[userLogin]
+	if !ok {
+		user, err = i.userRepository.GetByLogin(ctx, userLogin)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, err
+		}
+		users[userLogin] = user
+	}
+	if user == nil {
+		return nil, &TaskImportError{
+			Field: userLoginImportField,
+			Error: fmt.Sprintf("user %q not found", userLogin),
+		}, nil
+	}
+
+	now := time.Now()
+	return &repository.Task{
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		Status:      status,
+		UserID:      user.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil, nil
+}
+
+func importColumn(mapping map[string]string, field string) string {
+	if column, ok := mapping[field]; ok && column != "" {
+		return column
+	}
+
+	return field
+}
+
+// readImportRecords возвращает столбцы файла и записи в виде "столбец - значение".
+// CSV должен начинаться со строки заголовков, JSON - быть массивом объектов.
+func readImportRecords(format string, reader io.Reader) ([]string, []map[string]string, error) {
+	if format == JSONImportFormat {
+		return readJSONRecords(reader)
+	}
+
+	return readCSVRecords(reader)
+}
+
+func readCSVRecords(reader io.Reader) ([]string, []map[string]string, error) {
+	csvReader := csv.NewReader(reader)
+
+	columns, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read csv header: %w", err)
+	}
+	for j := range columns {
+		columns[j] = strings.TrimSpace(strings.TrimPrefix(columns[j], "\ufeff"))
+	}
+
+	records := make([]map[string]string, 0)
+	for {
+		values, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		} else if readErr != nil {
+			return nil, nil, fmt.Errorf("cannot read csv: %w", readErr)
+		}
+
+		record := make(map[string]string, len(columns))
+		for j, column := range columns {
+			record[column] = values[j]
+		}
+		records = append(records, record)
+	}
+
+	return columns, records, nil
+}
+
+func readJSONRecords(reader io.Reader) ([]string, []map[string]string, error) {
+	var objects []map[string]any
+	if err := json.NewDecoder(reader).Decode(&objects); err != nil {
+		return nil, nil, fmt.Errorf("json must be an array of objects: %w", err)
+	}
+
+	columns := make([]string, 0)
+	records := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		record := make(map[string]string, len(object))
+		for column, value := range object {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+			switch v := value.(type) {
+			case nil:
+			case string:
+				record[column] = v
+			default:
+				record[column] = fmt.Sprint(v)
+			}
+		}
+		records = append(records, record)
+	}
+	slices.Sort(columns)
+
+	return columns, records, nil
+}
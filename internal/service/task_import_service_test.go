@@ -0,0 +1,147 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const importCSV = `Summary,Details,Prio,Assignee,State
+Release,Publish 1.2,2,user,IN_PROGRESS
+Docs,"Update, then review",3,user,
+`
+
+var importMapping = map[string]string{
+	"title":       "Summary",
+	"description": "Details",
+	"priority":    "Prio",
+	"userLogin":   "Assignee",
+	"status":      "State",
+}
+
+func TestTaskImportService_Import_TasksCreated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	importService := NewTaskImportService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl))
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(&repository.User{ID: 2, Login: "user"}, nil)
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) (int, error) {
+			require.Equal(t, "Release", task.Title)
+			require.Equal(t, 2, task.Priority)
+			require.Equal(t, constant.InProgressTaskStatus, task.Status)
+			require.Equal(t, 2, task.UserID)
+			return 10, nil
+		})
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) (int, error) {
+			require.Equal(t, "Update, then review", task.Description)
+			require.Equal(t, constant.OpenTaskStatus, task.Status)
+			return 11, nil
+		})
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.TaskCreatedEvent, event.Event)
+			return nil
+		}).Times(2)
+
+	report, err := importService.Import(ctx, nil, CSVImportFormat, strings.NewReader(importCSV), importMapping, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Summary", "Details", "Prio", "Assignee", "State"}, report.Columns)
+	require.Equal(t, 2, report.Rows)
+	require.Equal(t, 2, report.Imported)
+	require.Empty(t, report.Errors)
+}
+
+func TestTaskImportService_Import_DryRun(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	importService := NewTaskImportService(nil, userRepo, nil, nil)
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(&repository.User{ID: 2, Login: "user"}, nil)
+
+	report, err := importService.Import(ctx, nil, CSVImportFormat, strings.NewReader(importCSV), importMapping, true)
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 2, report.Rows)
+	require.Zero(t, report.Imported)
+	require.Empty(t, report.Errors)
+}
+
+func TestTaskImportService_Import_RowErrorsReported(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	importService := NewTaskImportService(nil, userRepo, nil, nil)
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "ghost").Return(nil, pgx.ErrNoRows)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(&repository.User{ID: 2, Login: "user"}, nil)
+
+	tasksJSON := `[
+		{"title": "Release", "description": "Publish", "priority": 2, "userLogin": "ghost"},
+		{"title": "", "description": "Publish", "priority": 2, "userLogin": "user"},
+		{"title": "Docs", "description": "Write", "priority": "high", "userLogin": "user"},
+		{"title": "Docs", "description": "Write", "priority": 5, "userLogin": "user"},
+		{"title": "Docs", "description": "Write", "priority": 1, "userLogin": "user", "status": "CLOSED"},
+		{"title": "Docs", "description": "Write", "priority": 1, "userLogin": "user"}
+	]`
+
+	report, err := importService.Import(ctx, nil, JSONImportFormat, strings.NewReader(tasksJSON), nil, false)
+	require.NoError(t, err)
+	require.Equal(t, 6, report.Rows)
+	require.Zero(t, report.Imported)
+	require.Equal(t, []TaskImportError{
+		{Row: 1, Field: "userLogin", Error: `user "ghost" not found`},
+		{Row: 2, Error: "title is required"},
+		{Row: 3, Field: "priority", Error: "priority is not a number"},
+		{Row: 4, Error: "priority must be from 1 to 4"},
+		{Row: 5, Field: "status", Error: `unknown status "CLOSED"`},
+	}, report.Errors)
+}
+
+func TestTaskImportService_Import_ColumnNotFound(t *testing.T) {
+	ctx := context.Background()
+	importService := NewTaskImportService(nil, nil, nil, nil)
+
+	report, err := importService.Import(ctx, nil, CSVImportFormat, strings.NewReader(importCSV), nil, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Summary", "Details", "Prio", "Assignee", "State"}, report.Columns)
+	require.Len(t, report.Errors, 4)
+	require.Equal(t, TaskImportError{Field: "title", Error: `column "title" not found`}, report.Errors[0])
+}
+
+func TestTaskImportService_Import_BadRequest(t *testing.T) {
+	ctx := context.Background()
+	importService := NewTaskImportService(nil, nil, nil, nil)
+
+	_, err := importService.Import(ctx, nil, "xlsx", strings.NewReader(""), nil, true)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+
+	_, err = importService.Import(ctx, nil, CSVImportFormat, strings.NewReader(importCSV),
+		map[string]string{"owner": "Assignee"}, true)
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
+
+func TestTaskImportService_Import_MalformedFile(t *testing.T) {
+	ctx := context.Background()
+	importService := NewTaskImportService(nil, nil, nil, nil)
+
+	report, err := importService.Import(ctx, nil, JSONImportFormat, strings.NewReader(`{"title":"Release"}`), nil, true)
+	require.NoError(t, err)
+	require.Len(t, report.Errors, 1)
+	require.Contains(t, report.Errors[0].Error, "json must be an array of objects")
+}
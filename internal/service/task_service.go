@@ -2,34 +2,62 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/errs"
 	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/tracing"
 )
 
 type ITaskService interface {
 	GetTaskRepository() repository.ITaskRepo
-	Create(ctx context.Context, priority int, title, description, userLogin string) (int, error)
+	Create(ctx context.Context,
+		actor *repository.User,
+		priority int,
+		title, description, userLogin string,
+	) (int, error)
 	Update(ctx context.Context,
+		actor *repository.User,
 		title, description, status string,
-		priority, ID int,
+		priority, ID, version int,
 	) error
+	SetDueDate(ctx context.Context, actor *repository.User, ID int, dueAt *time.Time) error
+	Delete(ctx context.Context, actor *repository.User, ID int) error
+	GetByID(ctx context.Context, user *repository.User, ID int) (*repository.Task, error)
 	GetAllByUser(ctx context.Context, user *repository.User) ([]repository.TaskWithLogin, error)
+	GetAllByFilter(ctx context.Context,
+		user *repository.User,
+		filter *repository.TaskFilter,
+	) ([]repository.TaskWithLogin, error)
 	GetByStatus(ctx context.Context, status string) ([]repository.Task, error)
 	GetByPriority(ctx context.Context, priority int) ([]repository.Task, error)
 }
 
 type TaskService struct {
-	TaskRepository repository.ITaskRepo
-	userRepository repository.IUserRepo
+	TaskRepository    repository.ITaskRepo
+	userRepository    repository.IUserRepo
+	outboxRepository  repository.IOutboxRepo
+	transactor        repository.ITransactor
+	attachmentService IAttachmentService
 }
 
-func NewTaskService(taskRepository repository.ITaskRepo, userRepository repository.IUserRepo) *TaskService {
+func NewTaskService(
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	outboxRepository repository.IOutboxRepo,
+	transactor repository.ITransactor,
+	attachmentService IAttachmentService,
+) *TaskService {
 	return &TaskService{
-		TaskRepository: taskRepository,
-		userRepository: userRepository,
+		TaskRepository:    taskRepository,
+		userRepository:    userRepository,
+		outboxRepository:  outboxRepository,
+		transactor:        transactor,
+		attachmentService: attachmentService,
 	}
 }
 
@@ -38,6 +66,9 @@ func (t *TaskService) GetTaskRepository() repository.ITaskRepo {
 }
 
 func (t *TaskService) GetAllByUser(ctx context.Context, user *repository.User) ([]repository.TaskWithLogin, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllByUser")
+	defer span.End()
+
 	userRole := user.Role
 	if userRole == constant.AdminRole {
 		return t.TaskRepository.GetTasksWithLogin(ctx)
@@ -46,8 +77,42 @@ func (t *TaskService) GetAllByUser(ctx context.Context, user *repository.User) (
 	return t.TaskRepository.GetTasksWithLoginByUserID(ctx, user.ID)
 }
 
-func (t *TaskService) Create(ctx context.Context, priority int, title, description, userLogin string) (int, error) {
-	if title == "" || description == "" || userLogin == "" || priority < 1 || priority > 4 {
+// GetAllByFilter возвращает задачи по фильтру с логинами исполнителей. Видимость та же, что у GetAllByUser:
+// ADMIN видит все задачи, остальные - только свои.
+func (t *TaskService) GetAllByFilter(ctx context.Context,
+	user *repository.User,
+	filter *repository.TaskFilter,
+) ([]repository.TaskWithLogin, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllByFilter")
+	defer span.End()
+
+	userFilter := *filter
+	if user.Role != constant.AdminRole {
+		userFilter.UserID = user.ID
+	}
+
+	res := make([]repository.TaskWithLogin, 0)
+	err := t.TaskRepository.IterateWithLogin(ctx, &userFilter, func(task *repository.TaskWithLogin) error {
+		res = append(res, *task)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Create создаёт задачу и в той же транзакции сохраняет событие task.created. actor - автор изменения, может быть nil.
+func (t *TaskService) Create(ctx context.Context,
+	actor *repository.User,
+	priority int,
+	title, description, userLogin string,
+) (int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Create")
+	defer span.End()
+
+	if err := validateNewTask(title, description, userLogin, priority); err != nil {
 		return 0, errs.BadReqErr{}
 	}
 
@@ -67,13 +132,33 @@ func (t *TaskService) Create(ctx context.Context, priority int, title, descripti
 		UpdatedAt:   now,
 	}
 
-	return t.TaskRepository.Create(ctx, taskForCreate)
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		taskID, createErr := t.TaskRepository.Create(ctx, taskForCreate)
+		if createErr != nil {
+			return createErr
+		}
+		taskForCreate.ID = taskID
+
+		return t.addEvent(ctx, constant.TaskCreatedEvent, &TaskEvent{Task: *taskForCreate, ActorID: actorID(actor)})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return taskForCreate.ID, nil
 }
 
+// Update обновляет задачу и в той же транзакции сохраняет событие task.updated.
+// actor - автор изменения, может быть nil. version - версия задачи, которую видел автор изменения.
+// Если задачу успели изменить, возвращает errs.ConflictErr.
 func (t *TaskService) Update(ctx context.Context,
+	actor *repository.User,
 	title, description, status string,
-	priority, id int,
+	priority, id, version int,
 ) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Update")
+	defer span.End()
+
 	if title == "" || description == "" || status == "" || priority < 1 || priority > 4 {
 		return errs.BadReqErr{}
 	}
@@ -83,16 +168,110 @@ func (t *TaskService) Update(ctx context.Context,
 		return errs.BadReqErr{}
 	}
 
+	if version != taskForUpdate.Version {
+		return errs.ConflictErr{}
+	}
+
+	previousStatus := taskForUpdate.Status
 	taskForUpdate.ID = id
 	taskForUpdate.Title = title
 	taskForUpdate.Description = description
 	taskForUpdate.Priority = priority
 	taskForUpdate.Status = status
 
-	return t.TaskRepository.Update(ctx, taskForUpdate)
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if updateErr := t.TaskRepository.Update(ctx, taskForUpdate); updateErr != nil {
+			return updateErr
+		}
+
+		return t.addEvent(ctx, constant.TaskUpdatedEvent, &TaskEvent{
+			Task:           *taskForUpdate,
+			ActorID:        actorID(actor),
+			PreviousStatus: previousStatus,
+		})
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return errs.ConflictErr{}
+	}
+
+	return err
+}
+
+// SetDueDate задаёт срок выполнения задачи, nil - снимает срок. Срок хранится в UTC.
+// Изменение сохраняется в той же транзакции, что и событие task.updated.
+func (t *TaskService) SetDueDate(ctx context.Context, actor *repository.User, id int, dueAt *time.Time) error {
+	ctx, span := tracing.Start(ctx, "TaskService.SetDueDate")
+	defer span.End()
+
+	task, err := getAccessibleTask(ctx, t.TaskRepository, actor, id)
+	if err != nil {
+		return err
+	}
+
+	if dueAt != nil {
+		utcDueAt := dueAt.UTC().Truncate(time.Second)
+		dueAt = &utcDueAt
+	}
+	task.DueAt = dueAt
+
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if updateErr := t.TaskRepository.Update(ctx, task); updateErr != nil {
+			return updateErr
+		}
+
+		return t.addEvent(ctx, constant.TaskUpdatedEvent, &TaskEvent{
+			Task:           *task,
+			ActorID:        actorID(actor),
+			PreviousStatus: task.Status,
+		})
+	})
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return errs.ConflictErr{}
+	}
+
+	return err
+}
+
+// Delete удаляет задачу и в той же транзакции сохраняет событие task.deleted. actor - автор изменения, может быть nil.
+// Записи о вложениях удаляются каскадно вместе с задачей, а их файлы - из хранилища после фиксации транзакции.
+func (t *TaskService) Delete(ctx context.Context, actor *repository.User, id int) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Delete")
+	defer span.End()
+
+	taskForDelete, err := t.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		return errs.NotFoundErr{}
+	}
+
+	attachments, err := t.attachmentService.GetFiles(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if deleteErr := t.TaskRepository.DeleteByID(ctx, id); deleteErr != nil {
+			return deleteErr
+		}
+
+		return t.addEvent(ctx, constant.TaskDeletedEvent, &TaskEvent{Task: *taskForDelete, ActorID: actorID(actor)})
+	})
+	if err != nil {
+		return err
+	}
+	t.attachmentService.DeleteFiles(ctx, attachments)
+
+	return nil
+}
+
+// GetByID возвращает задачу, если пользователь может её открыть: свою, а ADMIN - любую.
+func (t *TaskService) GetByID(ctx context.Context, user *repository.User, id int) (*repository.Task, error) {
+	return getAccessibleTask(ctx, t.TaskRepository, user, id)
 }
 
 func (t *TaskService) GetByStatus(ctx context.Context, status string) ([]repository.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetByStatus")
+	defer span.End()
+
 	if status == "" {
 		return nil, errs.BadReqErr{}
 	}
@@ -111,9 +290,78 @@ func (t *TaskService) GetByStatus(ctx context.Context, status string) ([]reposit
 }
 
 func (t *TaskService) GetByPriority(ctx context.Context, priority int) ([]repository.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetByPriority")
+	defer span.End()
+
 	if priority < 1 || priority > 4 {
 		return nil, errs.BadReqErr{}
 	}
 
 	return t.TaskRepository.GetByPriority(ctx, priority)
 }
+
+func (t *TaskService) addEvent(ctx context.Context, event string, taskEvent *TaskEvent) error {
+	return addTaskEvent(ctx, t.outboxRepository, event, taskEvent)
+}
+
+// addTaskEvent сохраняет событие задачи в outbox. Вызывается внутри транзакции, изменяющей задачу.
+func addTaskEvent(ctx context.Context,
+	outboxRepository repository.IOutboxRepo,
+	event string,
+	taskEvent *TaskEvent,
+) error {
+	payload, err := json.Marshal(taskEvent)
+	if err != nil {
+		return err
+	}
+
+	return outboxRepository.Add(ctx, &repository.OutboxEvent{
+		AggregateID: taskEvent.Task.ID,
+		Event:       event,
+		Payload:     string(payload),
+	})
+}
+
+// validateNewTask проверяет поля новой задачи. Те же правила применяются при создании задачи и при импорте.
+func validateNewTask(title, description, userLogin string, priority int) error {
+	switch {
+	case title == "":
+		return errors.New("title is required")
+	case description == "":
+		return errors.New("description is required")
+	case userLogin == "":
+		return errors.New("user login is required")
+	case priority < constant.Blocker || priority > constant.Low:
+		return errors.New("priority must be from 1 to 4")
+	default:
+		return nil
+	}
+}
+
+func actorID(actor *repository.User) int {
+	if actor == nil {
+		return 0
+	}
+
+	return actor.ID
+}
+
+// getAccessibleTask возвращает задачу, если пользователь может с ней работать: ADMIN - с любой, остальные - со своей.
+func getAccessibleTask(ctx context.Context,
+	taskRepository repository.ITaskRepo,
+	user *repository.User,
+	taskID int,
+) (*repository.Task, error) {
+	task, err := taskRepository.GetByID(ctx, taskID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if user.Role != constant.AdminRole && task.UserID != user.ID {
+		return nil, errs.ForbiddenErr{}
+	}
+
+	return task, nil
+}
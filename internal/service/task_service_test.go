@@ -4,13 +4,16 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/errs"
 	"github.com/romakorinenko/task-manager/internal/repository"
 	"testing"
+	"time"
 
 	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	mockStorage "github.com/romakorinenko/task-manager/internal/storage/mocks"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -18,7 +21,7 @@ import (
 func TestTaskService_GetTaskRepository(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	taskRepository := taskService.GetTaskRepository()
 
@@ -30,21 +33,51 @@ func TestTaskService_Create_TaskCreated(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	taskService := NewTaskService(taskRepo, userRepo)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := NewTaskService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl), nil)
 
 	userRepo.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(&repository.User{ID: 1}, nil)
 	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
-
-	task, err := taskService.Create(background, 1, "Title", "Desc", "user")
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, 1, event.AggregateID)
+			require.Equal(t, constant.TaskCreatedEvent, event.Event)
+
+			var taskEvent TaskEvent
+			require.NoError(t, json.Unmarshal([]byte(event.Payload), &taskEvent))
+			require.Equal(t, 1, taskEvent.Task.ID)
+			require.Equal(t, 1, taskEvent.Task.UserID)
+			require.Equal(t, 2, taskEvent.ActorID)
+			return nil
+		})
+
+	task, err := taskService.Create(background, &repository.User{ID: 2}, 1, "Title", "Desc", "user")
 	require.NoError(t, err)
 	require.Equal(t, 1, task)
 }
 
+func TestTaskService_Create_OutboxErr(t *testing.T) {
+	background := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := NewTaskService(taskRepo, userRepo, outboxRepo, newTransactorMock(ctrl), nil)
+
+	userRepo.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(&repository.User{ID: 1}, nil)
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(errors.New(""))
+
+	task, err := taskService.Create(background, nil, 1, "Title", "Desc", "user")
+	require.Error(t, err)
+	require.Equal(t, 0, task)
+}
+
 func TestTaskService_Create_PriorityInvalid(t *testing.T) {
 	ctx := context.Background()
-	taskService := NewTaskService(nil, nil)
+	taskService := NewTaskService(nil, nil, nil, nil, nil)
 
-	taskID, err := taskService.Create(ctx, 0, "Title", "Desc", "user")
+	taskID, err := taskService.Create(ctx, nil, 0, "Title", "Desc", "user")
 	require.Equal(t, errs.BadReqErr{}, err)
 	require.Equal(t, 0, taskID)
 }
@@ -53,11 +86,11 @@ func TestTaskService_Create_UserLoginIsNotExists(t *testing.T) {
 	background := context.Background()
 	ctrl := gomock.NewController(t)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	taskService := NewTaskService(nil, userRepo)
+	taskService := NewTaskService(nil, userRepo, nil, nil, nil)
 
 	userRepo.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(nil, errors.New(""))
 
-	taskID, err := taskService.Create(background, 1, "Title", "Desc", "user")
+	taskID, err := taskService.Create(background, nil, 1, "Title", "Desc", "user")
 	require.Equal(t, errs.BadReqErr{}, err)
 	require.Equal(t, 0, taskID)
 }
@@ -67,12 +100,12 @@ func TestTaskService_Create_InternalErr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
 	userRepo := mockRepository.NewMockIUserRepo(ctrl)
-	taskService := NewTaskService(taskRepo, userRepo)
+	taskService := NewTaskService(taskRepo, userRepo, nil, newTransactorMock(ctrl), nil)
 
 	userRepo.EXPECT().GetByLogin(gomock.Any(), gomock.Any()).Return(&repository.User{ID: 1}, nil)
 	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(0, errors.New(""))
 
-	task, err := taskService.Create(background, 1, "Title", "Desc", "user")
+	task, err := taskService.Create(background, nil, 1, "Title", "Desc", "user")
 	require.Error(t, err)
 	require.Equal(t, 0, task)
 }
@@ -81,7 +114,7 @@ func TestTaskService_GetAllByUser_UserTasksReturned(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	user := &repository.User{ID: 1, Role: constant.UserRole}
 	taskRepo.EXPECT().GetTasksWithLoginByUserID(gomock.Any(), gomock.Any()).Return([]repository.TaskWithLogin{}, nil)
@@ -95,7 +128,7 @@ func TestTaskService_GetAllByUser_UsersTaskReceivingError(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	user := &repository.User{ID: 1, Role: constant.UserRole}
 	taskRepo.EXPECT().GetTasksWithLoginByUserID(gomock.Any(), gomock.Any()).Return(nil, errors.New(""))
@@ -109,7 +142,7 @@ func TestTaskService_GetAllByUser_AllTasksReturned(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	user := &repository.User{ID: 1, Role: constant.AdminRole}
 	taskRepo.EXPECT().GetTasksWithLogin(gomock.Any()).Return([]repository.TaskWithLogin{}, nil)
@@ -123,7 +156,7 @@ func TestTaskService_GetAllByUser_AllTaskReceivingError(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	user := &repository.User{ID: 1, Role: constant.AdminRole}
 	taskRepo.EXPECT().GetTasksWithLogin(gomock.Any()).Return(nil, errors.New(""))
@@ -137,21 +170,33 @@ func TestTaskService_Update_TaskUpdated(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, outboxRepo, newTransactorMock(ctrl), nil)
 
-	user := &repository.Task{}
-	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
+	task := &repository.Task{ID: 1, UserID: 3, Status: constant.OpenTaskStatus}
+	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(task, nil)
 	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
-
-	err := taskService.Update(ctx, "title", "desc", "OPEN", 1, 1)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, 1, event.AggregateID)
+			require.Equal(t, constant.TaskUpdatedEvent, event.Event)
+
+			var taskEvent TaskEvent
+			require.NoError(t, json.Unmarshal([]byte(event.Payload), &taskEvent))
+			require.Equal(t, "DONE", taskEvent.Task.Status)
+			require.Equal(t, constant.OpenTaskStatus, taskEvent.PreviousStatus)
+			return nil
+		})
+
+	err := taskService.Update(ctx, nil, "title", "desc", "DONE", 1, 1, 0)
 	require.NoError(t, err)
 }
 
 func TestTaskService_Update_InvalidDescription(t *testing.T) {
 	ctx := context.Background()
-	taskService := NewTaskService(nil, nil)
+	taskService := NewTaskService(nil, nil, nil, nil, nil)
 
-	err := taskService.Update(ctx, "title", "", "OPEN", 1, 1)
+	err := taskService.Update(ctx, nil, "title", "", "OPEN", 1, 1, 0)
 	require.Error(t, err)
 }
 
@@ -159,11 +204,11 @@ func TestTaskService_Update_TaskNotFound(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, errors.New(""))
 
-	err := taskService.Update(ctx, "title", "desc", "OPEN", 1, 1)
+	err := taskService.Update(ctx, nil, "title", "desc", "OPEN", 1, 1, 0)
 	require.Error(t, err)
 }
 
@@ -171,21 +216,50 @@ func TestTaskService_Update_InternalErr(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, newTransactorMock(ctrl), nil)
 
 	user := &repository.Task{}
 	taskRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(user, nil)
 	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New(""))
 
-	err := taskService.Update(ctx, "title", "desc", "OPEN", 1, 1)
+	err := taskService.Update(ctx, nil, "title", "desc", "OPEN", 1, 1, 0)
 	require.Error(t, err)
 }
 
+func TestTaskService_Update_StaleVersion(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, Version: 3}, nil)
+
+	err := taskService.Update(ctx, nil, "title", "desc", "OPEN", 1, 1, 2)
+	require.ErrorIs(t, err, errs.ConflictErr{})
+}
+
+func TestTaskService_Update_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, nil, newTransactorMock(ctrl), nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, Version: 3}, nil)
+	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) error {
+			require.Equal(t, 3, task.Version)
+			return repository.ErrVersionConflict
+		})
+
+	err := taskService.Update(ctx, nil, "title", "desc", "OPEN", 1, 1, 3)
+	require.ErrorIs(t, err, errs.ConflictErr{})
+}
+
 func TestTaskService_GetByStatus_TasksReturned(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	taskRepo.EXPECT().GetByStatus(gomock.Any(), gomock.Any()).Return([]repository.Task{}, nil)
 
@@ -196,7 +270,7 @@ func TestTaskService_GetByStatus_TasksReturned(t *testing.T) {
 
 func TestTaskService_GetByStatus_StatusIsEmpty(t *testing.T) {
 	ctx := context.Background()
-	taskService := NewTaskService(nil, nil)
+	taskService := NewTaskService(nil, nil, nil, nil, nil)
 
 	tasks, err := taskService.GetByStatus(ctx, "")
 	require.Error(t, err)
@@ -205,7 +279,7 @@ func TestTaskService_GetByStatus_StatusIsEmpty(t *testing.T) {
 
 func TestTaskService_GetByStatus_WrongStatus(t *testing.T) {
 	ctx := context.Background()
-	taskService := NewTaskService(nil, nil)
+	taskService := NewTaskService(nil, nil, nil, nil, nil)
 
 	tasks, err := taskService.GetByStatus(ctx, "PPPPP")
 	require.Error(t, err)
@@ -216,7 +290,7 @@ func TestTaskService_GetByPriority_TasksReturned(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
 	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
-	taskService := NewTaskService(taskRepo, nil)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
 
 	taskRepo.EXPECT().GetByPriority(gomock.Any(), gomock.Any()).Return([]repository.Task{}, nil)
 
@@ -227,9 +301,92 @@ func TestTaskService_GetByPriority_TasksReturned(t *testing.T) {
 
 func TestTaskService_GetByPriority_WrongStatus(t *testing.T) {
 	ctx := context.Background()
-	taskService := NewTaskService(nil, nil)
+	taskService := NewTaskService(nil, nil, nil, nil, nil)
 
 	tasks, err := taskService.GetByPriority(ctx, 0)
 	require.Error(t, err)
 	require.Nil(t, tasks)
 }
+
+func TestTaskService_Delete_TaskDeleted(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	attachmentRepo := mockRepository.NewMockIAttachmentRepo(ctrl)
+	attachmentStorage := mockStorage.NewMockIStorage(ctrl)
+	attachmentService := NewAttachmentService(attachmentRepo, taskRepo, attachmentStorage, nil)
+	taskService := NewTaskService(taskRepo, nil, outboxRepo, newTransactorMock(ctrl), attachmentService)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, Title: "title"}, nil)
+	attachmentRepo.EXPECT().GetByTaskID(gomock.Any(), 1).
+		Return([]repository.Attachment{{ID: 3, TaskID: 1, StorageKey: "tasks/1/3"}}, nil)
+	taskRepo.EXPECT().DeleteByID(gomock.Any(), 1).Return(nil)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, 1, event.AggregateID)
+			require.Equal(t, constant.TaskDeletedEvent, event.Event)
+			return nil
+		})
+
+	attachmentStorage.EXPECT().Delete(gomock.Any(), "tasks/1/3").Return(nil)
+
+	err := taskService.Delete(ctx, nil, 1)
+	require.NoError(t, err)
+}
+
+func TestTaskService_Delete_TaskNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errors.New(""))
+
+	err := taskService.Delete(ctx, nil, 1)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+// newTransactorMock возвращает транзакции, которые просто выполняют переданную функцию.
+func TestTaskService_SetDueDate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, outboxRepo, newTransactorMock(ctrl), nil)
+
+	dueAt := time.Date(2026, 10, 20, 18, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 2, Version: 3}, nil)
+	taskRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) error {
+			require.Equal(t, time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC), *task.DueAt)
+			require.Equal(t, 3, task.Version)
+			return nil
+		})
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := taskService.SetDueDate(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 1, &dueAt)
+	require.NoError(t, err)
+}
+
+func TestTaskService_SetDueDate_Forbidden(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	taskService := NewTaskService(taskRepo, nil, nil, nil, nil)
+
+	taskRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Task{ID: 1, UserID: 3}, nil)
+
+	err := taskService.SetDueDate(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 1, nil)
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+}
+
+func newTransactorMock(ctrl *gomock.Controller) *mockRepository.MockITransactor {
+	transactor := mockRepository.NewMockITransactor(ctrl)
+	transactor.EXPECT().WithinTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+	return transactor
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/live"
+	"github.com/romakorinenko/task-manager/internal/markdown"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+type ITaskStreamService interface {
+	Subscribe(ctx context.Context, user *repository.User, lastEventID int) (<-chan live.Event, error)
+}
+
+// TaskStreamService передаёт изменения задач открытым страницам. События приходят из outbox, поэтому
+// пропущенные при переподключении события восстанавливаются оттуда же по Last-Event-ID.
+type TaskStreamService struct {
+	broker           *live.Broker
+	outboxRepository repository.IOutboxRepo
+	userRepository   repository.IUserRepo
+	cfg              *config.Stream
+}
+
+func NewTaskStreamService(
+	broker *live.Broker,
+	outboxRepository repository.IOutboxRepo,
+	userRepository repository.IUserRepo,
+	cfg *config.Stream,
+) *TaskStreamService {
+	return &TaskStreamService{
+		broker:           broker,
+		outboxRepository: outboxRepository,
+		userRepository:   userRepository,
+		cfg:              cfg,
+	}
+}
+
+func (s *TaskStreamService) Name() string {
+	return "task-stream"
+}
+
+// Handle рассылает событие outbox подписчикам брокера.
+func (s *TaskStreamService) Handle(ctx context.Context, event *repository.OutboxEvent) error {
+	liveEvent, err := s.toLiveEvent(ctx, event, make(map[int]string))
+	if err != nil {
+		return err
+	}
+	s.broker.Publish(*liveEvent)
+
+	return nil
+}
+
+// Subscribe возвращает события задач, которые видит пользователь: ADMIN - все, остальные - только свои
+// и переназначенные с них другим.
+// Если lastEventID больше нуля, сначала отдаются события после него. Если пропущено больше replayLimit событий,
+// вместо них отдаётся событие reset, по которому страница перезагружается целиком.
+// Канал закрывается после отмены ctx или отключения подписчика брокером.
+func (s *TaskStreamService) Subscribe(ctx context.Context,
+	user *repository.User,
+	lastEventID int,
+) (<-chan live.Event, error) {
+	// подписываемся до чтения истории, чтобы не потерять события, опубликованные между ними.
+	subscription := s.broker.Subscribe()
+
+	missed, err := s.getMissed(ctx, lastEventID)
+	if err != nil {
+		subscription.Close()
+		return nil, err
+	}
+
+	res := make(chan live.Event)
+	go func() {
+		defer close(res)
+		defer subscription.Close()
+
+		replayed := make(map[int]bool, len(missed))
+		send := func(event live.Event) bool {
+			if replayed[event.ID] {
+				return true
+			}
+			event, ok := eventForUser(user, &event)
+			if !ok {
+				return true
+			}
+
+			select {
+			case res <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range missed {
+			if !send(event) {
+				return
+			}
+			replayed[event.ID] = true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscription.Events():
+				if !ok || !send(event) {
+					return
+				}
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+func (s *TaskStreamService) getMissed(ctx context.Context, lastEventID int) ([]live.Event, error) {
+	if lastEventID <= 0 {
+		return nil, nil
+	}
+
+	events, err := s.outboxRepository.GetAfter(ctx, lastEventID, s.cfg.ReplayLimit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > s.cfg.ReplayLimit {
+		return []live.Event{{Type: live.ResetEvent}}, nil
+	}
+
+	logins := make(map[int]string)
+	res := make([]live.Event, 0, len(events))
+	for i := range events {
+		liveEvent, convertErr := s.toLiveEvent(ctx, &events[i], logins)
+		if convertErr != nil {
+			return nil, convertErr
+		}
+		res = append(res, *liveEvent)
+	}
+
+	return res, nil
+}
+
+// toLiveEvent дополняет задачу из события данными для строки таблицы задач. logins - кэш логинов по ID.
+func (s *TaskStreamService) toLiveEvent(ctx context.Context,
+	event *repository.OutboxEvent,
+	logins map[int]string,
+) (*live.Event, error) {
+	var taskEvent TaskEvent
+	if err := json.Unmarshal([]byte(event.Payload), &taskEvent); err != nil {
+		return nil, err
+	}
+
+	task := taskEvent.Task
+	task.DescriptionText = markdown.ToText(task.Description)
+
+	login, ok := logins[task.UserID]
+	if !ok {
+		user, err := s.userRepository.GetByID(ctx, task.UserID)
+		if err != nil {
+			return nil, err
+		}
+		login = user.Login
+		logins[task.UserID] = login
+	}
+
+	return &live.Event{
+		ID:             event.ID,
+		Type:           event.Event,
+		Task:           task,
+		UserLogin:      login,
+		PreviousUserID: taskEvent.PreviousUserID,
+	}, nil
+}
+
+// eventForUser возвращает событие в том виде, в котором его должен получить пользователь, и false, если
+// событие ему не положено. Прежний исполнитель переназначенной задачи получает task.deleted только с ID задачи,
+// чтобы она пропала из его списка.
+func eventForUser(user *repository.User, event *live.Event) (live.Event, bool) {
+	switch {
+	case event.Type == live.ResetEvent || canSeeTask(user, &event.Task):
+		return *event, true
+	case event.PreviousUserID == user.ID:
+		return live.Event{ID: event.ID, Type: constant.TaskDeletedEvent, Task: repository.Task{ID: event.Task.ID}}, true
+	default:
+		return live.Event{}, false
+	}
+}
+
+func canSeeTask(user *repository.User, task *repository.Task) bool {
+	return user.Role == constant.AdminRole || task.UserID == user.ID
+}
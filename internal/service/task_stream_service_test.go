@@ -0,0 +1,133 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/live"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var streamConfig = &config.Stream{BufferSize: 10, ReplayLimit: 2}
+
+func TestTaskStreamService_Subscribe_MissedEventsReplayed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	broker := live.NewBroker(streamConfig.BufferSize)
+	streamService := NewTaskStreamService(broker, outboxRepo, userRepo, streamConfig)
+
+	otherEvent := newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{Task: repository.Task{ID: 7, UserID: 2}})
+	otherEvent.ID = 4
+	ownEvent := newTaskOutboxEvent(t, constant.TaskCreatedEvent, &TaskEvent{
+		Task: repository.Task{ID: 5, UserID: 1, Description: "**Release**"},
+	})
+	ownEvent.ID = 5
+	outboxRepo.EXPECT().GetAfter(gomock.Any(), 3, 3).Return([]repository.OutboxEvent{*otherEvent, *ownEvent}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.User{ID: 2, Login: "other"}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.User{ID: 1, Login: "user"}, nil)
+
+	events, err := streamService.Subscribe(ctx, &repository.User{ID: 1, Role: constant.UserRole}, 3)
+	require.NoError(t, err)
+
+	event := <-events
+	require.Equal(t, 5, event.ID)
+	require.Equal(t, constant.TaskCreatedEvent, event.Type)
+	require.Equal(t, 5, event.Task.ID)
+	require.Equal(t, "Release", event.Task.DescriptionText)
+	require.Equal(t, "user", event.UserLogin)
+
+	// событие, уже отданное из истории, не дублируется, когда его публикует outbox.
+	broker.Publish(event)
+	broker.Publish(live.Event{ID: 6, Task: repository.Task{ID: 5, UserID: 1}})
+	require.Equal(t, 6, (<-events).ID)
+
+	cancel()
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestTaskStreamService_Subscribe_TooManyMissedEvents(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	broker := live.NewBroker(streamConfig.BufferSize)
+	streamService := NewTaskStreamService(broker, outboxRepo, nil, streamConfig)
+
+	outboxRepo.EXPECT().GetAfter(gomock.Any(), 3, 3).
+		Return([]repository.OutboxEvent{{ID: 4}, {ID: 5}, {ID: 6}}, nil)
+
+	events, err := streamService.Subscribe(ctx, &repository.User{ID: 1, Role: constant.UserRole}, 3)
+	require.NoError(t, err)
+
+	require.Equal(t, live.ResetEvent, (<-events).Type)
+	broker.Close()
+	_, ok := <-events
+	require.False(t, ok)
+}
+
+func TestTaskStreamService_Handle_VisibleEventsPublished(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	broker := live.NewBroker(streamConfig.BufferSize)
+	streamService := NewTaskStreamService(broker, nil, userRepo, streamConfig)
+
+	userEvents, err := streamService.Subscribe(ctx, &repository.User{ID: 1, Role: constant.UserRole}, 0)
+	require.NoError(t, err)
+	adminEvents, err := streamService.Subscribe(ctx, &repository.User{ID: 3, Role: constant.AdminRole}, 0)
+	require.NoError(t, err)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(&repository.User{Login: "user"}, nil).Times(2)
+	otherEvent := newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{Task: repository.Task{ID: 7, UserID: 2}})
+	otherEvent.ID = 1
+	ownEvent := newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{Task: repository.Task{ID: 5, UserID: 1}})
+	ownEvent.ID = 2
+	require.NoError(t, streamService.Handle(ctx, otherEvent))
+	require.NoError(t, streamService.Handle(ctx, ownEvent))
+
+	require.Equal(t, 1, (<-adminEvents).ID)
+	require.Equal(t, 2, (<-adminEvents).ID)
+	require.Equal(t, 2, (<-userEvents).ID)
+
+	broker.Close()
+	_, ok := <-userEvents
+	require.False(t, ok)
+}
+
+func TestTaskStreamService_Subscribe_ReassignedTaskRemovedForPreviousUser(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	broker := live.NewBroker(streamConfig.BufferSize)
+	streamService := NewTaskStreamService(broker, nil, userRepo, streamConfig)
+
+	previousUserEvents, err := streamService.Subscribe(ctx, &repository.User{ID: 1, Role: constant.UserRole}, 0)
+	require.NoError(t, err)
+	newUserEvents, err := streamService.Subscribe(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 0)
+	require.NoError(t, err)
+
+	userRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&repository.User{ID: 2, Login: "other"}, nil)
+	reassignedEvent := newTaskOutboxEvent(t, constant.TaskUpdatedEvent, &TaskEvent{
+		Task:           repository.Task{ID: 5, UserID: 2, Title: "Release"},
+		PreviousUserID: 1,
+	})
+	reassignedEvent.ID = 3
+	require.NoError(t, streamService.Handle(ctx, reassignedEvent))
+
+	require.Equal(t, live.Event{ID: 3, Type: constant.TaskDeletedEvent, Task: repository.Task{ID: 5}},
+		<-previousUserEvents)
+	event := <-newUserEvents
+	require.Equal(t, constant.TaskUpdatedEvent, event.Type)
+	require.Equal(t, "Release", event.Task.Title)
+
+	broker.Close()
+}
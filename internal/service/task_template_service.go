@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+)
+
+const maxTemplateNameLen = 128
+
+// templatePlaceholderRegexp находит подстановки шаблона вида {{name}}, пробелы внутри скобок допускаются.
+var templatePlaceholderRegexp = regexp.MustCompile(`\{\{\s*([\p{L}\p{N}_.-]+)\s*\}\}`)
+
+type ITaskTemplateService interface {
+	Create(ctx context.Context, template *repository.TaskTemplate) error
+	Update(ctx context.Context, template *repository.TaskTemplate) error
+	Delete(ctx context.Context, templateID int) error
+	GetVisible(ctx context.Context, user *repository.User) ([]repository.TaskTemplate, error)
+	Get(ctx context.Context, user *repository.User, templateID int) (*repository.TaskTemplate, error)
+	CreateTask(ctx context.Context,
+		actor *repository.User,
+		templateID int,
+		userLogin string,
+		priority int,
+		values map[string]string,
+	) (*repository.Task, error)
+}
+
+type TaskTemplateService struct {
+	templateRepository  repository.ITaskTemplateRepo
+	taskRepository      repository.ITaskRepo
+	userRepository      repository.IUserRepo
+	labelRepository     repository.ILabelRepo
+	checklistRepository repository.IChecklistRepo
+	outboxRepository    repository.IOutboxRepo
+	transactor          repository.ITransactor
+}
+
+func NewTaskTemplateService(
+	templateRepository repository.ITaskTemplateRepo,
+	taskRepository repository.ITaskRepo,
+	userRepository repository.IUserRepo,
+	labelRepository repository.ILabelRepo,
+	checklistRepository repository.IChecklistRepo,
+	outboxRepository repository.IOutboxRepo,
+	transactor repository.ITransactor,
+) *TaskTemplateService {
+	return &TaskTemplateService{
+		templateRepository:  templateRepository,
+		taskRepository:      taskRepository,
+		userRepository:      userRepository,
+		labelRepository:     labelRepository,
+		checklistRepository: checklistRepository,
+		outboxRepository:    outboxRepository,
+		transactor:          transactor,
+	}
+}
+
+// Create сохраняет новый шаблон. Пустая видимость означает ALL.
+func (t *TaskTemplateService) Create(ctx context.Context, template *repository.TaskTemplate) error {
+	if err := normalizeTemplate(template); err != nil {
+		return err
+	}
+
+	err := t.templateRepository.Create(ctx, template)
+	if errors.Is(err, repository.ErrTemplateNameTaken) {
+		return errs.TemplateExistsErr{}
+	}
+
+	return err
+}
+
+// Update заменяет все поля шаблона. Задачи, уже созданные по шаблону, не меняются.
+func (t *TaskTemplateService) Update(ctx context.Context, template *repository.TaskTemplate) error {
+	if err := normalizeTemplate(template); err != nil {
+		return err
+	}
+
+	found, err := t.templateRepository.Update(ctx, template)
+	if errors.Is(err, repository.ErrTemplateNameTaken) {
+		return errs.TemplateExistsErr{}
+	} else if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+func (t *TaskTemplateService) Delete(ctx context.Context, templateID int) error {
+	found, err := t.templateRepository.DeleteByID(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+// GetVisible возвращает шаблоны, доступные пользователю: ADMIN видит все, остальные - с видимостью ALL.
+func (t *TaskTemplateService) GetVisible(ctx context.Context,
+	user *repository.User,
+) ([]repository.TaskTemplate, error) {
+	return t.templateRepository.GetByVisibility(ctx, visibleTemplateVisibilities(user))
+}
+
+// Get возвращает шаблон, если он доступен пользователю, иначе errs.NotFoundErr.
+func (t *TaskTemplateService) Get(ctx context.Context,
+	user *repository.User,
+	templateID int,
+) (*repository.TaskTemplate, error) {
+	template, err := t.templateRepository.GetByID(ctx, templateID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(visibleTemplateVisibilities(user), template.Visibility) {
+		return nil, errs.NotFoundErr{}
+	}
+
+	return template, nil
+}
+
+// CreateTask создаёт задачу по шаблону и возвращает её: заполняет подстановки значениями values, добавляет метки
+// и пункты чеклиста шаблона. priority 0 - приоритет из шаблона. USER создаёт задачи только на себя.
+// Задача, метки, чеклист и событие task.created сохраняются в одной транзакции.
+func (t *TaskTemplateService) CreateTask(ctx context.Context,
+	actor *repository.User,
+	templateID int,
+	userLogin string,
+	priority int,
+	values map[string]string,
+) (*repository.Task, error) {
+	template, err := t.Get(ctx, actor, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.Role != constant.AdminRole && userLogin != actor.Login {
+		return nil, errs.ForbiddenErr{}
+	}
+	if priority == 0 {
+		priority = template.Priority
+	}
+
+	if len(missingPlaceholders(template, values)) > 0 {
+		return nil, errs.BadReqErr{}
+	}
+	title := fillPlaceholders(template.Title, values)
+	description := fillPlaceholders(template.Description, values)
+	if err = validateNewTask(title, description, userLogin, priority); err != nil {
+		return nil, errs.BadReqErr{}
+	}
+
+	user, err := t.userRepository.GetByLogin(ctx, userLogin)
+	if err != nil {
+		return nil, errs.BadReqErr{}
+	}
+
+	now := time.Now()
+	task := &repository.Task{
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		UserID:      user.ID,
+		Status:      constant.OpenTaskStatus,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = t.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		taskID, createErr := t.taskRepository.Create(ctx, task)
+		if createErr != nil {
+			return createErr
+		}
+		task.ID = taskID
+
+		for _, label := range template.Labels {
+			if labelErr := t.labelRepository.Add(ctx, taskID, label); labelErr != nil {
+				return labelErr
+			}
+		}
+		for _, itemTitle := range template.Checklist {
+			_, itemErr := t.checklistRepository.Create(ctx, &repository.ChecklistItem{
+				TaskID: taskID,
+				Title:  fillPlaceholders(itemTitle, values),
+			})
+			if itemErr != nil {
+				return itemErr
+			}
+		}
+
+		return addTaskEvent(ctx, t.outboxRepository, constant.TaskCreatedEvent, &TaskEvent{
+			Task:    *task,
+			ActorID: actorID(actor),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// TemplatePlaceholders возвращает имена подстановок шаблона в порядке первого появления:
+// сначала в названии, затем в описании и в пунктах чеклиста.
+func TemplatePlaceholders(template *repository.TaskTemplate) []string {
+	res := make([]string, 0)
+	texts := append([]string{template.Title, template.Description}, template.Checklist...)
+	for _, text := range texts {
+		for _, match := range templatePlaceholderRegexp.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(res, match[1]) {
+				res = append(res, match[1])
+			}
+		}
+	}
+
+	return res
+}
+
+func missingPlaceholders(template *repository.TaskTemplate, values map[string]string) []string {
+	missing := make([]string, 0)
+	for _, placeholder := range TemplatePlaceholders(template) {
+		if strings.TrimSpace(values[placeholder]) == "" {
+			missing = append(missing, placeholder)
+		}
+	}
+
+	return missing
+}
+
+func fillPlaceholders(text string, values map[string]string) string {
+	return templatePlaceholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		return strings.TrimSpace(values[name])
+	})
+}
+
+// normalizeTemplate проверяет поля шаблона и убирает пустые и повторяющиеся метки и пункты чеклиста.
+func normalizeTemplate(template *repository.TaskTemplate) error {
+	template.Name = strings.TrimSpace(template.Name)
+	if template.Visibility == "" {
+		template.Visibility = constant.AllTemplateVisibility
+	}
+
+	switch {
+	case template.Name == "" || utf8.RuneCountInString(template.Name) > maxTemplateNameLen,
+		template.Title == "" || template.Description == "",
+		template.Priority < constant.Blocker || template.Priority > constant.Low,
+		!slices.Contains(constant.TemplateVisibilities, template.Visibility):
+		return errs.BadReqErr{}
+	}
+
+	labels := make([]string, 0, len(template.Labels))
+	for _, label := range template.Labels {
+		label = strings.TrimSpace(label)
+		if utf8.RuneCountInString(label) > maxTaskLabelLen {
+			return errs.BadReqErr{}
+		}
+		if label != "" && !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	template.Labels = labels
+
+	checklist := make([]string, 0, len(template.Checklist))
+	for _, item := range template.Checklist {
+		item = strings.TrimSpace(item)
+		if len(item) > maxChecklistItemTitleLen {
+			return errs.BadReqErr{}
+		}
+		if item != "" {
+			checklist = append(checklist, item)
+		}
+	}
+	template.Checklist = checklist
+
+	return nil
+}
+
+func visibleTemplateVisibilities(user *repository.User) []string {
+	if user.Role == constant.AdminRole {
+		return constant.TemplateVisibilities
+	}
+
+	return []string{constant.AllTemplateVisibility}
+}
@@ -0,0 +1,187 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var releaseTemplate = repository.TaskTemplate{
+	ID:          7,
+	Name:        "Релиз",
+	Title:       "Релиз {{version}}",
+	Description: "Выпустить версию {{ version }} для {{team}}",
+	Priority:    constant.High,
+	Labels:      []string{"release"},
+	Checklist:   []string{"Собрать changelog {{version}}", "Поставить тег"},
+	Visibility:  constant.AllTemplateVisibility,
+}
+
+func TestTaskTemplateService_CreateTask(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskRepo := mockRepository.NewMockITaskRepo(ctrl)
+	userRepo := mockRepository.NewMockIUserRepo(ctrl)
+	labelRepo := mockRepository.NewMockILabelRepo(ctrl)
+	checklistRepo := mockRepository.NewMockIChecklistRepo(ctrl)
+	outboxRepo := mockRepository.NewMockIOutboxRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, taskRepo, userRepo, labelRepo, checklistRepo,
+		outboxRepo, newTransactorMock(ctrl))
+
+	template := releaseTemplate
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&template, nil)
+	userRepo.EXPECT().GetByLogin(gomock.Any(), "user").Return(&repository.User{ID: 2, Login: "user"}, nil)
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, task *repository.Task) (int, error) {
+			require.Equal(t, "Релиз 1.2", task.Title)
+			require.Equal(t, "Выпустить версию 1.2 для backend", task.Description)
+			require.Equal(t, constant.High, task.Priority)
+			require.Equal(t, 2, task.UserID)
+			require.Equal(t, constant.OpenTaskStatus, task.Status)
+			return 15, nil
+		})
+	labelRepo.EXPECT().Add(gomock.Any(), 15, "release").Return(nil)
+	gomock.InOrder(
+		checklistRepo.EXPECT().Create(gomock.Any(),
+			&repository.ChecklistItem{TaskID: 15, Title: "Собрать changelog 1.2"}).Return(1, nil),
+		checklistRepo.EXPECT().Create(gomock.Any(),
+			&repository.ChecklistItem{TaskID: 15, Title: "Поставить тег"}).Return(2, nil),
+	)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, event *repository.OutboxEvent) error {
+			require.Equal(t, constant.TaskCreatedEvent, event.Event)
+			var taskEvent TaskEvent
+			require.NoError(t, json.Unmarshal([]byte(event.Payload), &taskEvent))
+			require.Equal(t, 15, taskEvent.Task.ID)
+			require.Equal(t, 2, taskEvent.ActorID)
+			return nil
+		})
+
+	actor := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	task, err := taskTemplateService.CreateTask(ctx, actor, 7, "user", 0,
+		map[string]string{"version": " 1.2 ", "team": "backend"})
+	require.NoError(t, err)
+	require.Equal(t, 15, task.ID)
+}
+
+func TestTaskTemplateService_CreateTask_MissingPlaceholder(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+
+	template := releaseTemplate
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&template, nil)
+
+	actor := &repository.User{ID: 1, Login: "admin", Role: constant.AdminRole}
+	_, err := taskTemplateService.CreateTask(ctx, actor, 7, "user", 0, map[string]string{"version": "1.2"})
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
+
+func TestTaskTemplateService_CreateTask_ForAnotherUser(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+
+	template := releaseTemplate
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&template, nil)
+
+	actor := &repository.User{ID: 2, Login: "user", Role: constant.UserRole}
+	_, err := taskTemplateService.CreateTask(ctx, actor, 7, "admin", 0,
+		map[string]string{"version": "1.2", "team": "backend"})
+	require.ErrorIs(t, err, errs.ForbiddenErr{})
+}
+
+func TestTaskTemplateService_Get_Visibility(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+
+	adminTemplate := releaseTemplate
+	adminTemplate.Visibility = constant.AdminTemplateVisibility
+	templateRepo.EXPECT().GetByID(gomock.Any(), 7).Return(&adminTemplate, nil).Times(2)
+	templateRepo.EXPECT().GetByID(gomock.Any(), 8).Return(nil, pgx.ErrNoRows)
+
+	_, err := taskTemplateService.Get(ctx, &repository.User{ID: 2, Role: constant.UserRole}, 7)
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+
+	template, err := taskTemplateService.Get(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, 7)
+	require.NoError(t, err)
+	require.Equal(t, 7, template.ID)
+
+	_, err = taskTemplateService.Get(ctx, &repository.User{ID: 1, Role: constant.AdminRole}, 8)
+	require.ErrorIs(t, err, errs.NotFoundErr{})
+}
+
+func TestTaskTemplateService_GetVisible(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+
+	templateRepo.EXPECT().GetByVisibility(gomock.Any(), []string{constant.AllTemplateVisibility}).
+		Return([]repository.TaskTemplate{releaseTemplate}, nil)
+	templateRepo.EXPECT().GetByVisibility(gomock.Any(), constant.TemplateVisibilities).
+		Return([]repository.TaskTemplate{releaseTemplate}, nil)
+
+	_, err := taskTemplateService.GetVisible(ctx, &repository.User{ID: 2, Role: constant.UserRole})
+	require.NoError(t, err)
+	_, err = taskTemplateService.GetVisible(ctx, &repository.User{ID: 1, Role: constant.AdminRole})
+	require.NoError(t, err)
+}
+
+func TestTaskTemplateService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	templateRepo := mockRepository.NewMockITaskTemplateRepo(ctrl)
+	taskTemplateService := NewTaskTemplateService(templateRepo, nil, nil, nil, nil, nil, nil)
+
+	templateRepo.EXPECT().Create(gomock.Any(), &repository.TaskTemplate{
+		Name:        "Онбординг",
+		Title:       "Онбординг {{login}}",
+		Description: "Выдать доступы",
+		Priority:    constant.Medium,
+		Labels:      []string{"onboarding"},
+		Checklist:   []string{"Почта", "VPN"},
+		Visibility:  constant.AllTemplateVisibility,
+	}).Return(nil)
+	templateRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repository.ErrTemplateNameTaken)
+
+	err := taskTemplateService.Create(ctx, &repository.TaskTemplate{
+		Name:        " Онбординг ",
+		Title:       "Онбординг {{login}}",
+		Description: "Выдать доступы",
+		Priority:    constant.Medium,
+		Labels:      []string{"onboarding", " ", "onboarding"},
+		Checklist:   []string{"Почта", "", " VPN "},
+	})
+	require.NoError(t, err)
+
+	err = taskTemplateService.Create(ctx, &repository.TaskTemplate{
+		Name: "Онбординг", Title: "Онбординг", Description: "Выдать доступы", Priority: constant.Medium,
+	})
+	require.ErrorIs(t, err, errs.TemplateExistsErr{})
+
+	err = taskTemplateService.Create(ctx, &repository.TaskTemplate{
+		Name: "Секрет", Title: "Секрет", Description: "Секрет", Priority: constant.Medium, Visibility: "TEAM",
+	})
+	require.ErrorIs(t, err, errs.BadReqErr{})
+}
+
+func TestTemplatePlaceholders(t *testing.T) {
+	require.Equal(t, []string{"version", "team"}, TemplatePlaceholders(&releaseTemplate))
+	require.Empty(t, TemplatePlaceholders(&repository.TaskTemplate{Title: "Без подстановок {{}}"}))
+}
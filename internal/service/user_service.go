@@ -3,25 +3,34 @@ package service
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"strconv"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/constant"
 	"github.com/romakorinenko/task-manager/internal/errs"
 	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/tracing"
 )
 
 type IUserService interface {
 	GetUserRepository() repository.IUserRepo
 	Create(ctx context.Context, user *repository.User) error
+	Block(ctx context.Context, userID string) bool
 	GetByLogin(ctx context.Context, userLogin string) *repository.User
-	GetAll(ctx context.Context) []repository.User
+	GetAll(ctx context.Context) ([]repository.User, error)
 }
 
 type UserService struct {
 	userRepository repository.IUserRepo
+	webhookService IWebhookService
 }
 
-func NewUserService(userRepository repository.IUserRepo) *UserService {
-	return &UserService{userRepository: userRepository}
+func NewUserService(userRepository repository.IUserRepo, webhookService IWebhookService) *UserService {
+	return &UserService{
+		userRepository: userRepository,
+		webhookService: webhookService,
+	}
 }
 
 func (u *UserService) GetUserRepository() repository.IUserRepo {
@@ -29,6 +38,9 @@ func (u *UserService) GetUserRepository() repository.IUserRepo {
 }
 
 func (u *UserService) Create(ctx context.Context, user *repository.User) error {
+	ctx, span := tracing.Start(ctx, "UserService.Create")
+	defer span.End()
+
 	userFromDB, err := u.userRepository.GetByLogin(ctx, user.Login)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return err
@@ -42,19 +54,67 @@ func (u *UserService) Create(ctx context.Context, user *repository.User) error {
 	if createdUser == nil {
 		return errors.New("internal server error. user is not created")
 	}
+	u.publish(ctx, constant.UserCreatedEvent, createdUser)
 
 	return nil
 }
 
+// Block блокирует пользователя и сообщает об этом подписчикам вебхуков.
+func (u *UserService) Block(ctx context.Context, userID string) bool {
+	ctx, span := tracing.Start(ctx, "UserService.Block")
+	defer span.End()
+
+	if !u.userRepository.BlockByID(ctx, userID) {
+		return false
+	}
+
+	ID, err := strconv.Atoi(userID)
+	if err != nil {
+		return true
+	}
+	blockedUser, err := u.userRepository.GetByID(ctx, ID)
+	if err != nil {
+		// пользователя с таким идентификатором нет, блокировать было некого.
+		return true
+	}
+	u.publish(ctx, constant.UserBlockedEvent, blockedUser)
+
+	return true
+}
+
 func (u *UserService) GetByLogin(ctx context.Context, userLogin string) *repository.User {
+	ctx, span := tracing.Start(ctx, "UserService.GetByLogin")
+	defer span.End()
+
 	user, err := u.userRepository.GetByLogin(ctx, userLogin)
 	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.ErrorContext(ctx, "cannot get user", slog.String("login", userLogin), slog.Any("error", err))
+		}
 		return nil
 	}
 
 	return user
 }
 
-func (u *UserService) GetAll(ctx context.Context) []repository.User {
+func (u *UserService) GetAll(ctx context.Context) ([]repository.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAll")
+	defer span.End()
+
 	return u.userRepository.GetAll(ctx)
 }
+
+// publish сообщает о событии подписчикам вебхуков без пароля пользователя. Изменение к этому моменту
+// уже сохранено, поэтому ошибка только логируется.
+func (u *UserService) publish(ctx context.Context, event string, user *repository.User) {
+	withoutPassword := *user
+	withoutPassword.Password = ""
+
+	if err := u.webhookService.Publish(ctx, 0, event, &withoutPassword); err != nil {
+		slog.ErrorContext(ctx, "cannot publish user event",
+			slog.Int("userId", user.ID),
+			slog.String("event", event),
+			slog.Any("error", err),
+		)
+	}
+}
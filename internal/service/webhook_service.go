@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	"github.com/romakorinenko/task-manager/internal/webhook"
+)
+
+const (
+	// deliveryLogLimit - сколько последних доставок показывать в журнале подписки.
+	deliveryLogLimit = 100
+	// maxDeliveryErrorLength - размер колонки last_error.
+	maxDeliveryErrorLength = 500
+)
+
+type IWebhookService interface {
+	Create(ctx context.Context, url string, events []string, secret string) (*repository.Webhook, error)
+	GetAll(ctx context.Context) ([]repository.Webhook, error)
+	Delete(ctx context.Context, webhookID int) error
+	GetDeliveries(ctx context.Context, webhookID int) ([]repository.WebhookDelivery, error)
+	SendTest(ctx context.Context, webhookID int) (*repository.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID, deliveryID int) error
+	Publish(ctx context.Context, eventID int, event string, data any) error
+	DeliverDue(ctx context.Context) error
+	RunDeliveries(ctx context.Context)
+}
+
+type WebhookService struct {
+	webhookRepository repository.IWebhookRepo
+	sender            webhook.ISender
+	cfg               *config.Webhooks
+	wakeup            chan struct{}
+}
+
+func NewWebhookService(
+	webhookRepository repository.IWebhookRepo,
+	sender webhook.ISender,
+	cfg *config.Webhooks,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepository: webhookRepository,
+		sender:            sender,
+		cfg:               cfg,
+		wakeup:            make(chan struct{}, 1),
+	}
+}
+
+// Create регистрирует подписку. Если секрет не передан, он генерируется; секрет возвращается только здесь.
+func (w *WebhookService) Create(ctx context.Context,
+	rawURL string,
+	events []string,
+	secret string,
+) (*repository.Webhook, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, errs.BadReqErr{}
+	}
+	if len(events) == 0 {
+		return nil, errs.BadReqErr{}
+	}
+	for _, event := range events {
+		if !slices.Contains(constant.WebhookEvents, event) {
+			return nil, errs.BadReqErr{}
+		}
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newWebhook := &repository.Webhook{
+		URL:    rawURL,
+		Events: slices.Compact(slices.Sorted(slices.Values(events))),
+		Secret: secret,
+	}
+	if err = w.webhookRepository.Create(ctx, newWebhook); err != nil {
+		return nil, err
+	}
+
+	return newWebhook, nil
+}
+
+// GetAll возвращает подписки без секретов.
+func (w *WebhookService) GetAll(ctx context.Context) ([]repository.Webhook, error) {
+	webhooks, err := w.webhookRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookService) Delete(ctx context.Context, webhookID int) error {
+	found, err := w.webhookRepository.DeleteByID(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.NotFoundErr{}
+	}
+
+	return nil
+}
+
+func (w *WebhookService) GetDeliveries(ctx context.Context, webhookID int) ([]repository.WebhookDelivery, error) {
+	if _, err := w.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return w.webhookRepository.GetDeliveries(ctx, webhookID, deliveryLogLimit)
+}
+
+// SendTest сразу отправляет подписчику тестовое событие и возвращает результат доставки.
+// При неудаче доставка повторяется по общим правилам.
+func (w *WebhookService) SendTest(ctx context.Context, webhookID int) (*repository.WebhookDelivery, error) {
+	subscription, err := w.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(webhook.Payload{
+		Event:      constant.TestWebhookEvent,
+		OccurredAt: time.Now(),
+		Data:       map[string]int{"webhookId": webhookID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// первую попытку делаем здесь, поэтому фоновый обработчик не должен взять доставку раньше времени повтора.
+	delivery := &repository.WebhookDelivery{
+		WebhookID:     webhookID,
+		Event:         constant.TestWebhookEvent,
+		Payload:       string(body),
+		Status:        constant.PendingDeliveryStatus,
+		NextAttemptAt: time.Now().Add(w.cfg.RetryBackoff),
+	}
+	if err = w.webhookRepository.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	if err = w.deliver(ctx, subscription, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Redeliver возвращает доставку из статуса DEAD в очередь с полным числом попыток.
+func (w *WebhookService) Redeliver(ctx context.Context, webhookID, deliveryID int) error {
+	delivery, err := w.webhookRepository.GetDeliveryByID(ctx, deliveryID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return errs.NotFoundErr{}
+	} else if err != nil {
+		return err
+	}
+	if delivery.WebhookID != webhookID {
+		return errs.NotFoundErr{}
+	}
+	if delivery.Status != constant.DeadDeliveryStatus {
+		return errs.BadReqErr{}
+	}
+
+	delivery.Status = constant.PendingDeliveryStatus
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err = w.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	w.wake()
+
+	return nil
+}
+
+// Publish ставит событие в очередь доставки всем подписчикам. eventID - событие outbox, 0 для событий не из outbox;
+// при повторной обработке события outbox уже созданные доставки не дублируются.
+func (w *WebhookService) Publish(ctx context.Context, eventID int, event string, data any) error {
+	webhooks, err := w.webhookRepository.GetByEvent(ctx, event)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	body, err := json.Marshal(webhook.Payload{Event: event, OccurredAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range webhooks {
+		err = w.webhookRepository.CreateDelivery(ctx, &repository.WebhookDelivery{
+			WebhookID:     subscription.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       string(body),
+			Status:        constant.PendingDeliveryStatus,
+			NextAttemptAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.wake()
+
+	return nil
+}
+
+// DeliverDue отправляет доставки, время попытки которых наступило.
+func (w *WebhookService) DeliverDue(ctx context.Context) error {
+	deliveries, err := w.webhookRepository.GetDueDeliveries(ctx, time.Now(), w.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	webhooks := make(map[int]*repository.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		subscription, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			subscription, err = w.webhookRepository.GetByID(ctx, delivery.WebhookID)
+			if err != nil {
+				return err
+			}
+			webhooks[delivery.WebhookID] = subscription
+		}
+
+		if err = w.deliver(ctx, subscription, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunDeliveries отправляет доставки каждые pollInterval и сразу после публикации событий, пока не отменён ctx.
+func (w *WebhookService) RunDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.DeliverDue(ctx); err != nil {
+			slog.Error("cannot deliver webhooks", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wakeup:
+		}
+	}
+}
+
+// deliver делает одну попытку доставки и сохраняет её результат. После maxAttempts неудачных попыток
+// доставка переходит в статус DEAD, до этого пауза перед следующей попыткой удваивается.
+func (w *WebhookService) deliver(ctx context.Context,
+	subscription *repository.Webhook,
+	delivery *repository.WebhookDelivery,
+) error {
+	responseStatus, err := w.sender.Send(ctx, webhook.Request{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		Event:      delivery.Event,
+		DeliveryID: delivery.ID,
+		Body:       []byte(delivery.Payload),
+	})
+
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	switch {
+	case err == nil:
+		delivery.Status = constant.DeliveredDeliveryStatus
+		delivery.LastError = ""
+	case delivery.Attempts >= w.cfg.MaxAttempts:
+		delivery.Status = constant.DeadDeliveryStatus
+		delivery.LastError = truncate(err.Error(), maxDeliveryErrorLength)
+	default:
+		delivery.LastError = truncate(err.Error(), maxDeliveryErrorLength)
+		delivery.NextAttemptAt = time.Now().Add(w.cfg.RetryBackoff << (delivery.Attempts - 1))
+	}
+
+	return w.webhookRepository.UpdateDelivery(ctx, delivery)
+}
+
+func (w *WebhookService) getWebhook(ctx context.Context, webhookID int) (*repository.Webhook, error) {
+	subscription, err := w.webhookRepository.GetByID(ctx, webhookID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, errs.NotFoundErr{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// wake будит обработчик доставок, не дожидаясь очередного интервала опроса.
+func (w *WebhookService) wake() {
+	select {
+	case w.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+func truncate(s string, maxLength int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+
+	return string(runes[:maxLength])
+}
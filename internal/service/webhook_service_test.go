@@ -0,0 +1,233 @@
+//go:build unit && !integration
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/romakorinenko/task-manager/internal/constant"
+	"github.com/romakorinenko/task-manager/internal/errs"
+	"github.com/romakorinenko/task-manager/internal/repository"
+	mockRepository "github.com/romakorinenko/task-manager/internal/repository/mocks"
+	"github.com/romakorinenko/task-manager/internal/webhook"
+	mockWebhook "github.com/romakorinenko/task-manager/internal/webhook/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var webhookConfig = &config.Webhooks{MaxAttempts: 3, RetryBackoff: time.Minute, BatchSize: 10}
+
+func TestWebhookService_Create_SecretGenerated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, w *repository.Webhook) error {
+			w.ID = 1
+			return nil
+		})
+
+	created, err := webhookService.Create(ctx, "https://ci.example.com/hooks",
+		[]string{constant.TaskUpdatedEvent, constant.TaskCreatedEvent, constant.TaskUpdatedEvent}, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, created.ID)
+	require.Equal(t, []string{constant.TaskCreatedEvent, constant.TaskUpdatedEvent}, created.Events)
+	require.Len(t, created.Secret, 64)
+}
+
+func TestWebhookService_Create_InvalidURL(t *testing.T) {
+	ctx := context.Background()
+	webhookService := NewWebhookService(nil, nil, webhookConfig)
+
+	_, err := webhookService.Create(ctx, "ftp://ci.example.com", []string{constant.TaskCreatedEvent}, "")
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestWebhookService_Create_UnknownEvent(t *testing.T) {
+	ctx := context.Background()
+	webhookService := NewWebhookService(nil, nil, webhookConfig)
+
+	_, err := webhookService.Create(ctx, "https://ci.example.com", []string{constant.TestWebhookEvent}, "")
+	require.Equal(t, errs.BadReqErr{}, err)
+}
+
+func TestWebhookService_GetAll_SecretsHidden(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetAll(gomock.Any()).Return([]repository.Webhook{{ID: 1, Secret: "secret"}}, nil)
+
+	webhooks, err := webhookService.GetAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "", webhooks[0].Secret)
+}
+
+func TestWebhookService_Publish_DeliveriesCreated(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetByEvent(gomock.Any(), constant.TaskCreatedEvent).
+		Return([]repository.Webhook{{ID: 1}, {ID: 2}}, nil)
+	var webhookIDs []int
+	webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, constant.PendingDeliveryStatus, delivery.Status)
+			require.Equal(t, 3, delivery.EventID)
+			require.Contains(t, delivery.Payload, `"event":"task.created"`)
+			require.Contains(t, delivery.Payload, `"title":"Release"`)
+			webhookIDs = append(webhookIDs, delivery.WebhookID)
+			return nil
+		}).Times(2)
+
+	err := webhookService.Publish(ctx, 3, constant.TaskCreatedEvent, &repository.Task{ID: 5, Title: "Release"})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, webhookIDs)
+}
+
+func TestWebhookService_Publish_RepositoryErr(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetByEvent(gomock.Any(), constant.TaskCreatedEvent).Return([]repository.Webhook{{ID: 1}}, nil)
+	webhookRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).Return(errors.New(""))
+
+	err := webhookService.Publish(ctx, 3, constant.TaskCreatedEvent, &repository.Task{ID: 5})
+	require.Error(t, err)
+}
+
+func TestWebhookService_DeliverDue_Delivered(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	sender := mockWebhook.NewMockISender(ctrl)
+	webhookService := NewWebhookService(webhookRepo, sender, webhookConfig)
+
+	webhookRepo.EXPECT().GetDueDeliveries(gomock.Any(), gomock.Any(), 10).Return([]repository.WebhookDelivery{
+		{ID: 7, WebhookID: 1, Event: constant.TaskCreatedEvent, Payload: `{}`, Status: constant.PendingDeliveryStatus},
+	}, nil)
+	webhookRepo.EXPECT().GetByID(gomock.Any(), 1).
+		Return(&repository.Webhook{ID: 1, URL: "https://ci.example.com", Secret: "secret"}, nil)
+	sender.EXPECT().Send(gomock.Any(), webhook.Request{
+		URL:        "https://ci.example.com",
+		Secret:     "secret",
+		Event:      constant.TaskCreatedEvent,
+		DeliveryID: 7,
+		Body:       []byte(`{}`),
+	}).Return(200, nil)
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, constant.DeliveredDeliveryStatus, delivery.Status)
+			require.Equal(t, 1, delivery.Attempts)
+			require.Equal(t, 200, delivery.ResponseStatus)
+			return nil
+		})
+
+	err := webhookService.DeliverDue(ctx)
+	require.NoError(t, err)
+}
+
+func TestWebhookService_DeliverDue_RetryScheduled(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	sender := mockWebhook.NewMockISender(ctrl)
+	webhookService := NewWebhookService(webhookRepo, sender, webhookConfig)
+
+	webhookRepo.EXPECT().GetDueDeliveries(gomock.Any(), gomock.Any(), 10).Return([]repository.WebhookDelivery{
+		{ID: 7, WebhookID: 1, Status: constant.PendingDeliveryStatus, Attempts: 1},
+	}, nil)
+	webhookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Webhook{ID: 1}, nil)
+	sender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(503, errors.New("unexpected response status 503"))
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, constant.PendingDeliveryStatus, delivery.Status)
+			require.Equal(t, 2, delivery.Attempts)
+			require.Equal(t, "unexpected response status 503", delivery.LastError)
+			// вторая неудачная попытка - пауза удваивается.
+			require.WithinDuration(t, time.Now().Add(2*time.Minute), delivery.NextAttemptAt, time.Second)
+			return nil
+		})
+
+	err := webhookService.DeliverDue(ctx)
+	require.NoError(t, err)
+}
+
+func TestWebhookService_DeliverDue_DeadAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	sender := mockWebhook.NewMockISender(ctrl)
+	webhookService := NewWebhookService(webhookRepo, sender, webhookConfig)
+
+	webhookRepo.EXPECT().GetDueDeliveries(gomock.Any(), gomock.Any(), 10).Return([]repository.WebhookDelivery{
+		{ID: 7, WebhookID: 1, Status: constant.PendingDeliveryStatus, Attempts: 2},
+	}, nil)
+	webhookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&repository.Webhook{ID: 1}, nil)
+	sender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(0, errors.New("connection refused"))
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, constant.DeadDeliveryStatus, delivery.Status)
+			require.Equal(t, 3, delivery.Attempts)
+			return nil
+		})
+
+	err := webhookService.DeliverDue(ctx)
+	require.NoError(t, err)
+}
+
+func TestWebhookService_SendTest_WebhookNotFound(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	_, err := webhookService.SendTest(ctx, 1)
+	require.Equal(t, errs.NotFoundErr{}, err)
+}
+
+func TestWebhookService_Redeliver_DeliveryRequeued(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetDeliveryByID(gomock.Any(), 7).
+		Return(&repository.WebhookDelivery{ID: 7, WebhookID: 1, Status: constant.DeadDeliveryStatus, Attempts: 3}, nil)
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, delivery *repository.WebhookDelivery) error {
+			require.Equal(t, constant.PendingDeliveryStatus, delivery.Status)
+			require.Equal(t, 0, delivery.Attempts)
+			return nil
+		})
+
+	err := webhookService.Redeliver(ctx, 1, 7)
+	require.NoError(t, err)
+}
+
+func TestWebhookService_Redeliver_NotDead(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	webhookRepo := mockRepository.NewMockIWebhookRepo(ctrl)
+	webhookService := NewWebhookService(webhookRepo, nil, webhookConfig)
+
+	webhookRepo.EXPECT().GetDeliveryByID(gomock.Any(), 7).
+		Return(&repository.WebhookDelivery{ID: 7, WebhookID: 1, Status: constant.DeliveredDeliveryStatus}, nil)
+
+	err := webhookService.Redeliver(ctx, 1, 7)
+	require.Equal(t, errs.BadReqErr{}, err)
+}
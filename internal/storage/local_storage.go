@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type LocalStorage struct {
+	baseDir string
+}
+
+func NewLocalStorage(baseDir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{baseDir: baseDir}, nil
+}
+
+func (l *LocalStorage) Put(_ context.Context, key string, reader io.Reader, _ int64, _ string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o750); mkdirErr != nil {
+		return mkdirErr
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	if _, copyErr := io.Copy(tmpFile, reader); copyErr != nil {
+		_ = tmpFile.Close()
+		return copyErr
+	}
+	if closeErr := tmpFile.Close(); closeErr != nil {
+		return closeErr
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (l *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+func (l *LocalStorage) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *LocalStorage) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(l.baseDir, filepath.FromSlash(key)), nil
+}
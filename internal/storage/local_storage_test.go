@@ -0,0 +1,51 @@
+//go:build unit && !integration
+
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	localStorage, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	err = localStorage.Put(ctx, "tasks/1/10", strings.NewReader("content"), 7, "text/plain")
+	require.NoError(t, err)
+
+	reader, err := localStorage.Get(ctx, "tasks/1/10")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "content", string(content))
+
+	require.NoError(t, localStorage.Delete(ctx, "tasks/1/10"))
+	_, err = localStorage.Get(ctx, "tasks/1/10")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, localStorage.Delete(ctx, "tasks/1/10"))
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	baseDir := t.TempDir()
+	localStorage, err := NewLocalStorage(filepath.Join(baseDir, "attachments"))
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "tasks/../../outside", "tasks//1"} {
+		err = localStorage.Put(ctx, key, strings.NewReader("content"), 7, "text/plain")
+		require.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+
+	_, err = os.Stat(filepath.Join(baseDir, "outside"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
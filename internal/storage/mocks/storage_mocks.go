@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage.go
+//
+// Generated by this command:
+//
+//	mockgen -source=storage.go -destination=mocks/storage_mocks.go
+//
+
+// Package mock_storage is a generated GoMock package.
+package mock_storage
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIStorage is a mock of IStorage interface.
+type MockIStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStorageMockRecorder
+}
+
+// MockIStorageMockRecorder is the mock recorder for MockIStorage.
+type MockIStorageMockRecorder struct {
+	mock *MockIStorage
+}
+
+// NewMockIStorage creates a new mock instance.
+func NewMockIStorage(ctrl *gomock.Controller) *MockIStorage {
+	mock := &MockIStorage{ctrl: ctrl}
+	mock.recorder = &MockIStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStorage) EXPECT() *MockIStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIStorageMockRecorder) Delete(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIStorage)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockIStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIStorageMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIStorage)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockIStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, reader, size, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIStorageMockRecorder) Put(ctx, key, reader, size, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIStorage)(nil).Put), ctx, key, reader, size, contentType)
+}
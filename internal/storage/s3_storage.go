@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/romakorinenko/task-manager/internal/config"
+)
+
+// S3Storage работает с любым S3-совместимым хранилищем (AWS S3, MinIO и т.п.).
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Storage(ctx context.Context, cfg *config.S3) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if makeBucketErr := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{}); makeBucketErr != nil {
+			return nil, makeBucketErr
+		}
+	}
+
+	return &S3Storage{client: client, bucket: cfg.Bucket}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject ленивый: ошибка отсутствия объекта появляется только при первом обращении.
+	if _, statErr := object.Stat(); statErr != nil {
+		_ = object.Close()
+		return nil, statErr
+	}
+
+	return object, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,47 @@
+//go:build integration && !unit
+
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Storage(t *testing.T) {
+	ctx := context.Background()
+	minio := test.RunMinioContainer(t)
+	defer minio.Close()
+
+	s3Storage, err := NewS3Storage(ctx, minio.Config)
+	require.NoError(t, err)
+
+	t.Run("файл сохраняется и читается", func(t *testing.T) {
+		err := s3Storage.Put(ctx, "tasks/1/10", strings.NewReader("content"), 7, "text/plain")
+		require.NoError(t, err)
+
+		reader, err := s3Storage.Get(ctx, "tasks/1/10")
+		require.NoError(t, err)
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(content))
+	})
+	t.Run("удалённый файл недоступен", func(t *testing.T) {
+		err := s3Storage.Put(ctx, "tasks/1/11", strings.NewReader("content"), 7, "text/plain")
+		require.NoError(t, err)
+
+		require.NoError(t, s3Storage.Delete(ctx, "tasks/1/11"))
+
+		_, err = s3Storage.Get(ctx, "tasks/1/11")
+		require.Error(t, err)
+	})
+	t.Run("бакет не пересоздаётся при повторном подключении", func(t *testing.T) {
+		_, err := NewS3Storage(ctx, minio.Config)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+package storage
+
+//go:generate mockgen -source=storage.go -destination=mocks/storage_mocks.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+)
+
+const (
+	LocalStorageType = "local"
+	S3StorageType    = "s3"
+)
+
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// IStorage хранит содержимое вложений по ключу вида "tasks/{taskID}/{attachmentID}".
+type IStorage interface {
+	Put(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewStorage(ctx context.Context, cfg *config.Attachments) (IStorage, error) {
+	switch cfg.Storage {
+	case LocalStorageType, "":
+		return NewLocalStorage(cfg.LocalDir)
+	case S3StorageType:
+		return NewS3Storage(ctx, cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown attachments storage %q", cfg.Storage)
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
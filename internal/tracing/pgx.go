@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer создаёт спан на каждый SQL-запрос через пул. Текст запроса попадает в атрибут db.query.text,
+// значения параметров не записываются: в них бывают пароли и персональные данные.
+type QueryTracer struct{}
+
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{}
+}
+
+func (q *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = otel.Tracer(tracerName).Start(ctx, queryOperation(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBQueryText(data.SQL)),
+	)
+
+	return ctx
+}
+
+func (q *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// queryOperation - имя спана запроса: первое слово SQL (SELECT, INSERT, ...).
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,84 @@
+//go:build unit && !integration
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func setUpRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	return recorder
+}
+
+func TestInit_Disabled(t *testing.T) {
+	shutdown, err := Init(context.Background(), &config.Tracing{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestMiddleware_ServiceAndQuerySpansNested(t *testing.T) {
+	recorder := setUpRecorder(t)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware)
+	router.GET("/tasks/:id", func(c *gin.Context) {
+		ctx, span := Start(c.Request.Context(), "TaskService.GetByID")
+		tracer := NewQueryTracer()
+		queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "select * from tasks where id = $1"})
+		tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{Err: errors.New("timeout")})
+		span.End()
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/5", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	query, service, server := spans[0], spans[1], spans[2]
+
+	require.Equal(t, "GET /tasks/:id", server.Name())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	require.Contains(t, server.Attributes(), attribute.String("http.route", "/tasks/:id"))
+	require.Contains(t, server.Attributes(), attribute.Int("http.response.status_code", 500))
+	require.Equal(t, codes.Error, server.Status().Code)
+
+	require.Equal(t, "TaskService.GetByID", service.Name())
+	require.Equal(t, server.SpanContext().SpanID(), service.Parent().SpanID())
+
+	require.Equal(t, "SELECT", query.Name())
+	require.Equal(t, service.SpanContext().SpanID(), query.Parent().SpanID())
+	require.Contains(t, query.Attributes(), attribute.String("db.query.text", "select * from tasks where id = $1"))
+	require.Equal(t, codes.Error, query.Status().Code)
+}
+
+func TestQueryOperation(t *testing.T) {
+	require.Equal(t, "INSERT", queryOperation("\n insert into tasks values ($1)"))
+	require.Equal(t, "SQL", queryOperation(" "))
+}
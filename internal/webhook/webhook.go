@@ -0,0 +1,85 @@
+package webhook
+
+//go:generate mockgen -source=webhook.go -destination=mocks/webhook_mocks.go
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Payload - тело запроса подписчику. Data - задача или пользователь, с которыми произошло событие.
+type Payload struct {
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID int
+	Body       []byte
+}
+
+type ISender interface {
+	// Send возвращает HTTP-статус ответа подписчика (0, если ответа нет) и ошибку, если событие не доставлено.
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+// HTTPSender отправляет события POST-запросом с JSON-телом, подписанным HMAC-SHA256.
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (h *HTTPSender) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "task-manager-webhooks")
+	httpReq.Header.Set(EventHeader, req.Event)
+	httpReq.Header.Set(DeliveryHeader, strconv.Itoa(req.DeliveryID))
+	httpReq.Header.Set(SignatureHeader, Sign(req.Secret, req.Body))
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign возвращает подпись тела запроса в виде "sha256=<hex>". Подписчик проверяет её, вычисляя
+// HMAC-SHA256 от тела запроса с тем же секретом.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
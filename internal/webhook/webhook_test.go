@@ -0,0 +1,58 @@
+//go:build unit && !integration
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// значение из документации GitHub по проверке подписи вебхуков.
+	require.Equal(t,
+		"sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17",
+		Sign("It's a Secret to Everybody", []byte("Hello, World!")),
+	)
+}
+
+func TestHTTPSender_Send_SignedRequestDelivered(t *testing.T) {
+	body := []byte(`{"event":"task.created"}`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, body, received)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "task.created", r.Header.Get(EventHeader))
+		require.Equal(t, "7", r.Header.Get(DeliveryHeader))
+		require.Equal(t, Sign("secret", body), r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	status, err := NewHTTPSender(time.Second).Send(context.Background(), Request{
+		URL:        server.URL,
+		Secret:     "secret",
+		Event:      "task.created",
+		DeliveryID: 7,
+		Body:       body,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, status)
+}
+
+func TestHTTPSender_Send_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	status, err := NewHTTPSender(time.Second).Send(context.Background(), Request{URL: server.URL, Body: []byte(`{}`)})
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, status)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/romakorinenko/task-manager/internal/markdown"
 )
 
 func SetUpTestRouter() *gin.Engine {
@@ -20,6 +21,7 @@ func SetUpTestRouter() *gin.Engine {
 	router := gin.Default()
 	store := sessions.NewCookieStore([]byte("secret"))
 	router.Use(sessions.Sessions("sessions", store))
+	router.SetFuncMap(markdown.TemplateFuncs())
 	router.LoadHTMLGlob(projectDir + "/internal/server/templates/*.html")
 
 	return router
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type CapturedMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// SMTPCaptureServer - минимальный SMTP-сервер для тестов: принимает письма и сохраняет их в памяти.
+type SMTPCaptureServer struct {
+	Config *config.Email
+
+	listener  net.Listener
+	mu        sync.Mutex
+	failFirst int
+	attempts  int
+	mails     []CapturedMail
+}
+
+func RunSMTPCaptureServer(t *testing.T) *SMTPCaptureServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &SMTPCaptureServer{
+		Config: &config.Email{
+			Enabled:     true,
+			Host:        "127.0.0.1",
+			Port:        listener.Addr().(*net.TCPAddr).Port,
+			From:        "Task Manager <task-manager@localhost>",
+			BaseURL:     "http://localhost:8080",
+			QueueSize:   10,
+			MaxAttempts: 1,
+		},
+		listener: listener,
+	}
+	go server.serve()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	return server
+}
+
+// FailFirst заставляет сервер отклонять временной ошибкой первые n попыток отправки, чтобы проверять повторы.
+func (s *SMTPCaptureServer) FailFirst(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failFirst = n
+}
+
+func (s *SMTPCaptureServer) Mails() []CapturedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]CapturedMail(nil), s.mails...)
+}
+
+func (s *SMTPCaptureServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(textproto.NewConn(conn))
+	}
+}
+
+func (s *SMTPCaptureServer) handle(conn *textproto.Conn) {
+	defer conn.Close()
+
+	var mail CapturedMail
+	reply := func(line string) bool {
+		return conn.PrintfLine("%s", line) == nil
+	}
+	if !reply("220 localhost ESMTP capture") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch command {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			s.mu.Lock()
+			s.attempts++
+			fail := s.attempts <= s.failFirst
+			s.mu.Unlock()
+			if fail {
+				reply("451 try again later")
+				continue
+			}
+			mail = CapturedMail{From: addressOf(line)}
+			reply("250 OK")
+		case "RCPT":
+			mail.To = append(mail.To, addressOf(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, readErr := conn.ReadDotBytes()
+			if readErr != nil {
+				return
+			}
+			mail.Data = string(data)
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func addressOf(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return line[start+1 : end]
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/romakorinenko/task-manager/internal/config"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type MinioContainer struct {
+	Container testcontainers.Container
+	Config    *config.S3
+}
+
+func RunMinioContainer(t *testing.T) *MinioContainer {
+	t.Helper()
+
+	ctx := context.Background()
+
+	accessKey := "test_minio_user"
+	secretKey := "test_minio_password123321"
+
+	minioContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "minio/minio:RELEASE.2024-01-16T16-07-38Z",
+			ExposedPorts: []string{"9000/tcp"},
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     accessKey,
+				"MINIO_ROOT_PASSWORD": secretKey,
+			},
+			Cmd: []string{"server", "/data"},
+			WaitingFor: wait.ForHTTP("/minio/health/live").
+				WithPort("9000/tcp").
+				WithStartupTimeout(30 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	host, err := minioContainer.Host(ctx)
+	require.NoError(t, err)
+	port, err := minioContainer.MappedPort(ctx, "9000/tcp")
+	require.NoError(t, err)
+
+	return &MinioContainer{
+		Container: minioContainer,
+		Config: &config.S3{
+			Endpoint:  fmt.Sprintf("%s:%s", host, port.Port()),
+			AccessKey: accessKey,
+			SecretKey: secretKey,
+			Bucket:    "test-attachments",
+		},
+	}
+}
+
+func (m *MinioContainer) Close() {
+	err := m.Container.Terminate(context.Background())
+	if err != nil {
+		fmt.Println(err)
+	}
+}